@@ -2,14 +2,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/silouanwright/gh-scout/internal/analysis"
 	"github.com/silouanwright/gh-scout/internal/github"
 	"github.com/silouanwright/gh-scout/internal/search"
 )
@@ -19,22 +22,51 @@ var (
 	batchClient github.GitHubAPI
 	// Rate limiter for intelligent retry and delay logic
 	batchRateLimiter *github.RateLimiter
+	// Token bucket shared by all concurrent batch searches
+	batchTokenBucket *github.TokenBucket
+	// Clients and token buckets for searches on other hosts, keyed by hostname
+	batchHostClients map[string]github.GitHubAPI
+	batchHostBuckets map[string]*github.TokenBucket
+	batchHostMutex   sync.Mutex
+
+	// Batch flags
+	batchConcurrency     int
+	batchContinueOnError bool
+	batchResume          string
+	batchFormat          string
+	batchColumns         []string
+	batchOutputDir       string
+)
+
+const (
+	// defaultBatchConcurrency is how many batch searches run at once by default
+	defaultBatchConcurrency = 3
+	// maxBatchConcurrency bounds concurrency to stay clear of secondary rate limits
+	maxBatchConcurrency = 10
+	// maxBatchRetries bounds per-search retry budgets
+	maxBatchRetries = 10
+	// defaultBatchMaxResults is the result limit for searches without max_results
+	defaultBatchMaxResults = 50
 )
 
 // BatchConfig represents the structure of a batch search configuration file
 type BatchConfig struct {
-	Name        string              `yaml:"name,omitempty"`
-	Description string              `yaml:"description,omitempty"`
-	Output      BatchOutputConfig   `yaml:"output,omitempty"`
-	Searches    []BatchSearchConfig `yaml:"searches"`
+	Name            string              `yaml:"name,omitempty"`
+	Description     string              `yaml:"description,omitempty"`
+	Concurrency     int                 `yaml:"concurrency,omitempty"`       // Searches run at once (default 3)
+	ContinueOnError bool                `yaml:"continue_on_error,omitempty"` // Keep going when a search fails
+	Output          BatchOutputConfig   `yaml:"output,omitempty"`
+	Searches        []BatchSearchConfig `yaml:"searches"`
 }
 
 // BatchOutputConfig represents output configuration for batch searches
 type BatchOutputConfig struct {
-	Format    string `yaml:"format"`    // "combined", "separate", "comparison"
-	Directory string `yaml:"directory"` // Output directory
-	Compare   bool   `yaml:"compare"`   // Enable comparison mode
-	Aggregate bool   `yaml:"aggregate"` // Combine results
+	Format    string   `yaml:"format"`    // "combined", "separate", "comparison"
+	Type      string   `yaml:"type"`      // "markdown", "json", "csv", "tsv", "html", "sarif"
+	Columns   []string `yaml:"columns"`   // csv and tsv columns, after the search name
+	Directory string   `yaml:"directory"` // Output directory (stdout when empty)
+	Compare   bool     `yaml:"compare"`   // Enable comparison mode
+	Aggregate bool     `yaml:"aggregate"` // Combine results
 }
 
 // BatchSearchConfig represents individual search configuration
@@ -44,6 +76,8 @@ type BatchSearchConfig struct {
 	Filters    search.SearchFilters `yaml:"filters"`
 	MaxResults int                  `yaml:"max_results,omitempty"`
 	Tags       []string             `yaml:"tags,omitempty"`
+	Retries    *int                 `yaml:"retries,omitempty"` // Retry budget (default from rate limiter)
+	Host       string               `yaml:"host,omitempty"`    // GitHub host to search (default: --hostname or github.host)
 }
 
 // BatchResults holds aggregated results from multiple searches
@@ -52,6 +86,7 @@ type BatchResults struct {
 	Description  string                  `json:"description"`
 	SearchCount  int                     `json:"search_count"`
 	TotalResults int                     `json:"total_results"`
+	FailedCount  int                     `json:"failed_count,omitempty"`
 	Results      []BatchSearchResult     `json:"results"`
 	Comparisons  []BatchComparisonResult `json:"comparisons,omitempty"`
 }
@@ -63,6 +98,8 @@ type BatchSearchResult struct {
 	Tags        []string              `json:"tags,omitempty"`
 	ResultCount int                   `json:"result_count"`
 	Results     *github.SearchResults `json:"results"`
+	Error       string                `json:"error,omitempty"` // Set when the search failed
+	Patterns    *analysis.Report      `json:"patterns,omitempty"`
 }
 
 // BatchComparisonResult holds comparison analysis between searches
@@ -72,6 +109,9 @@ type BatchComparisonResult struct {
 	CommonPatterns []string `json:"common_patterns"`
 	KeyDifferences []string `json:"key_differences"`
 	Summary        string   `json:"summary"`
+
+	// Analysis holds the data the patterns and differences are derived from
+	Analysis *BatchComparisonAnalysis `json:"analysis,omitempty"`
 }
 
 var batchCmd = &cobra.Command{
@@ -93,6 +133,22 @@ var batchCmd = &cobra.Command{
 		- Define multiple searches with different filters
 		- Aggregate and compare results automatically
 		- Export to various formats (JSON, Markdown, etc.)
+
+		Output layouts (output.format):
+		- combined:   a single report with every search
+		- separate:   one file per search, named after the search
+		- comparison: a side-by-side matrix of the searches
+
+		Each layout can be written as markdown, json, csv or html (output.type
+		or --format). Combined and separate reports can also be written as
+		sarif, with one rule per search, for code scanning tools. Reports are
+		written to output.directory, or printed when no directory is set
+		(separate always writes files).
+
+		Progress is checkpointed to a state file after every page, next to the
+		output directory (or the config file). If a run is interrupted or hits a
+		rate limit, continue it with --resume: finished searches are skipped and
+		unfinished ones continue from the page where they stopped.
 	`),
 	Example: heredoc.Doc(`
 		# Execute batch search from config
@@ -106,32 +162,91 @@ var batchCmd = &cobra.Command{
 
 		# Override output format
 		$ gh scout batch config.yaml --format json
+
+		# Write HTML reports into a directory
+		$ gh scout batch config.yaml --format html --output-dir reports
+
+		# Write a SARIF log for code scanning tools
+		$ gh scout batch organization-audit.yaml --format sarif --output-dir audit
+
+		# Run 5 searches at once and report failures instead of stopping
+		$ gh scout batch config.yaml --concurrency 5 --continue-on-error
+
+		# Resume an interrupted run from its state file
+		$ gh scout batch --resume security-audit.batch-state.json
 	`),
-	Args: cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		// The state file records its config, so resuming needs no argument
+		if batchResume != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runBatch,
 }
 
 func init() {
 	rootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().IntVar(&batchConcurrency, "concurrency", 0, "searches to run at once, up to 10 (overrides config, default 3)")
+	batchCmd.Flags().BoolVar(&batchContinueOnError, "continue-on-error", false, "keep running when a search fails and mark it in the output")
+	batchCmd.Flags().StringVar(&batchResume, "resume", "", "resume an interrupted run from its state file")
+	batchCmd.Flags().StringVar(&batchFormat, "format", "", "report file format: markdown, json, csv, tsv, html, sarif (overrides output.type)")
+	batchCmd.Flags().StringSliceVar(&batchColumns, "columns", nil, "csv/tsv columns: repo, path, url, stars, forks, language, pushed_at, score, sha, fragment (overrides output.columns)")
+	batchCmd.Flags().StringVar(&batchOutputDir, "output-dir", "", "directory to write reports to (overrides output.directory)")
 }
 
 func runBatch(cmd *cobra.Command, args []string) error {
+	if err := validateBatchConcurrency("--concurrency", batchConcurrency); err != nil {
+		return err
+	}
+
 	// Initialize client if not set (production use)
 	if batchClient == nil {
-		client, err := createGitHubClient()
+		client, err := createGitHubClient(hostname)
 		if err != nil {
 			return fmt.Errorf("failed to create GitHub client: %w", err)
 		}
 		batchClient = client
 	}
 
+	// Load the state of an interrupted run when resuming
+	var configFile string
+	if len(args) > 0 {
+		configFile = args[0]
+	}
+	var resumed *BatchState
+	if batchResume != "" {
+		state, err := readBatchState(batchResume)
+		if err != nil {
+			return fmt.Errorf("failed to read state file: %w", err)
+		}
+		resumed = state
+		if configFile == "" {
+			configFile = state.ConfigFile
+		}
+	}
+
 	// Read and validate batch configuration
-	configFile := args[0]
 	config, err := readBatchConfig(configFile)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// Command-line output settings override the config file
+	if batchFormat != "" {
+		config.Output.Type = batchFormat
+	}
+	if len(batchColumns) > 0 {
+		config.Output.Columns = batchColumns
+	}
+	if batchOutputDir != "" {
+		config.Output.Directory = batchOutputDir
+	}
+	if err := validateBatchOutput(&config.Output); err != nil {
+		return err
+	}
+
 	// Handle verbose output and dry run
 	if verbose {
 		fmt.Printf("Configuration: %s\n", configFile)
@@ -139,6 +254,9 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		if config.Output.Compare {
 			fmt.Printf("Comparison mode: enabled\n")
 		}
+		if resumed != nil {
+			fmt.Printf("Resuming from: %s\n", batchResume)
+		}
 		fmt.Println()
 	}
 
@@ -146,8 +264,14 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return showDryRunInfo(config, configFile)
 	}
 
+	statePath := batchResume
+	if statePath == "" {
+		statePath = defaultBatchStatePath(configFile, config)
+	}
+	checkpoint := newBatchCheckpoint(statePath, configFile, resumed)
+
 	// Execute batch processing
-	return executeBatchSearches(cmd.Context(), config)
+	return executeBatchSearches(cmd.Context(), config, checkpoint)
 }
 
 // readBatchConfig reads and validates the batch configuration file
@@ -171,23 +295,37 @@ func readBatchConfig(configFile string) (*BatchConfig, error) {
 	}
 
 	// Validate searches
+	seen := make(map[string]bool)
 	for i, searchConfig := range config.Searches {
 		if searchConfig.Name == "" {
 			return nil, fmt.Errorf("search %d: name is required", i+1)
 		}
+		// Names identify output files and checkpoint entries
+		if seen[searchConfig.Name] {
+			return nil, fmt.Errorf("search %d: duplicate name %q", i+1, searchConfig.Name)
+		}
+		seen[searchConfig.Name] = true
 		if searchConfig.Query == "" {
 			return nil, fmt.Errorf("search %d: query is required", i+1)
 		}
 
 		// Set default max results if not specified
 		if searchConfig.MaxResults == 0 {
-			config.Searches[i].MaxResults = 50 // Default limit
+			config.Searches[i].MaxResults = defaultBatchMaxResults
+		}
+
+		if searchConfig.Retries != nil && (*searchConfig.Retries < 0 || *searchConfig.Retries > maxBatchRetries) {
+			return nil, fmt.Errorf("search %d: retries must be between 0 and %d", i+1, maxBatchRetries)
 		}
 	}
 
-	// Set default output format if not specified
-	if config.Output.Format == "" {
-		config.Output.Format = "combined"
+	if err := validateBatchConcurrency("concurrency", config.Concurrency); err != nil {
+		return nil, err
+	}
+
+	// Apply output defaults and check the layout and file type
+	if err := validateBatchOutput(&config.Output); err != nil {
+		return nil, err
 	}
 
 	return &config, nil
@@ -200,7 +338,7 @@ func showDryRunInfo(config *BatchConfig, configFile string) error {
 	if config.Description != "" {
 		fmt.Printf("Description: %s\n", config.Description)
 	}
-	fmt.Printf("Output format: %s\n", config.Output.Format)
+	fmt.Printf("Output format: %s (%s)\n", config.Output.Format, config.Output.Type)
 	if config.Output.Directory != "" {
 		fmt.Printf("Output directory: %s\n", config.Output.Directory)
 	}
@@ -209,6 +347,9 @@ func showDryRunInfo(config *BatchConfig, configFile string) error {
 	for i, searchConfig := range config.Searches {
 		fmt.Printf("  %d. %s\n", i+1, searchConfig.Name)
 		fmt.Printf("     Query: %s\n", searchConfig.Query)
+		if searchConfig.Host != "" {
+			fmt.Printf("     Host: %s\n", github.NormalizeHost(searchConfig.Host))
+		}
 		if len(searchConfig.Tags) > 0 {
 			fmt.Printf("     Tags: %s\n", strings.Join(searchConfig.Tags, ", "))
 		}
@@ -240,95 +381,132 @@ func showDryRunInfo(config *BatchConfig, configFile string) error {
 	return nil
 }
 
-// executeBatchSearches executes all searches and processes results
-func executeBatchSearches(ctx context.Context, config *BatchConfig) error {
+// executeBatchSearches executes all searches and processes results.
+// Searches run concurrently, sharing a token bucket sized to the search API
+// rate limit. Unless continue_on_error is set, the first failure cancels the rest.
+func executeBatchSearches(ctx context.Context, config *BatchConfig, checkpoint *batchCheckpoint) error {
+	concurrency := resolveBatchConcurrency(config)
+	continueOnError := config.ContinueOnError || batchContinueOnError
+
 	if verbose {
-		fmt.Printf("Executing %d searches...\n", len(config.Searches))
+		fmt.Printf("Executing %d searches (concurrency %d)...\n", len(config.Searches), concurrency)
 	}
 
 	// Initialize performance tracking
 	performanceTracker := github.NewPerformanceTracker()
 	performanceTracker.StartBatch(len(config.Searches))
 
-	var batchResults BatchResults
-	batchResults.Name = config.Name
-	batchResults.Description = config.Description
-	batchResults.SearchCount = len(config.Searches)
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	// Initialize rate limiter if not set
+	// Initialize rate limiting if not set
 	if batchRateLimiter == nil {
-		batchRateLimiter = github.NewRateLimiter()
+		batchRateLimiter = newRateLimiter(hostname, github.ResourceCodeSearch)
+	}
+	if batchTokenBucket == nil {
+		batchTokenBucket = searchTokenBucket(ctx, batchClient, github.ResolveHost(hostname))
 	}
 
-	// Execute each search sequentially with intelligent rate limiting
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	enrichmentBefore := enrichmentStats(batchClient)
+	results := make([]BatchSearchResult, len(config.Searches))
+	searchErrs := make([]error, len(config.Searches))
+
+	// Searches finished by a previous run are restored from the checkpoint
+	var pending []int
 	for i, searchConfig := range config.Searches {
-		if verbose {
-			fmt.Printf("Executing search %d/%d: %s\n", i+1, len(config.Searches), searchConfig.Name)
+		query := buildBatchQuery(searchConfig)
+		if checkpoint.completed(searchConfig, query) {
+			progress := checkpoint.progress(searchConfig, query)
+			results[i] = progress.result(searchConfig)
+			continue
 		}
+		pending = append(pending, i)
+	}
+	if verbose && len(pending) < len(config.Searches) {
+		fmt.Printf("Skipping %d searches completed in a previous run\n", len(config.Searches)-len(pending))
+	}
 
-		// Start tracking this search
-		performanceTracker.StartSearch(searchConfig.Name, searchConfig.Query)
-
-		// Execute search with retry logic and performance tracking
-		var result BatchSearchResult
-		err := batchRateLimiter.WithRetry(ctx, fmt.Sprintf("batch search '%s'", searchConfig.Name), func() error {
-			var searchErr error
-			result, searchErr = executeSingleBatchSearch(ctx, searchConfig)
-			if searchErr != nil {
-				performanceTracker.RecordRetry()
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], searchErrs[i] = runScheduledBatchSearch(ctx, config.Searches[i], performanceTracker, checkpoint)
+				if searchErrs[i] != nil && !continueOnError {
+					cancel()
+				}
 			}
-			return searchErr
-		})
-		// End tracking for this search
-		resultCount := 0
-		if err == nil {
-			resultCount = result.ResultCount
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for dispatched < len(pending) {
+		select {
+		case jobs <- pending[dispatched]:
+			dispatched++
+		case <-ctx.Done():
+			break dispatch
 		}
-		performanceTracker.EndSearch(resultCount, err)
+	}
+	close(jobs)
+	wg.Wait()
 
-		if err != nil {
-			// Generate performance report before returning error
-			performanceTracker.EndBatch()
+	// Searches that never started are reported as cancelled
+	for _, i := range pending[dispatched:] {
+		searchErrs[i] = ctx.Err()
+		results[i] = failedBatchResult(config.Searches[i], ctx.Err())
+	}
+
+	performanceTracker.RecordEnrichment(enrichmentStats(batchClient).Sub(enrichmentBefore))
+	performanceTracker.EndBatch()
+
+	if !continueOnError {
+		if i, err := firstBatchError(searchErrs); err != nil {
 			if verbose {
 				fmt.Println("\n" + performanceTracker.GenerateReport())
 			}
-			return fmt.Errorf("failed to execute search '%s': %w", searchConfig.Name, err)
+			return fmt.Errorf("failed to execute search '%s': %w\n\n💡 Set continue_on_error: true (or --continue-on-error) to keep partial results%s", config.Searches[i].Name, err, checkpoint.resumeHint())
 		}
+	}
 
-		batchResults.Results = append(batchResults.Results, result)
-		batchResults.TotalResults += result.ResultCount
+	var batchResults BatchResults
+	batchResults.Name = config.Name
+	batchResults.Description = config.Description
+	batchResults.SearchCount = len(config.Searches)
+	batchResults.Results = results
 
-		if verbose {
-			fmt.Printf("  Found %d results\n", result.ResultCount)
+	settings, patternsEnabled := patternSettings()
+	var succeeded []BatchSearchResult
+	for i, result := range results {
+		if result.Error != "" {
+			batchResults.FailedCount++
+			continue
 		}
-
-		// Add intelligent delay between searches (except after last search)
-		if i < len(config.Searches)-1 {
-			complexity := github.EstimateComplexity(searchConfig.Query, searchConfig.MaxResults, hasFilters(searchConfig.Filters))
-			if verbose {
-				fmt.Printf("  Adding delay for complexity: %v\n", complexity)
-			}
-			delay := batchRateLimiter.CalculateIntelligentDelay(complexity)
-			performanceTracker.RecordDelay(delay)
-
-			err := batchRateLimiter.IntelligentDelay(ctx, complexity)
-			if err != nil {
-				return fmt.Errorf("operation cancelled during delay: %w", err)
+		if patternsEnabled {
+			if report := analysis.Analyze(result.Results, settings); !report.Empty() {
+				results[i].Patterns = report
+				result.Patterns = report
 			}
 		}
+		batchResults.TotalResults += result.ResultCount
+		succeeded = append(succeeded, result)
 	}
 
 	// Generate comparisons if requested
-	if config.Output.Compare && len(batchResults.Results) > 1 {
+	if config.Output.Compare && len(succeeded) > 1 {
 		if verbose {
 			fmt.Printf("Generating comparison analysis...\n")
 		}
-		batchResults.Comparisons = generateComparisons(batchResults.Results)
+		batchResults.Comparisons = generateComparisons(succeeded)
 	}
 
-	// End performance tracking
-	performanceTracker.EndBatch()
-
 	// Output results
 	err := outputBatchResults(&batchResults, config.Output)
 	if err != nil {
@@ -340,51 +518,251 @@ func executeBatchSearches(ctx context.Context, config *BatchConfig) error {
 		fmt.Println("\n" + performanceTracker.GenerateDetailedReport())
 	}
 
+	if len(succeeded) == 0 {
+		return fmt.Errorf("all %d batch searches failed%s", len(config.Searches), checkpoint.resumeHint())
+	}
+
+	if batchResults.FailedCount > 0 {
+		// Keep stdout clean for JSON and CSV reports
+		if hint := checkpoint.resumeHint(); hint != "" {
+			fmt.Fprintln(os.Stderr, strings.TrimPrefix(hint, "\n"))
+		}
+		return nil
+	}
+
+	// Nothing is left to resume once every search has completed
+	checkpoint.remove()
 	return nil
 }
 
-// executeSingleBatchSearch executes a single search from the batch configuration
-func executeSingleBatchSearch(ctx context.Context, searchConfig BatchSearchConfig) (BatchSearchResult, error) {
-	// Build query using existing search package functionality
-	terms := strings.Fields(searchConfig.Query)
-	qb := search.NewQueryBuilderFromFilters(terms, searchConfig.Filters)
-	query := qb.Build()
+// runScheduledBatchSearch runs one batch search page by page under the shared
+// token bucket and the search's retry budget, checkpointing after every page.
+// Failed searches return a result marked with the error.
+func runScheduledBatchSearch(ctx context.Context, searchConfig BatchSearchConfig, tracker *github.PerformanceTracker, checkpoint *batchCheckpoint) (BatchSearchResult, error) {
+	if verbose {
+		fmt.Printf("Starting search: %s\n", searchConfig.Name)
+	}
+
+	searchTracker := tracker.TrackSearch(searchConfig.Name, searchConfig.Query)
+
+	retries := -1 // rate limiter default
+	if searchConfig.Retries != nil {
+		retries = *searchConfig.Retries
+	}
+
+	client, bucket, err := batchSearchClient(ctx, searchConfig.Host)
+	if err != nil {
+		searchTracker.End(0, err)
+		if verbose {
+			fmt.Printf("  ❌ %s failed: %v\n", searchConfig.Name, err)
+		}
+		return failedBatchResult(searchConfig, err), err
+	}
+
+	// Searches on another host wait on that host's quota
+	rateLimiter := batchRateLimiter
+	if searchConfig.Host != "" {
+		rateLimiter = batchRateLimiter.WithQuota(github.SharedQuotaTracker(), github.ResolveHost(searchConfig.Host), github.ResourceCodeSearch, currentConfig().GitHub.RateLimitBuffer)
+	}
+
+	query := buildBatchQuery(searchConfig)
+	progress := checkpoint.progress(searchConfig, query)
+	if verbose && progress.NextPage > 1 {
+		fmt.Printf("  ↪️  %s: resuming at page %d (%d results saved)\n", searchConfig.Name, progress.NextPage, len(progress.Results.Items))
+	}
+
+	for progress.Status != batchSearchCompleted {
+		page := progress.NextPage
+		var pageResults *github.SearchResults
+		operation := fmt.Sprintf("batch search '%s' page %d", searchConfig.Name, page)
+		err := rateLimiter.WithRetryBudget(ctx, operation, retries, func() error {
+			waited, err := bucket.Wait(ctx)
+			searchTracker.RecordDelay(waited)
+			if err != nil {
+				return err
+			}
+
+			var searchErr error
+			pageResults, searchErr = fetchBatchPage(ctx, client, query, page, progress.PerPage)
+			if searchErr != nil {
+				searchTracker.RecordRetry()
+			}
+			return searchErr
+		})
+
+		if err != nil {
+			// Keep the pages fetched so far so a resumed run continues from here
+			progress.Status = batchSearchFailed
+			progress.Error = err.Error()
+			checkpoint.record(searchConfig.Name, progress)
+
+			searchTracker.End(0, err)
+			if verbose {
+				fmt.Printf("  ❌ %s failed: %v\n", searchConfig.Name, err)
+			}
+			return failedBatchResult(searchConfig, err), err
+		}
+
+		progress.addPage(pageResults, batchMaxResults(searchConfig))
+		checkpoint.record(searchConfig.Name, progress)
+	}
+
+	result := progress.result(searchConfig)
+	searchTracker.End(result.ResultCount, nil)
+	if verbose {
+		fmt.Printf("  %s: found %d results\n", searchConfig.Name, result.ResultCount)
+	}
+	return result, nil
+}
+
+// validateBatchConcurrency checks a concurrency setting named name, where 0
+// selects the default
+func validateBatchConcurrency(name string, concurrency int) error {
+	if concurrency < 0 || concurrency > maxBatchConcurrency {
+		return fmt.Errorf("%s must be between 0 and %d, 0 uses the default", name, maxBatchConcurrency)
+	}
+	return nil
+}
+
+// resolveBatchConcurrency picks the concurrency from flags, config or the default
+func resolveBatchConcurrency(config *BatchConfig) int {
+	concurrency := defaultBatchConcurrency
+	if config.Concurrency > 0 {
+		concurrency = config.Concurrency
+	}
+	if batchConcurrency > 0 {
+		concurrency = batchConcurrency
+	}
+	return min(concurrency, len(config.Searches))
+}
 
-	// Set up search options
+// failedBatchResult builds the result entry for a search that did not complete
+func failedBatchResult(searchConfig BatchSearchConfig, err error) BatchSearchResult {
+	message := "cancelled"
+	if err != nil && !errors.Is(err, context.Canceled) {
+		message = err.Error()
+	}
+	return BatchSearchResult{
+		Name:  searchConfig.Name,
+		Query: buildBatchQuery(searchConfig),
+		Tags:  searchConfig.Tags,
+		Error: message,
+	}
+}
+
+// firstBatchError returns the first root-cause error in config order,
+// preferring real failures over the cancellations they triggered
+func firstBatchError(errs []error) (int, error) {
+	first := -1
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			return i, err
+		}
+		if first == -1 {
+			first = i
+		}
+	}
+	if first == -1 {
+		return -1, nil
+	}
+	return first, errs[first]
+}
+
+// batchSearchClient returns the client and token bucket for a search's host.
+// Searches on the default host share batchClient; each other host gets its
+// own client and a bucket sized to that host's search limit.
+func batchSearchClient(ctx context.Context, host string) (github.GitHubAPI, *github.TokenBucket, error) {
+	host = github.NormalizeHost(host)
+	if host == "" || host == github.ResolveHost(hostname) {
+		return batchClient, batchTokenBucket, nil
+	}
+
+	batchHostMutex.Lock()
+	defer batchHostMutex.Unlock()
+
+	client, ok := batchHostClients[host]
+	if !ok {
+		var err error
+		client, err = createGitHubClient(host)
+		if err != nil {
+			return nil, nil, err
+		}
+		if batchHostClients == nil {
+			batchHostClients = make(map[string]github.GitHubAPI)
+		}
+		batchHostClients[host] = client
+	}
+
+	bucket, ok := batchHostBuckets[host]
+	if !ok {
+		bucket = searchTokenBucket(ctx, client, host)
+		if batchHostBuckets == nil {
+			batchHostBuckets = make(map[string]*github.TokenBucket)
+		}
+		batchHostBuckets[host] = bucket
+	}
+	return client, bucket, nil
+}
+
+// searchTokenBucket creates the token bucket for searches on host. GitHub
+// Enterprise Server administrators set their own search limit, so enterprise
+// hosts are sized from the limit the server reports.
+func searchTokenBucket(ctx context.Context, client github.GitHubAPI, host string) *github.TokenBucket {
+	if !github.IsEnterpriseHost(host) {
+		return github.NewSearchTokenBucket()
+	}
+
+	rateLimit, err := client.GetRateLimit(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("⚠️  Could not read the search rate limit of %s, pacing at %d searches per minute: %v\n", host, github.SearchRequestsPerMinute, err)
+		}
+		return github.NewSearchTokenBucket()
+	}
+	if verbose {
+		if rateLimit.Disabled {
+			fmt.Printf("%s has rate limiting disabled\n", host)
+		} else {
+			fmt.Printf("%s allows %d searches per minute\n", host, rateLimit.Limit)
+		}
+	}
+	return github.NewSearchTokenBucketForLimit(rateLimit)
+}
+
+// fetchBatchPage fetches one page of a batch search
+func fetchBatchPage(ctx context.Context, client github.GitHubAPI, query string, page, perPage int) (*github.SearchResults, error) {
 	opts := &github.SearchOptions{
 		Sort:  "relevance", // Use relevance for batch searches
 		Order: "desc",
 		ListOptions: github.ListOptions{
-			Page:    1,
-			PerPage: searchConfig.MaxResults,
+			Page:    page,
+			PerPage: perPage,
 		},
 		SkipEnrichment: false, // Batch searches typically want full info
 	}
 
-	// Execute search
-	results, err := batchClient.SearchCode(ctx, query, opts)
-	if err != nil {
-		return BatchSearchResult{}, err
-	}
+	return client.SearchCode(ctx, query, opts)
+}
 
-	// Build result
-	batchResult := BatchSearchResult{
-		Name:        searchConfig.Name,
-		Query:       query,
-		Tags:        searchConfig.Tags,
-		ResultCount: len(results.Items),
-		Results:     results,
+// batchMaxResults returns a search's result limit, applying the default when unset
+func batchMaxResults(searchConfig BatchSearchConfig) int {
+	if searchConfig.MaxResults > 0 {
+		return searchConfig.MaxResults
 	}
+	return defaultBatchMaxResults
+}
 
-	return batchResult, nil
+// buildBatchQuery builds a search's query using existing search package functionality
+func buildBatchQuery(searchConfig BatchSearchConfig) string {
+	terms := strings.Fields(searchConfig.Query)
+	return search.NewQueryBuilderFromFilters(terms, searchConfig.Filters).Build()
 }
 
 // generateComparisons analyzes results to find patterns and differences
 func generateComparisons(results []BatchSearchResult) []BatchComparisonResult {
-	var comparisons []BatchComparisonResult
-
-	// For now, create a simple overall comparison
-	// This can be expanded with more sophisticated analysis
 	searchNames := make([]string, len(results))
 	totalResults := 0
 
@@ -393,43 +771,27 @@ func generateComparisons(results []BatchSearchResult) []BatchComparisonResult {
 		totalResults += result.ResultCount
 	}
 
-	comparison := BatchComparisonResult{
-		Name:        "Overall Analysis",
-		SearchNames: searchNames,
-		Summary:     fmt.Sprintf("Analyzed %d searches with %d total results", len(results), totalResults),
-	}
-
-	// Add simple pattern analysis
-	comparison.CommonPatterns = []string{
-		"Configuration files found across multiple ecosystems",
-		"Repository patterns vary by technology stack",
-	}
-
-	comparison.KeyDifferences = []string{
-		"Result counts vary significantly between searches",
-		"Different file naming conventions observed",
-	}
-
-	comparisons = append(comparisons, comparison)
-	return comparisons
-}
+	analysis := analyzeBatchComparison(results)
 
-// hasFilters checks if any filters are applied to determine complexity
-func hasFilters(filters search.SearchFilters) bool {
-	return filters.Language != "" ||
-		filters.Filename != "" ||
-		len(filters.Repository) > 0 ||
-		len(filters.Owner) > 0 ||
-		filters.MinStars > 0
+	return []BatchComparisonResult{{
+		Name:           "Overall Analysis",
+		SearchNames:    searchNames,
+		CommonPatterns: comparisonPatterns(analysis),
+		KeyDifferences: comparisonDifferences(analysis),
+		Summary:        fmt.Sprintf("Analyzed %d searches with %d total results", len(results), totalResults),
+		Analysis:       analysis,
+	}}
 }
 
-// outputBatchResults formats and outputs the batch results
+// outputBatchResults renders the batch results in the configured layout and
+// file type, writing files to the output directory or printing them to stdout
 func outputBatchResults(results *BatchResults, outputConfig BatchOutputConfig) error {
-	// For now, output in JSON format to stdout
-	// This can be expanded to support different formats and file output
-
 	if verbose {
-		fmt.Printf("\nBatch search completed successfully!\n")
+		if results.FailedCount > 0 {
+			fmt.Printf("\nBatch search completed with %d failed searches\n", results.FailedCount)
+		} else {
+			fmt.Printf("\nBatch search completed successfully!\n")
+		}
 		fmt.Printf("Total searches: %d\n", results.SearchCount)
 		fmt.Printf("Total results: %d\n", results.TotalResults)
 		if len(results.Comparisons) > 0 {
@@ -438,68 +800,35 @@ func outputBatchResults(results *BatchResults, outputConfig BatchOutputConfig) e
 		fmt.Println()
 	}
 
-	// Output summary
-	fmt.Printf("🔍 Batch Search Results: %s\n", results.Name)
-	if results.Description != "" {
-		fmt.Printf("📋 %s\n", results.Description)
+	if err := validateBatchOutput(&outputConfig); err != nil {
+		return err
 	}
-	fmt.Printf("📊 Executed %d searches, found %d total results\n\n", results.SearchCount, results.TotalResults)
-
-	// Output individual search results
-	for i, result := range results.Results {
-		fmt.Printf("## %d. %s (%d results)\n", i+1, result.Name, result.ResultCount)
-		fmt.Printf("**Query:** `%s`\n", result.Query)
-		if len(result.Tags) > 0 {
-			fmt.Printf("**Tags:** %s\n", strings.Join(result.Tags, ", "))
-		}
-
-		// Show top results
-		maxShow := 3
-		if len(result.Results.Items) < maxShow {
-			maxShow = len(result.Results.Items)
-		}
 
-		for j := 0; j < maxShow; j++ {
-			item := result.Results.Items[j]
-			if item.Repository.FullName != nil && item.Path != nil {
-				stars := 0
-				if item.Repository.StargazersCount != nil {
-					stars = *item.Repository.StargazersCount
-				}
-				fmt.Printf("- **%s** (%s) ⭐ %d\n", *item.Path, *item.Repository.FullName, stars)
-			}
-		}
+	files, err := renderBatchOutput(results, outputConfig)
+	if err != nil {
+		return err
+	}
 
-		if len(result.Results.Items) > maxShow {
-			fmt.Printf("- ... and %d more results\n", len(result.Results.Items)-maxShow)
+	directory := outputConfig.Directory
+	if directory == "" && outputConfig.Format == BatchLayoutSeparate {
+		// One file per search cannot be printed meaningfully, so default to a directory
+		directory = batchFileName(results.Name, "batch-results")
+	}
+	if directory == "" {
+		for _, file := range files {
+			fmt.Print(string(file.Content))
 		}
-		fmt.Println()
+		return nil
 	}
 
-	// Output comparisons if available
-	if len(results.Comparisons) > 0 {
-		fmt.Printf("## Analysis & Comparisons\n")
-		for _, comparison := range results.Comparisons {
-			fmt.Printf("### %s\n", comparison.Name)
-			fmt.Printf("%s\n\n", comparison.Summary)
-
-			if len(comparison.CommonPatterns) > 0 {
-				fmt.Printf("**Common Patterns:**\n")
-				for _, pattern := range comparison.CommonPatterns {
-					fmt.Printf("- %s\n", pattern)
-				}
-				fmt.Println()
-			}
-
-			if len(comparison.KeyDifferences) > 0 {
-				fmt.Printf("**Key Differences:**\n")
-				for _, diff := range comparison.KeyDifferences {
-					fmt.Printf("- %s\n", diff)
-				}
-				fmt.Println()
-			}
-		}
+	for _, line := range batchSummaryLines(results) {
+		fmt.Println(line)
 	}
+	return writeBatchOutputFiles(directory, files)
+}
 
-	return nil
+// firstLine returns the first line of a possibly multi-line error message
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return line
 }
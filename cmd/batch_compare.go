@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// maxDistributionEntries caps each distribution in a search profile
+const maxDistributionEntries = 10
+
+// maxListedNames caps how many names are spelled out in a pattern sentence
+const maxListedNames = 3
+
+// BatchComparisonAnalysis is the machine-readable data behind a comparison
+type BatchComparisonAnalysis struct {
+	Searches           []SearchProfile     `json:"searches"`
+	RepositoryOverlap  []RepositoryOverlap `json:"repository_overlap"`
+	SharedRepositories []SharedItem        `json:"shared_repositories"`
+	SharedFilenames    []SharedItem        `json:"shared_filenames"`
+}
+
+// SearchProfile summarizes the results of one search
+type SearchProfile struct {
+	Name               string           `json:"name"`
+	ResultCount        int              `json:"result_count"`
+	Repositories       int              `json:"repositories"`
+	Languages          []CountEntry     `json:"languages"`
+	Paths              []CountEntry     `json:"paths"`
+	Filenames          []CountEntry     `json:"filenames"`
+	Stars              StarDistribution `json:"stars"`
+	UniqueRepositories []string         `json:"unique_repositories"`
+}
+
+// CountEntry is one value of a distribution
+type CountEntry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// StarDistribution describes repository star counts (each repository counted once)
+type StarDistribution struct {
+	Repositories int `json:"repositories"`
+	Min          int `json:"min"`
+	Median       int `json:"median"`
+	Max          int `json:"max"`
+	Mean         int `json:"mean"`
+}
+
+// RepositoryOverlap compares the repositories found by two searches
+type RepositoryOverlap struct {
+	Searches []string `json:"searches"`
+	Shared   int      `json:"shared"`
+	Jaccard  float64  `json:"jaccard"` // shared / union, 0 when both are empty
+}
+
+// SharedItem is a repository or filename found by more than one search
+type SharedItem struct {
+	Name     string   `json:"name"`
+	Searches []string `json:"searches"`
+}
+
+// searchData holds the per-search sets the analysis is built from
+type searchData struct {
+	repos     map[string]int // repository -> result count
+	filenames map[string]int
+}
+
+// analyzeBatchComparison computes overlap, distributions and shared items across searches
+func analyzeBatchComparison(results []BatchSearchResult) *BatchComparisonAnalysis {
+	analysis := &BatchComparisonAnalysis{}
+	data := make([]searchData, len(results))
+
+	for i, result := range results {
+		languages := make(map[string]int)
+		paths := make(map[string]int)
+		repoStars := make(map[string]int)
+		data[i] = searchData{repos: make(map[string]int), filenames: make(map[string]int)}
+
+		if result.Results != nil {
+			for _, item := range result.Results.Items {
+				repo := derefString(item.Repository.FullName)
+				filePath := derefString(item.Path)
+				if repo != "" {
+					data[i].repos[repo]++
+					if item.Repository.StargazersCount != nil {
+						repoStars[repo] = *item.Repository.StargazersCount
+					}
+				}
+				if filePath != "" {
+					data[i].filenames[path.Base(filePath)]++
+					paths[directoryOf(filePath)]++
+				}
+				if language := itemLanguage(item.Repository.Language, filePath); language != "" {
+					languages[language]++
+				}
+			}
+		}
+
+		analysis.Searches = append(analysis.Searches, SearchProfile{
+			Name:         result.Name,
+			ResultCount:  result.ResultCount,
+			Repositories: len(data[i].repos),
+			Languages:    topCounts(languages, maxDistributionEntries),
+			Paths:        topCounts(paths, maxDistributionEntries),
+			Filenames:    topCounts(data[i].filenames, maxDistributionEntries),
+			Stars:        starDistribution(repoStars),
+		})
+	}
+
+	// Repositories and filenames found by more than one search
+	analysis.SharedRepositories = sharedItems(results, data, func(d searchData) map[string]int { return d.repos })
+	analysis.SharedFilenames = sharedItems(results, data, func(d searchData) map[string]int { return d.filenames })
+
+	// Repositories only one search found
+	for i := range results {
+		for repo := range data[i].repos {
+			if !foundElsewhere(data, i, repo) {
+				analysis.Searches[i].UniqueRepositories = append(analysis.Searches[i].UniqueRepositories, repo)
+			}
+		}
+		slices.Sort(analysis.Searches[i].UniqueRepositories)
+	}
+
+	// Pairwise repository overlap
+	for i := range results {
+		for j := i + 1; j < len(results); j++ {
+			shared := 0
+			for repo := range data[i].repos {
+				if _, ok := data[j].repos[repo]; ok {
+					shared++
+				}
+			}
+			overlap := RepositoryOverlap{Searches: []string{results[i].Name, results[j].Name}, Shared: shared}
+			if union := len(data[i].repos) + len(data[j].repos) - shared; union > 0 {
+				overlap.Jaccard = float64(shared) / float64(union)
+			}
+			analysis.RepositoryOverlap = append(analysis.RepositoryOverlap, overlap)
+		}
+	}
+
+	return analysis
+}
+
+// comparisonPatterns describes what the searches have in common
+func comparisonPatterns(analysis *BatchComparisonAnalysis) []string {
+	var patterns []string
+	searchCount := len(analysis.Searches)
+
+	if len(analysis.SharedRepositories) > 0 {
+		inAll := 0
+		for _, item := range analysis.SharedRepositories {
+			if len(item.Searches) == searchCount {
+				inAll++
+			}
+		}
+		if inAll > 0 && searchCount > 1 {
+			patterns = append(patterns, fmt.Sprintf("%s in all %d searches: %s",
+				countRepositories(inAll, "appears", "appear"), searchCount, listNames(sharedNames(analysis.SharedRepositories, searchCount))))
+		} else {
+			patterns = append(patterns, fmt.Sprintf("%s in more than one search: %s",
+				countRepositories(len(analysis.SharedRepositories), "appears", "appear"), listNames(sharedNames(analysis.SharedRepositories, 0))))
+		}
+	}
+
+	for _, item := range analysis.SharedFilenames[:min(len(analysis.SharedFilenames), maxListedNames)] {
+		patterns = append(patterns, fmt.Sprintf("`%s` is matched by %d searches (%s)", item.Name, len(item.Searches), strings.Join(item.Searches, ", ")))
+	}
+
+	if language, ok := commonTop(analysis.Searches, func(p SearchProfile) []CountEntry { return p.Languages }); ok {
+		patterns = append(patterns, fmt.Sprintf("%s is the most common language in every search", language))
+	}
+	if dir, ok := commonTop(analysis.Searches, func(p SearchProfile) []CountEntry { return p.Paths }); ok {
+		patterns = append(patterns, fmt.Sprintf("Most matches in every search are in %s", describeDirectory(dir)))
+	}
+
+	if len(patterns) == 0 {
+		patterns = append(patterns, "No repositories, filenames or languages are shared between searches")
+	}
+	return patterns
+}
+
+// comparisonDifferences describes how the searches differ
+func comparisonDifferences(analysis *BatchComparisonAnalysis) []string {
+	var differences []string
+	profiles := analysis.Searches
+	if len(profiles) < 2 {
+		return differences
+	}
+
+	// Result volume
+	most, least := profiles[0], profiles[0]
+	for _, profile := range profiles[1:] {
+		if profile.ResultCount > most.ResultCount {
+			most = profile
+		}
+		if profile.ResultCount < least.ResultCount {
+			least = profile
+		}
+	}
+	if most.ResultCount != least.ResultCount {
+		differences = append(differences, fmt.Sprintf("%s found the most results (%d), %s the fewest (%d)",
+			most.Name, most.ResultCount, least.Name, least.ResultCount))
+	}
+
+	// Top languages that differ between searches
+	var languages []string
+	distinct := make(map[string]bool)
+	for _, profile := range profiles {
+		if len(profile.Languages) > 0 {
+			languages = append(languages, fmt.Sprintf("%s → %s", profile.Name, profile.Languages[0].Name))
+			distinct[profile.Languages[0].Name] = true
+		}
+	}
+	if len(distinct) > 1 {
+		differences = append(differences, "Top languages differ: "+strings.Join(languages, ", "))
+	}
+
+	// Star distributions
+	var highest, lowest *SearchProfile
+	for i := range profiles {
+		if profiles[i].Stars.Repositories == 0 {
+			continue
+		}
+		if highest == nil || profiles[i].Stars.Median > highest.Stars.Median {
+			highest = &profiles[i]
+		}
+		if lowest == nil || profiles[i].Stars.Median < lowest.Stars.Median {
+			lowest = &profiles[i]
+		}
+	}
+	if highest != nil && highest.Stars.Median != lowest.Stars.Median {
+		differences = append(differences, fmt.Sprintf("Median repository stars range from %s (%s) to %s (%s)",
+			formatStars(lowest.Stars.Median), lowest.Name, formatStars(highest.Stars.Median), highest.Name))
+	}
+
+	// Repositories only one search found
+	for _, profile := range profiles {
+		if unique := profile.UniqueRepositories; len(unique) > 0 {
+			differences = append(differences, fmt.Sprintf("%d of %s only %s in %s: %s",
+				len(unique), countRepositories(profile.Repositories, "", ""), verb(len(unique), "appears", "appear"), profile.Name, listNames(unique)))
+		}
+	}
+
+	// The pair of searches with the least repository overlap
+	var leastOverlap *RepositoryOverlap
+	for i := range analysis.RepositoryOverlap {
+		if leastOverlap == nil || analysis.RepositoryOverlap[i].Jaccard < leastOverlap.Jaccard {
+			leastOverlap = &analysis.RepositoryOverlap[i]
+		}
+	}
+	if leastOverlap != nil && repositoriesFound(profiles) {
+		pair := leastOverlap.Searches
+		if leastOverlap.Shared == 0 {
+			differences = append(differences, fmt.Sprintf("%s and %s share no repositories", pair[0], pair[1]))
+		} else if len(profiles) > 2 {
+			differences = append(differences, fmt.Sprintf("%s and %s overlap least (%d shared repositories)", pair[0], pair[1], leastOverlap.Shared))
+		}
+	}
+
+	return differences
+}
+
+// sharedItems returns names found by at least two searches, most widely shared first
+func sharedItems(results []BatchSearchResult, data []searchData, set func(searchData) map[string]int) []SharedItem {
+	found := make(map[string][]string)
+	for i, result := range results {
+		for name := range set(data[i]) {
+			found[name] = append(found[name], result.Name)
+		}
+	}
+
+	var items []SharedItem
+	for name, searches := range found {
+		if len(searches) > 1 {
+			items = append(items, SharedItem{Name: name, Searches: searches})
+		}
+	}
+	slices.SortFunc(items, func(a, b SharedItem) int {
+		if len(a.Searches) != len(b.Searches) {
+			return len(b.Searches) - len(a.Searches)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return items
+}
+
+// repositoriesFound reports whether every search found at least one repository
+func repositoriesFound(profiles []SearchProfile) bool {
+	for _, profile := range profiles {
+		if profile.Repositories == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// foundElsewhere reports whether any search other than skip found repo
+func foundElsewhere(data []searchData, skip int, repo string) bool {
+	for i := range data {
+		if i == skip {
+			continue
+		}
+		if _, ok := data[i].repos[repo]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// commonTop returns the top entry of a distribution when it is the same for every search
+func commonTop(profiles []SearchProfile, distribution func(SearchProfile) []CountEntry) (string, bool) {
+	if len(profiles) < 2 {
+		return "", false
+	}
+	var top string
+	for _, profile := range profiles {
+		entries := distribution(profile)
+		if len(entries) == 0 {
+			return "", false
+		}
+		if top != "" && entries[0].Name != top {
+			return "", false
+		}
+		top = entries[0].Name
+	}
+	return top, true
+}
+
+// sharedNames lists shared item names, optionally only those in exactly searchCount searches
+func sharedNames(items []SharedItem, searchCount int) []string {
+	var names []string
+	for _, item := range items {
+		if searchCount == 0 || len(item.Searches) == searchCount {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// topCounts returns the largest counts first, breaking ties by name
+func topCounts(counts map[string]int, limit int) []CountEntry {
+	entries := make([]CountEntry, 0, len(counts))
+	for name, count := range counts {
+		entries = append(entries, CountEntry{Name: name, Count: count})
+	}
+	slices.SortFunc(entries, func(a, b CountEntry) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// starDistribution summarizes repository star counts
+func starDistribution(repoStars map[string]int) StarDistribution {
+	if len(repoStars) == 0 {
+		return StarDistribution{}
+	}
+
+	stars := make([]int, 0, len(repoStars))
+	total := 0
+	for _, count := range repoStars {
+		stars = append(stars, count)
+		total += count
+	}
+	slices.Sort(stars)
+
+	median := stars[len(stars)/2]
+	if len(stars)%2 == 0 {
+		median = (stars[len(stars)/2-1] + stars[len(stars)/2]) / 2
+	}
+
+	return StarDistribution{
+		Repositories: len(stars),
+		Min:          stars[0],
+		Median:       median,
+		Max:          stars[len(stars)-1],
+		Mean:         total / len(stars),
+	}
+}
+
+// itemLanguage prefers the repository language and falls back to the file extension
+func itemLanguage(repoLanguage *string, filePath string) string {
+	if repoLanguage != nil && *repoLanguage != "" {
+		return *repoLanguage
+	}
+	return detectLanguage(filePath)
+}
+
+// directoryOf returns the directory of a file path, "/" for the repository root
+func directoryOf(filePath string) string {
+	dir := path.Dir(filePath)
+	if dir == "." || dir == "/" {
+		return "/"
+	}
+	return dir + "/"
+}
+
+// describeDirectory phrases a directory for pattern sentences
+func describeDirectory(dir string) string {
+	if dir == "/" {
+		return "the repository root"
+	}
+	return fmt.Sprintf("`%s`", dir)
+}
+
+// countRepositories phrases a repository count with an optional agreeing verb
+func countRepositories(n int, singularVerb, pluralVerb string) string {
+	phrase := fmt.Sprintf("%d repositories", n)
+	if n == 1 {
+		phrase = "1 repository"
+	}
+	if v := verb(n, singularVerb, pluralVerb); v != "" {
+		phrase += " " + v
+	}
+	return phrase
+}
+
+// verb picks the verb form that agrees with n
+func verb(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// listNames joins the first few names, noting how many were left out
+func listNames(names []string) string {
+	if len(names) <= maxListedNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxListedNames], ", "), len(names)-maxListedNames)
+}
+
+// formatStars formats a star count compactly (e.g. 1.2k)
+func formatStars(stars int) string {
+	if stars >= 1000 {
+		return fmt.Sprintf("%.1fk", float64(stars)/1000)
+	}
+	return fmt.Sprintf("%d", stars)
+}
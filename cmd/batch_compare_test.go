@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// comparisonItem builds a search item with an explicit star count
+func comparisonItem(repo, path string, stars int) github.SearchItem {
+	item := github.CreateTestSearchItem(repo, path, "")
+	item.Repository.StargazersCount = github.IntPtr(stars)
+	return item
+}
+
+// comparisonResults builds three searches with partially overlapping repositories
+func comparisonResults() []BatchSearchResult {
+	return []BatchSearchResult{
+		{
+			Name:        "next-configs",
+			ResultCount: 3,
+			Results: github.CreateTestSearchResults(3,
+				comparisonItem("vercel/next.js", "tsconfig.json", 120000),
+				comparisonItem("vercel/next.js", "packages/next/tsconfig.json", 120000),
+				comparisonItem("shadcn/ui", "tsconfig.json", 60000),
+			),
+		},
+		{
+			Name:        "react-configs",
+			ResultCount: 2,
+			Results: github.CreateTestSearchResults(2,
+				comparisonItem("facebook/react", "tsconfig.json", 220000),
+				comparisonItem("vercel/next.js", "tsconfig.json", 120000),
+			),
+		},
+		{
+			Name:        "vite-configs",
+			ResultCount: 1,
+			Results: github.CreateTestSearchResults(1,
+				comparisonItem("vitejs/vite", "packages/vite/vite.config.ts", 65000),
+			),
+		},
+	}
+}
+
+func TestAnalyzeBatchComparison(t *testing.T) {
+	analysis := analyzeBatchComparison(comparisonResults())
+
+	require.Len(t, analysis.Searches, 3)
+	next := analysis.Searches[0]
+	assert.Equal(t, 2, next.Repositories)
+	assert.Equal(t, []CountEntry{{Name: "tsconfig.json", Count: 3}}, next.Filenames)
+	assert.Equal(t, []CountEntry{{Name: "/", Count: 2}, {Name: "packages/next/", Count: 1}}, next.Paths)
+	assert.Equal(t, []CountEntry{{Name: "json", Count: 3}}, next.Languages)
+	assert.Equal(t, StarDistribution{Repositories: 2, Min: 60000, Median: 90000, Max: 120000, Mean: 90000}, next.Stars)
+	assert.Equal(t, []string{"shadcn/ui"}, next.UniqueRepositories)
+
+	assert.Equal(t, []string{"facebook/react"}, analysis.Searches[1].UniqueRepositories)
+	assert.Equal(t, []string{"vitejs/vite"}, analysis.Searches[2].UniqueRepositories)
+
+	assert.Equal(t, []SharedItem{{Name: "vercel/next.js", Searches: []string{"next-configs", "react-configs"}}}, analysis.SharedRepositories)
+	assert.Equal(t, []SharedItem{{Name: "tsconfig.json", Searches: []string{"next-configs", "react-configs"}}}, analysis.SharedFilenames)
+
+	require.Len(t, analysis.RepositoryOverlap, 3)
+	assert.Equal(t, []string{"next-configs", "react-configs"}, analysis.RepositoryOverlap[0].Searches)
+	assert.Equal(t, 1, analysis.RepositoryOverlap[0].Shared)
+	assert.InDelta(t, 1.0/3.0, analysis.RepositoryOverlap[0].Jaccard, 0.001)
+	assert.Equal(t, 0, analysis.RepositoryOverlap[1].Shared)
+}
+
+func TestGenerateComparisons_Patterns(t *testing.T) {
+	comparisons := generateComparisons(comparisonResults())
+	require.Len(t, comparisons, 1)
+	comparison := comparisons[0]
+
+	assert.Equal(t, []string{
+		"1 repository appears in more than one search: vercel/next.js",
+		"`tsconfig.json` is matched by 2 searches (next-configs, react-configs)",
+	}, comparison.CommonPatterns)
+
+	assert.Contains(t, comparison.KeyDifferences, "next-configs found the most results (3), vite-configs the fewest (1)")
+	assert.Contains(t, comparison.KeyDifferences, "Top languages differ: next-configs → json, react-configs → json, vite-configs → typescript")
+	assert.Contains(t, comparison.KeyDifferences, "Median repository stars range from 65.0k (vite-configs) to 170.0k (react-configs)")
+	assert.Contains(t, comparison.KeyDifferences, "1 of 1 repository only appears in vite-configs: vitejs/vite")
+	assert.Contains(t, comparison.KeyDifferences, "next-configs and vite-configs share no repositories")
+
+	// The structured form travels with the comparison in JSON output
+	data, err := json.Marshal(comparison)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Contains(t, decoded, "analysis")
+}
+
+func TestComparisonPatterns_CommonTop(t *testing.T) {
+	results := []BatchSearchResult{
+		{Name: "a", ResultCount: 1, Results: github.CreateTestSearchResults(1, comparisonItem("one/repo", "Dockerfile", 10))},
+		{Name: "b", ResultCount: 1, Results: github.CreateTestSearchResults(1, comparisonItem("two/repo", "Dockerfile", 10))},
+	}
+
+	patterns := comparisonPatterns(analyzeBatchComparison(results))
+	assert.Contains(t, patterns, "dockerfile is the most common language in every search")
+	assert.Contains(t, patterns, "Most matches in every search are in the repository root")
+}
+
+func TestComparisonPatterns_NothingShared(t *testing.T) {
+	results := []BatchSearchResult{{Name: "a"}, {Name: "b"}}
+	patterns := comparisonPatterns(analyzeBatchComparison(results))
+	assert.Equal(t, []string{"No repositories, filenames or languages are shared between searches"}, patterns)
+}
+
+func TestStarDistribution(t *testing.T) {
+	assert.Equal(t, StarDistribution{}, starDistribution(nil))
+	assert.Equal(t, StarDistribution{Repositories: 3, Min: 1, Median: 5, Max: 30, Mean: 12},
+		starDistribution(map[string]int{"a": 5, "b": 1, "c": 30}))
+}
+
+func TestListNames(t *testing.T) {
+	assert.Equal(t, "a, b", listNames([]string{"a", "b"}))
+	assert.Equal(t, "a, b, c and 2 more", listNames([]string{"a", "b", "c", "d", "e"}))
+}
@@ -0,0 +1,674 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/analysis"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/output"
+)
+
+// maxMatrixRepositories caps the repository rows of a comparison matrix
+const maxMatrixRepositories = 15
+
+// batchLayouts and batchFileTypes list the supported output.format and output.type values
+var (
+	batchLayouts   = []string{BatchLayoutCombined, BatchLayoutSeparate, BatchLayoutComparison}
+	batchFileTypes = []string{OutputFormatMarkdown, OutputFormatJSON, OutputFormatCSV, OutputFormatTSV, OutputFormatHTML, OutputFormatSARIF}
+)
+
+// batchFileExtensions maps report file types to file extensions
+var batchFileExtensions = map[string]string{
+	OutputFormatMarkdown: ".md",
+	OutputFormatJSON:     ".json",
+	OutputFormatCSV:      ".csv",
+	OutputFormatTSV:      ".tsv",
+	OutputFormatHTML:     ".html",
+	OutputFormatSARIF:    ".sarif",
+}
+
+// batchOutputFile is one rendered report
+type batchOutputFile struct {
+	Name    string
+	Content []byte
+}
+
+// BatchComparisonMatrix lines searches up side by side
+type BatchComparisonMatrix struct {
+	Name         string                  `json:"name"`
+	Searches     []string                `json:"searches"`
+	Metrics      []BatchMatrixRow        `json:"metrics"`
+	Repositories []BatchMatrixRow        `json:"repositories"`
+	Comparisons  []BatchComparisonResult `json:"comparisons,omitempty"`
+}
+
+// BatchMatrixRow holds one value per search
+type BatchMatrixRow struct {
+	Label  string   `json:"label"`
+	Values []string `json:"values"`
+}
+
+// batchItemRow is a flattened search result used by tabular formats
+type batchItemRow struct {
+	Search     string
+	Repository string
+	Path       string
+	Stars      int
+	Language   string
+	URL        string
+}
+
+// validateBatchOutput applies output defaults and checks layout and file type
+func validateBatchOutput(outputConfig *BatchOutputConfig) error {
+	if outputConfig.Format == "" {
+		outputConfig.Format = BatchLayoutCombined
+	}
+	if outputConfig.Type == "" {
+		outputConfig.Type = OutputFormatMarkdown
+	}
+
+	if !slices.Contains(batchLayouts, outputConfig.Format) {
+		return fmt.Errorf("unsupported output format: %s (supported: %s)", outputConfig.Format, strings.Join(batchLayouts, ", "))
+	}
+	if !slices.Contains(batchFileTypes, outputConfig.Type) {
+		return fmt.Errorf("unsupported output type: %s (supported: %s)", outputConfig.Type, strings.Join(batchFileTypes, ", "))
+	}
+	if outputConfig.Type == OutputFormatSARIF && outputConfig.Format == BatchLayoutComparison {
+		return fmt.Errorf("sarif output has no comparison layout\n\n💡 Use output.format combined or separate to report each search as a SARIF rule")
+	}
+
+	if len(outputConfig.Columns) > 0 {
+		columns, err := output.ParseColumns(outputConfig.Columns)
+		if err != nil {
+			return err
+		}
+		outputConfig.Columns = columns
+	}
+	return nil
+}
+
+// writeBatchOutputFiles writes rendered reports into directory and lists them.
+// Reports can quote private code, so they are readable by the user only.
+func writeBatchOutputFiles(directory string, files []batchOutputFile) error {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", directory, err)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(directory, file.Name)
+		if err := os.WriteFile(path, file.Content, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("📁 Wrote %s\n", path)
+	}
+	return nil
+}
+
+// renderBatchOutput renders results in the configured layout and file type
+func renderBatchOutput(results *BatchResults, outputConfig BatchOutputConfig) ([]batchOutputFile, error) {
+	ext := batchFileExtensions[outputConfig.Type]
+	baseName := batchFileName(results.Name, "batch-results")
+
+	switch outputConfig.Format {
+	case BatchLayoutSeparate:
+		var files []batchOutputFile
+		used := make(map[string]int)
+		for _, result := range results.Results {
+			content, err := renderBatchSearch(result, outputConfig)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, batchOutputFile{Name: uniqueFileName(batchFileName(result.Name, "search"), used) + ext, Content: content})
+		}
+		// Comparisons do not belong to any one search, so they get their own
+		// file; SARIF has no place for them
+		if len(results.Comparisons) > 0 && outputConfig.Type != OutputFormatSARIF {
+			content, err := renderBatchComparison(results, outputConfig.Type)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, batchOutputFile{Name: uniqueFileName(baseName+"-comparison", used) + ext, Content: content})
+		}
+		return files, nil
+
+	case BatchLayoutComparison:
+		content, err := renderBatchComparison(results, outputConfig.Type)
+		if err != nil {
+			return nil, err
+		}
+		return []batchOutputFile{{Name: baseName + "-comparison" + ext, Content: content}}, nil
+
+	default:
+		content, err := renderBatchCombined(results, outputConfig)
+		if err != nil {
+			return nil, err
+		}
+		return []batchOutputFile{{Name: baseName + ext, Content: content}}, nil
+	}
+}
+
+// renderBatchCombined renders every search in a single report
+func renderBatchCombined(results *BatchResults, outputConfig BatchOutputConfig) ([]byte, error) {
+	switch outputConfig.Type {
+	case OutputFormatJSON:
+		return marshalBatchJSON(results)
+	case OutputFormatCSV, OutputFormatTSV:
+		return renderBatchItemsTable(results.Results, outputConfig)
+	case OutputFormatHTML:
+		var matrix *BatchComparisonMatrix
+		if len(results.Results) > 1 {
+			matrix = buildComparisonMatrix(results)
+		}
+		return batchHTMLReport("Batch Search Results: "+results.Name, results.Description, batchSummaryLines(results), results.Results, matrix)
+	case OutputFormatSARIF:
+		return batchSARIFReport(results.Results)
+	default:
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "# 🔍 Batch Search Results: %s\n\n", results.Name)
+		if results.Description != "" {
+			fmt.Fprintf(&buf, "📋 %s\n\n", results.Description)
+		}
+		for _, line := range batchSummaryLines(results) {
+			fmt.Fprintf(&buf, "%s\n", line)
+		}
+		buf.WriteString("\n")
+
+		for i, result := range results.Results {
+			writeBatchSearchMarkdown(&buf, fmt.Sprintf("## %d. ", i+1), result)
+		}
+		writeBatchComparisonsMarkdown(&buf, results.Comparisons)
+		return []byte(buf.String()), nil
+	}
+}
+
+// renderBatchSearch renders a single search for the separate layout
+func renderBatchSearch(result BatchSearchResult, outputConfig BatchOutputConfig) ([]byte, error) {
+	switch outputConfig.Type {
+	case OutputFormatJSON:
+		return marshalBatchJSON(result)
+	case OutputFormatCSV, OutputFormatTSV:
+		return renderBatchItemsTable([]BatchSearchResult{result}, outputConfig)
+	case OutputFormatHTML:
+		return batchHTMLReport(result.Name, "", nil, []BatchSearchResult{result}, nil)
+	case OutputFormatSARIF:
+		return batchSARIFReport([]BatchSearchResult{result})
+	default:
+		var buf strings.Builder
+		writeBatchSearchMarkdown(&buf, "# ", result)
+		return []byte(buf.String()), nil
+	}
+}
+
+// renderBatchComparison renders the side-by-side comparison matrix
+func renderBatchComparison(results *BatchResults, fileType string) ([]byte, error) {
+	matrix := buildComparisonMatrix(results)
+
+	switch fileType {
+	case OutputFormatJSON:
+		return marshalBatchJSON(matrix)
+	case OutputFormatCSV, OutputFormatTSV:
+		return renderMatrixTable(matrix, fileType)
+	case OutputFormatHTML:
+		return batchHTMLReport("Batch Comparison: "+results.Name, results.Description, batchSummaryLines(results), nil, matrix)
+	default:
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "# 📊 Batch Comparison: %s\n\n", results.Name)
+		if results.Description != "" {
+			fmt.Fprintf(&buf, "📋 %s\n\n", results.Description)
+		}
+
+		buf.WriteString("## Overview\n\n")
+		writeMatrixMarkdown(&buf, "", matrix.Searches, matrix.Metrics)
+
+		if len(matrix.Repositories) > 0 {
+			buf.WriteString("## Repositories\n\n")
+			writeMatrixMarkdown(&buf, "Repository", matrix.Searches, matrix.Repositories)
+		}
+
+		writeBatchComparisonsMarkdown(&buf, matrix.Comparisons)
+		return []byte(buf.String()), nil
+	}
+}
+
+// buildComparisonMatrix summarizes each search into comparable metrics and
+// counts matches per repository across searches
+func buildComparisonMatrix(results *BatchResults) *BatchComparisonMatrix {
+	matrix := &BatchComparisonMatrix{
+		Name:        results.Name,
+		Comparisons: results.Comparisons,
+	}
+
+	metricLabels := []string{"Status", "Results", "Repositories", "Average stars", "Top language", "Top repository"}
+	metrics := make([]BatchMatrixRow, len(metricLabels))
+	for i, label := range metricLabels {
+		metrics[i].Label = label
+	}
+
+	repoCounts := make(map[string][]int)
+	repoTotals := make(map[string]int)
+
+	for col, result := range results.Results {
+		matrix.Searches = append(matrix.Searches, result.Name)
+		rows := batchItemRows(result)
+
+		status := "✅ ok"
+		if result.Error != "" {
+			status = "❌ failed"
+		}
+
+		perRepo := make(map[string]int)
+		languages := make(map[string]int)
+		repoStars := make(map[string]int)
+		for _, row := range rows {
+			perRepo[row.Repository]++
+			repoStars[row.Repository] = row.Stars
+			if row.Language != "" {
+				languages[row.Language]++
+			}
+		}
+
+		totalStars := 0
+		for _, stars := range repoStars {
+			totalStars += stars
+		}
+		averageStars := "-"
+		if len(repoStars) > 0 {
+			averageStars = strconv.Itoa(totalStars / len(repoStars))
+		}
+
+		values := []string{status, strconv.Itoa(result.ResultCount), strconv.Itoa(len(perRepo)), averageStars, topKey(languages), topKey(perRepo)}
+		for i := range metrics {
+			metrics[i].Values = append(metrics[i].Values, values[i])
+		}
+
+		for repo, count := range perRepo {
+			if _, ok := repoCounts[repo]; !ok {
+				repoCounts[repo] = make([]int, len(results.Results))
+			}
+			repoCounts[repo][col] = count
+			repoTotals[repo] += count
+		}
+	}
+	matrix.Metrics = metrics
+
+	// Repositories matched by the most results first, then by name
+	repos := make([]string, 0, len(repoCounts))
+	for repo := range repoCounts {
+		repos = append(repos, repo)
+	}
+	slices.SortFunc(repos, func(a, b string) int {
+		if repoTotals[a] != repoTotals[b] {
+			return repoTotals[b] - repoTotals[a]
+		}
+		return strings.Compare(a, b)
+	})
+	if len(repos) > maxMatrixRepositories {
+		repos = repos[:maxMatrixRepositories]
+	}
+
+	for _, repo := range repos {
+		row := BatchMatrixRow{Label: repo}
+		for _, count := range repoCounts[repo] {
+			row.Values = append(row.Values, strconv.Itoa(count))
+		}
+		matrix.Repositories = append(matrix.Repositories, row)
+	}
+
+	return matrix
+}
+
+// batchSummaryLines describes what a batch run found
+func batchSummaryLines(results *BatchResults) []string {
+	lines := []string{fmt.Sprintf("📊 Executed %d searches, found %d total results", results.SearchCount, results.TotalResults)}
+	if results.FailedCount > 0 {
+		lines = append(lines, fmt.Sprintf("⚠️  %d of %d searches failed, results are partial", results.FailedCount, results.SearchCount))
+	}
+	return lines
+}
+
+// writeBatchSearchMarkdown writes one search's heading and results
+func writeBatchSearchMarkdown(buf *strings.Builder, headingPrefix string, result BatchSearchResult) {
+	if result.Error != "" {
+		fmt.Fprintf(buf, "%s%s ❌ failed\n", headingPrefix, result.Name)
+		fmt.Fprintf(buf, "**Query:** `%s`\n", result.Query)
+		fmt.Fprintf(buf, "**Error:** %s\n\n", firstLine(result.Error))
+		return
+	}
+
+	fmt.Fprintf(buf, "%s%s (%d results)\n", headingPrefix, result.Name, result.ResultCount)
+	fmt.Fprintf(buf, "**Query:** `%s`\n", result.Query)
+	if len(result.Tags) > 0 {
+		fmt.Fprintf(buf, "**Tags:** %s\n", strings.Join(result.Tags, ", "))
+	}
+	buf.WriteString("\n")
+
+	for _, row := range batchItemRows(result) {
+		if row.URL != "" {
+			fmt.Fprintf(buf, "- **[%s](%s)** (%s) ⭐ %d\n", row.Path, row.URL, row.Repository, row.Stars)
+		} else {
+			fmt.Fprintf(buf, "- **%s** (%s) ⭐ %d\n", row.Path, row.Repository, row.Stars)
+		}
+	}
+	buf.WriteString("\n")
+
+	if !result.Patterns.Empty() {
+		buf.WriteString("**Common Patterns:**\n\n")
+		buf.WriteString(analysis.FormatMarkdown(result.Patterns))
+		buf.WriteString("\n")
+	}
+}
+
+// writeBatchComparisonsMarkdown writes the comparison analysis section
+func writeBatchComparisonsMarkdown(buf *strings.Builder, comparisons []BatchComparisonResult) {
+	if len(comparisons) == 0 {
+		return
+	}
+
+	buf.WriteString("## Analysis & Comparisons\n\n")
+	for _, comparison := range comparisons {
+		fmt.Fprintf(buf, "### %s\n", comparison.Name)
+		fmt.Fprintf(buf, "%s\n\n", comparison.Summary)
+
+		if len(comparison.CommonPatterns) > 0 {
+			buf.WriteString("**Common Patterns:**\n")
+			for _, pattern := range comparison.CommonPatterns {
+				fmt.Fprintf(buf, "- %s\n", pattern)
+			}
+			buf.WriteString("\n")
+		}
+
+		if len(comparison.KeyDifferences) > 0 {
+			buf.WriteString("**Key Differences:**\n")
+			for _, diff := range comparison.KeyDifferences {
+				fmt.Fprintf(buf, "- %s\n", diff)
+			}
+			buf.WriteString("\n")
+		}
+	}
+}
+
+// writeMatrixMarkdown writes a matrix as a markdown table
+func writeMatrixMarkdown(buf *strings.Builder, corner string, searches []string, rows []BatchMatrixRow) {
+	header := append([]string{corner}, searches...)
+	fmt.Fprintf(buf, "| %s |\n", strings.Join(escapeTableCells(header), " | "))
+	fmt.Fprintf(buf, "|%s\n", strings.Repeat("---|", len(header)))
+	for _, row := range rows {
+		cells := append([]string{row.Label}, row.Values...)
+		fmt.Fprintf(buf, "| %s |\n", strings.Join(escapeTableCells(cells), " | "))
+	}
+	buf.WriteString("\n")
+}
+
+// escapeTableCells escapes pipes so cell text cannot break a markdown table
+func escapeTableCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", "\\|")
+	}
+	return escaped
+}
+
+// renderBatchItemsTable writes one row per search result: the search name
+// followed by the configured columns
+func renderBatchItemsTable(results []BatchSearchResult, outputConfig BatchOutputConfig) ([]byte, error) {
+	columns := outputConfig.Columns
+	if len(columns) == 0 {
+		columns = output.DefaultColumns
+	}
+
+	records := [][]string{append([]string{"search"}, columns...)}
+	for _, result := range results {
+		if result.Results == nil {
+			continue
+		}
+		for i := range result.Results.Items {
+			records = append(records, append([]string{result.Name}, output.ColumnValues(&result.Results.Items[i], columns)...))
+		}
+	}
+	return writeTableRecords(records, outputConfig.Type)
+}
+
+// renderMatrixTable writes metric rows followed by repository rows
+func renderMatrixTable(matrix *BatchComparisonMatrix, fileType string) ([]byte, error) {
+	records := [][]string{append([]string{"kind", "name"}, matrix.Searches...)}
+	for _, row := range matrix.Metrics {
+		records = append(records, append([]string{"metric", row.Label}, row.Values...))
+	}
+	for _, row := range matrix.Repositories {
+		records = append(records, append([]string{"repository", row.Label}, row.Values...))
+	}
+	return writeTableRecords(records, fileType)
+}
+
+// writeTableRecords encodes records as CSV, or as TSV for the tsv file type
+func writeTableRecords(records [][]string, fileType string) ([]byte, error) {
+	comma := ','
+	if fileType == OutputFormatTSV {
+		comma = '\t'
+	}
+	table, err := output.WriteTable(records, comma)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(table), nil
+}
+
+// marshalBatchJSON encodes v as indented JSON with a trailing newline
+func marshalBatchJSON(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// batchItemRows flattens a search's results for tabular output
+func batchItemRows(result BatchSearchResult) []batchItemRow {
+	if result.Results == nil {
+		return nil
+	}
+
+	rows := make([]batchItemRow, 0, len(result.Results.Items))
+	for _, item := range result.Results.Items {
+		rows = append(rows, batchItemRow{
+			Search:     result.Name,
+			Repository: derefString(item.Repository.FullName),
+			Path:       derefString(item.Path),
+			Stars:      derefInt(item.Repository.StargazersCount),
+			Language:   derefString(item.Repository.Language),
+			URL:        derefString(item.HTMLURL),
+		})
+	}
+	return rows
+}
+
+// topKey returns the key with the highest count, breaking ties by name
+func topKey(counts map[string]int) string {
+	best, bestCount := "-", 0
+	for key, count := range counts {
+		if count > bestCount || (count == bestCount && key < best) {
+			best, bestCount = key, count
+		}
+	}
+	return best
+}
+
+// batchFileName turns a search or batch name into a safe file name
+func batchFileName(name, fallback string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.':
+			b.WriteRune(r)
+			lastDash = false
+		case !lastDash && b.Len() > 0:
+			b.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	fileName := strings.Trim(b.String(), "-.")
+	if fileName == "" {
+		return fallback
+	}
+	return fileName
+}
+
+// uniqueFileName appends a counter when different names map to the same file
+func uniqueFileName(name string, used map[string]int) string {
+	used[name]++
+	if used[name] == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, used[name])
+}
+
+// derefString returns the value of s, or "" when nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// derefInt returns the value of i, or 0 when nil
+func derefInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// batchHTMLReport renders searches as tabs of an HTML report, followed by
+// a comparison tab when matrix is set
+func batchHTMLReport(title, description string, summary []string, results []BatchSearchResult, matrix *BatchComparisonMatrix) ([]byte, error) {
+	report := output.HTMLReport{
+		Title:       title,
+		Description: description,
+		Summary:     summary,
+		MaxLines:    currentConfig().Output.MaxContentLines,
+	}
+
+	for _, result := range results {
+		section := output.HTMLSection{
+			Name:    result.Name,
+			Query:   result.Query,
+			Tags:    result.Tags,
+			Error:   firstLine(result.Error),
+			Results: result.Results,
+		}
+		if section.Error == "" && section.Results == nil {
+			section.Results = &github.SearchResults{}
+		}
+		if result.Patterns != nil && !result.Patterns.Empty() {
+			extra, err := executeHTMLFragment(batchPatternsHTML, result.Patterns)
+			if err != nil {
+				return nil, err
+			}
+			section.Extra = extra
+		}
+		report.Sections = append(report.Sections, section)
+	}
+
+	if matrix != nil {
+		extra, err := executeHTMLFragment(batchComparisonHTML, matrix)
+		if err != nil {
+			return nil, err
+		}
+		report.Sections = append(report.Sections, output.HTMLSection{Name: "Comparison", Extra: extra})
+	}
+	return report.Render()
+}
+
+// executeHTMLFragment renders part of a report for an HTMLSection
+func executeHTMLFragment(tmpl *template.Template, data interface{}) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// batchSARIFReport reports each search as a SARIF rule identified by its
+// file name, tagged with the search's tags
+func batchSARIFReport(results []BatchSearchResult) ([]byte, error) {
+	var report output.SARIFReport
+	used := make(map[string]int)
+	for _, result := range results {
+		report.Rules = append(report.Rules, output.SARIFRule{
+			ID:      uniqueFileName(batchFileName(result.Name, "search"), used),
+			Name:    result.Name,
+			Query:   result.Query,
+			Tags:    result.Tags,
+			Error:   firstLine(result.Error),
+			Results: result.Results,
+		})
+	}
+	return report.Render()
+}
+
+var batchPatternsHTML = template.Must(template.New("patterns").Parse(`
+<h3>Common Patterns</h3>
+<p>{{.Summary}}</p>
+<table>
+<thead><tr><th>Kind</th><th>Pattern</th><th>Results</th></tr></thead>
+<tbody>
+{{- range .Keys}}
+<tr><td>key</td><td><code>{{.Text}}</code></td><td>{{.Count}}</td></tr>
+{{- end}}
+{{- range .Values}}
+<tr><td>value</td><td><code>{{.Text}}</code></td><td>{{.Count}}</td></tr>
+{{- end}}
+{{- range .Lines}}
+<tr><td>line</td><td><code>{{.Text}}</code></td><td>{{.Count}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+`))
+
+var batchComparisonHTML = template.Must(template.New("comparison").Parse(`
+<h3>Overview</h3>
+<table>
+<thead><tr><th></th>{{range .Searches}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Metrics}}
+<tr><th>{{.Label}}</th>{{range .Values}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- if .Repositories}}
+<h3>Repositories</h3>
+<table>
+<thead><tr><th>Repository</th>{{range .Searches}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Repositories}}
+<tr><th>{{.Label}}</th>{{range .Values}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Comparisons}}
+<h3>Analysis &amp; Comparisons</h3>
+{{- range .Comparisons}}
+<h4>{{.Name}}</h4>
+<p>{{.Summary}}</p>
+{{- if .CommonPatterns}}
+<h5>Common Patterns</h5>
+<ul>{{range .CommonPatterns}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .KeyDifferences}}
+<h5>Key Differences</h5>
+<ul>{{range .KeyDifferences}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- end}}
+{{- end}}
+`))
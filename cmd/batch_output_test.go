@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/analysis"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBatchResults builds results for two successful searches and one failure
+func testBatchResults() *BatchResults {
+	configs := github.CreateTestSearchResults(3,
+		github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", `"strict": true`),
+		github.CreateTestSearchItem("vercel/next.js", "packages/tsconfig.json", `"strict": true`),
+		github.CreateTestSearchItem("facebook/react", "tsconfig.json", "{}"),
+	)
+	configs.Items[2].Repository.StargazersCount = github.IntPtr(200)
+	configs.Items[2].Repository.Language = github.StringPtr("JavaScript")
+
+	dockerfiles := github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "<script>Dockerfile", "FROM node"),
+	)
+
+	return &BatchResults{
+		Name:         "Tech Stack | Audit",
+		Description:  "Compare configs",
+		SearchCount:  3,
+		TotalResults: 4,
+		FailedCount:  1,
+		Results: []BatchSearchResult{
+			{Name: "TypeScript Configs", Query: "tsconfig", Tags: []string{"ts"}, ResultCount: 3, Results: configs,
+				Patterns: analysis.Analyze(configs, analysis.Settings{MinPatternCount: 2})},
+			{Name: "dockerfiles", Query: "dockerfile", ResultCount: 1, Results: dockerfiles},
+			{Name: "broken", Query: "broken", Error: "validation failed"},
+		},
+		Comparisons: []BatchComparisonResult{{Name: "Overall Analysis", Summary: "Analyzed 2 searches"}},
+	}
+}
+
+func TestValidateBatchOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      BatchOutputConfig
+		want        BatchOutputConfig
+		errContains string
+	}{
+		{
+			name:   "defaults",
+			config: BatchOutputConfig{},
+			want:   BatchOutputConfig{Format: BatchLayoutCombined, Type: OutputFormatMarkdown},
+		},
+		{
+			name:   "explicit values",
+			config: BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatCSV},
+			want:   BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatCSV},
+		},
+		{
+			name:   "columns",
+			config: BatchOutputConfig{Type: OutputFormatTSV, Columns: []string{"repo, Stars", "fragment"}},
+			want:   BatchOutputConfig{Format: BatchLayoutCombined, Type: OutputFormatTSV, Columns: []string{"repo", "stars", "fragment"}},
+		},
+		{
+			name:        "unknown column",
+			config:      BatchOutputConfig{Type: OutputFormatCSV, Columns: []string{"owner"}},
+			errContains: "unknown column: owner",
+		},
+		{
+			name:        "unknown layout",
+			config:      BatchOutputConfig{Format: "grid"},
+			errContains: "unsupported output format: grid",
+		},
+		{
+			name:        "unknown type",
+			config:      BatchOutputConfig{Type: "pdf"},
+			errContains: "unsupported output type: pdf",
+		},
+		{
+			name:        "sarif comparison",
+			config:      BatchOutputConfig{Format: BatchLayoutComparison, Type: OutputFormatSARIF},
+			errContains: "sarif output has no comparison layout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBatchOutput(&tt.config)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.config)
+		})
+	}
+}
+
+func TestRenderBatchOutput_FileNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    BatchOutputConfig
+		wantFiles []string
+	}{
+		{
+			name:      "combined",
+			config:    BatchOutputConfig{Format: BatchLayoutCombined, Type: OutputFormatMarkdown},
+			wantFiles: []string{"tech-stack-audit.md"},
+		},
+		{
+			name:      "separate",
+			config:    BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatJSON},
+			wantFiles: []string{"typescript-configs.json", "dockerfiles.json", "broken.json", "tech-stack-audit-comparison.json"},
+		},
+		{
+			name:      "separate tsv",
+			config:    BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatTSV},
+			wantFiles: []string{"typescript-configs.tsv", "dockerfiles.tsv", "broken.tsv", "tech-stack-audit-comparison.tsv"},
+		},
+		{
+			name:      "separate sarif has no comparison file",
+			config:    BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatSARIF},
+			wantFiles: []string{"typescript-configs.sarif", "dockerfiles.sarif", "broken.sarif"},
+		},
+		{
+			name:      "comparison",
+			config:    BatchOutputConfig{Format: BatchLayoutComparison, Type: OutputFormatHTML},
+			wantFiles: []string{"tech-stack-audit-comparison.html"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := renderBatchOutput(testBatchResults(), tt.config)
+			require.NoError(t, err)
+
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name)
+				assert.NotEmpty(t, file.Content)
+			}
+			assert.Equal(t, tt.wantFiles, names)
+		})
+	}
+}
+
+func TestRenderBatchCombined(t *testing.T) {
+	results := testBatchResults()
+
+	t.Run("markdown", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatMarkdown})
+		require.NoError(t, err)
+		report := string(content)
+		assert.Contains(t, report, "# 🔍 Batch Search Results: Tech Stack | Audit")
+		assert.Contains(t, report, "⚠️  1 of 3 searches failed")
+		assert.Contains(t, report, "## 1. TypeScript Configs (3 results)")
+		assert.Contains(t, report, "[packages/tsconfig.json](https://github.com/vercel/next.js/blob/main/packages/tsconfig.json)")
+		assert.Contains(t, report, "## 3. broken ❌ failed")
+		assert.Contains(t, report, "**Common Patterns:**\n\n*Patterns in at least 2 of 3 results")
+		assert.Contains(t, report, "### Overall Analysis")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatJSON})
+		require.NoError(t, err)
+		var decoded BatchResults
+		require.NoError(t, json.Unmarshal(content, &decoded))
+		assert.Equal(t, results.Name, decoded.Name)
+		assert.Len(t, decoded.Results, 3)
+		assert.Equal(t, "validation failed", decoded.Results[2].Error)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatCSV})
+		require.NoError(t, err)
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 5) // header + 4 results
+		assert.Equal(t, []string{"search", "repo", "path", "url", "stars", "language"}, records[0])
+		assert.Equal(t, []string{"TypeScript Configs", "facebook/react", "tsconfig.json",
+			"https://github.com/facebook/react/blob/main/tsconfig.json", "200", "JavaScript"}, records[3])
+	})
+
+	t.Run("tsv with columns", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatTSV, Columns: []string{"repo", "stars"}})
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 5)
+		assert.Equal(t, "search\trepo\tstars", lines[0])
+		assert.Equal(t, "TypeScript Configs\tfacebook/react\t200", lines[3])
+	})
+
+	t.Run("html escapes content", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatHTML})
+		require.NoError(t, err)
+		report := string(content)
+		assert.True(t, strings.HasPrefix(report, "<!DOCTYPE html>"))
+		assert.Contains(t, report, "TypeScript Configs (3 results)")
+		assert.Contains(t, report, "&lt;script&gt;Dockerfile")
+		assert.Contains(t, report, "<h3>Common Patterns</h3>")
+		assert.NotContains(t, report, "<script>Dockerfile")
+
+		// One tab per search, then the comparison
+		assert.Contains(t, report, `<button role="tab" data-tab="section-1" aria-selected="false">TypeScript Configs<span class="count">3</span></button>`)
+		assert.Contains(t, report, `<section class="panel" id="section-3" role="tabpanel">
+<h2>broken <span class="failed">failed</span></h2>`)
+		assert.Contains(t, report, `data-tab="section-4" aria-selected="false">Comparison</button>`)
+		assert.Contains(t, report, "<h3>Overview</h3>")
+		assert.Contains(t, report, "<h4>Overall Analysis</h4>")
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		content, err := renderBatchCombined(results, BatchOutputConfig{Type: OutputFormatSARIF})
+		require.NoError(t, err)
+
+		var log struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Rules []struct {
+							ID         string `json:"id"`
+							Name       string `json:"name"`
+							Properties struct {
+								Tags []string `json:"tags"`
+							} `json:"properties"`
+						} `json:"rules"`
+					} `json:"driver"`
+				} `json:"tool"`
+				Invocations []struct {
+					ExecutionSuccessful bool `json:"executionSuccessful"`
+				} `json:"invocations"`
+				Results []struct {
+					RuleID    string `json:"ruleId"`
+					RuleIndex int    `json:"ruleIndex"`
+				} `json:"results"`
+			} `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(content, &log))
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		run := log.Runs[0]
+
+		// One rule per search, identified like its file name
+		require.Len(t, run.Tool.Driver.Rules, 3)
+		assert.Equal(t, "typescript-configs", run.Tool.Driver.Rules[0].ID)
+		assert.Equal(t, "TypeScript Configs", run.Tool.Driver.Rules[0].Name)
+		assert.Equal(t, []string{"ts"}, run.Tool.Driver.Rules[0].Properties.Tags)
+		assert.Equal(t, "broken", run.Tool.Driver.Rules[2].ID)
+
+		// One result per item, and the failed search marks the run unsuccessful
+		require.Len(t, run.Results, 4)
+		assert.Equal(t, "typescript-configs", run.Results[0].RuleID)
+		assert.Equal(t, "dockerfiles", run.Results[3].RuleID)
+		assert.Equal(t, 1, run.Results[3].RuleIndex)
+		require.Len(t, run.Invocations, 1)
+		assert.False(t, run.Invocations[0].ExecutionSuccessful)
+	})
+}
+
+func TestBuildComparisonMatrix(t *testing.T) {
+	matrix := buildComparisonMatrix(testBatchResults())
+
+	assert.Equal(t, []string{"TypeScript Configs", "dockerfiles", "broken"}, matrix.Searches)
+
+	metrics := make(map[string][]string)
+	for _, row := range matrix.Metrics {
+		metrics[row.Label] = row.Values
+	}
+	assert.Equal(t, []string{"✅ ok", "✅ ok", "❌ failed"}, metrics["Status"])
+	assert.Equal(t, []string{"3", "1", "0"}, metrics["Results"])
+	assert.Equal(t, []string{"2", "1", "0"}, metrics["Repositories"])
+	assert.Equal(t, []string{"600", "1000", "-"}, metrics["Average stars"])
+	assert.Equal(t, []string{"JavaScript", "-", "-"}, metrics["Top language"])
+	assert.Equal(t, []string{"vercel/next.js", "facebook/react", "-"}, metrics["Top repository"])
+
+	require.Len(t, matrix.Repositories, 2)
+	assert.Equal(t, BatchMatrixRow{Label: "facebook/react", Values: []string{"1", "1", "0"}}, matrix.Repositories[0])
+	assert.Equal(t, BatchMatrixRow{Label: "vercel/next.js", Values: []string{"2", "0", "0"}}, matrix.Repositories[1])
+}
+
+func TestRenderBatchComparison(t *testing.T) {
+	results := testBatchResults()
+
+	t.Run("markdown", func(t *testing.T) {
+		content, err := renderBatchComparison(results, OutputFormatMarkdown)
+		require.NoError(t, err)
+		report := string(content)
+		assert.Contains(t, report, "|  | TypeScript Configs | dockerfiles | broken |")
+		assert.Contains(t, report, "| Results | 3 | 1 | 0 |")
+		assert.Contains(t, report, "| facebook/react | 1 | 1 | 0 |")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		content, err := renderBatchComparison(results, OutputFormatCSV)
+		require.NoError(t, err)
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kind", "name", "TypeScript Configs", "dockerfiles", "broken"}, records[0])
+		assert.Contains(t, records, []string{"repository", "vercel/next.js", "2", "0", "0"})
+	})
+
+	t.Run("json", func(t *testing.T) {
+		content, err := renderBatchComparison(results, OutputFormatJSON)
+		require.NoError(t, err)
+		var decoded BatchComparisonMatrix
+		require.NoError(t, json.Unmarshal(content, &decoded))
+		assert.Len(t, decoded.Metrics, 6)
+		assert.Len(t, decoded.Comparisons, 1)
+	})
+}
+
+func TestOutputBatchResults_Directory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	output := captureOutput(func() error {
+		return outputBatchResults(testBatchResults(), BatchOutputConfig{Format: BatchLayoutSeparate, Type: OutputFormatMarkdown, Directory: dir})
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "📁 Wrote "+filepath.Join(dir, "dockerfiles.md"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "typescript-configs.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# TypeScript Configs (3 results)")
+
+	_, err = os.Stat(filepath.Join(dir, "tech-stack-audit-comparison.md"))
+	assert.NoError(t, err)
+
+	// Reports are readable by the user only
+	dirInfo, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), dirInfo.Mode().Perm())
+	fileInfo, err := os.Stat(filepath.Join(dir, "dockerfiles.md"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+}
+
+func TestOutputBatchResults_Stdout(t *testing.T) {
+	output := captureOutput(func() error {
+		return outputBatchResults(testBatchResults(), BatchOutputConfig{Format: BatchLayoutCombined, Type: OutputFormatJSON})
+	})
+	require.NoError(t, output.err)
+
+	var decoded BatchResults
+	assert.NoError(t, json.Unmarshal([]byte(output.stdout), &decoded), "stdout should contain only the JSON report")
+}
+
+func TestBatchFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"security-policies", "security-policies"},
+		{"TypeScript Configs", "typescript-configs"},
+		{"../../etc/passwd", "etc-passwd"},
+		{"vue.config (v3)", "vue.config-v3"},
+		{"!!!", "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, batchFileName(tt.name, "fallback"))
+		})
+	}
+}
+
+func TestUniqueFileName(t *testing.T) {
+	used := make(map[string]int)
+	assert.Equal(t, "configs", uniqueFileName("configs", used))
+	assert.Equal(t, "configs-2", uniqueFileName("configs", used))
+	assert.Equal(t, "other", uniqueFileName("other", used))
+}
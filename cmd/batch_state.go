@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// batchStateVersion identifies the layout of batch state files
+const batchStateVersion = 1
+
+// batchStateSuffix is appended to the output directory or config name to form the state file path
+const batchStateSuffix = ".batch-state.json"
+
+// Batch search progress states
+const (
+	batchSearchRunning   = "running"
+	batchSearchCompleted = "completed"
+	batchSearchFailed    = "failed"
+)
+
+// BatchState is a checkpoint of a batch run, written after every fetched page
+type BatchState struct {
+	Version    int                          `json:"version"`
+	ConfigFile string                       `json:"config_file"`
+	StartedAt  time.Time                    `json:"started_at"`
+	UpdatedAt  time.Time                    `json:"updated_at"`
+	Searches   map[string]*BatchSearchState `json:"searches"`
+}
+
+// BatchSearchState records how far a single batch search has progressed
+type BatchSearchState struct {
+	Query    string                `json:"query"`
+	Status   string                `json:"status"`
+	NextPage int                   `json:"next_page"`
+	PerPage  int                   `json:"per_page"`
+	Results  *github.SearchResults `json:"results"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// newBatchSearchState creates the progress for a search that has not fetched any pages
+func newBatchSearchState(searchConfig BatchSearchConfig, query string) BatchSearchState {
+	return BatchSearchState{
+		Query:    query,
+		Status:   batchSearchRunning,
+		NextPage: 1,
+		PerPage:  min(batchMaxResults(searchConfig), GitHubMaxResultsPerPage),
+		Results:  &github.SearchResults{},
+	}
+}
+
+// addPage appends a fetched page and marks the search completed once
+// maxResults are collected or GitHub has no more results to return
+func (s *BatchSearchState) addPage(page *github.SearchResults, maxResults int) {
+	s.Results.Total = page.Total
+	s.Results.IncompleteResults = page.IncompleteResults
+	s.Results.Items = append(s.Results.Items, page.Items...)
+	s.NextPage++
+	s.Error = ""
+
+	exhausted := len(page.Items) < s.PerPage || (s.NextPage-1)*s.PerPage >= GitHubMaxSearchResults
+	if len(s.Results.Items) >= maxResults || exhausted {
+		if len(s.Results.Items) > maxResults {
+			s.Results.Items = s.Results.Items[:maxResults]
+		}
+		s.Status = batchSearchCompleted
+	}
+}
+
+// result converts the collected pages into a batch search result
+func (s *BatchSearchState) result(searchConfig BatchSearchConfig) BatchSearchResult {
+	return BatchSearchResult{
+		Name:        searchConfig.Name,
+		Query:       s.Query,
+		Tags:        searchConfig.Tags,
+		ResultCount: len(s.Results.Items),
+		Results:     s.Results,
+	}
+}
+
+// clone copies the state so it can be encoded while the search keeps running
+func (s BatchSearchState) clone() *BatchSearchState {
+	if s.Results != nil {
+		results := *s.Results
+		results.Items = append([]github.SearchItem(nil), s.Results.Items...)
+		s.Results = &results
+	}
+	return &s
+}
+
+// batchCheckpoint persists batch progress as searches fetch pages. It is
+// safe for concurrent use; a nil checkpoint disables persistence.
+type batchCheckpoint struct {
+	mu     sync.Mutex
+	path   string
+	state  *BatchState
+	warned bool
+}
+
+// newBatchCheckpoint creates a checkpoint at path, continuing from resumed when set
+func newBatchCheckpoint(path, configFile string, resumed *BatchState) *batchCheckpoint {
+	if absPath, err := filepath.Abs(configFile); err == nil {
+		configFile = absPath
+	}
+
+	state := resumed
+	if state == nil {
+		state = &BatchState{
+			Version:   batchStateVersion,
+			StartedAt: time.Now(),
+			Searches:  make(map[string]*BatchSearchState),
+		}
+	}
+	state.ConfigFile = configFile
+
+	return &batchCheckpoint{path: path, state: state}
+}
+
+// progress returns the saved progress for a search, or fresh progress when
+// the search has not run yet or its query changed since the checkpoint
+func (c *batchCheckpoint) progress(searchConfig BatchSearchConfig, query string) BatchSearchState {
+	if c == nil {
+		return newBatchSearchState(searchConfig, query)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	saved, ok := c.state.Searches[searchConfig.Name]
+	if !ok || saved.Query != query || saved.Results == nil || saved.NextPage < 1 || saved.PerPage < 1 {
+		return newBatchSearchState(searchConfig, query)
+	}
+	return *saved.clone()
+}
+
+// completed reports whether a search finished in a previous run
+func (c *batchCheckpoint) completed(searchConfig BatchSearchConfig, query string) bool {
+	if c == nil {
+		return false
+	}
+	progress := c.progress(searchConfig, query)
+	return progress.Status == batchSearchCompleted
+}
+
+// record saves a search's progress and writes the state file
+func (c *batchCheckpoint) record(name string, progress BatchSearchState) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Searches[name] = progress.clone()
+	c.state.UpdatedAt = time.Now()
+
+	// A failed checkpoint never fails the batch, but the user should know resume is unavailable
+	if err := writeBatchState(c.path, c.state); err != nil && !c.warned {
+		c.warned = true
+		fmt.Fprintf(os.Stderr, "⚠️  Could not save batch progress: %v\n", err)
+	}
+}
+
+// remove deletes the state file once the batch no longer needs resuming
+func (c *batchCheckpoint) remove() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = os.Remove(c.path)
+}
+
+// resumeHint tells the user how to continue an unfinished batch
+func (c *batchCheckpoint) resumeHint() string {
+	if c == nil {
+		return ""
+	}
+	if _, err := os.Stat(c.path); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("\n💾 Progress saved to %s\n   Resume with: gh scout batch --resume %s", c.path, c.path)
+}
+
+// defaultBatchStatePath places the state file next to the output directory,
+// or next to the config file when no output directory is configured
+func defaultBatchStatePath(configFile string, config *BatchConfig) string {
+	if config.Output.Directory != "" {
+		return filepath.Clean(config.Output.Directory) + batchStateSuffix
+	}
+	base := strings.TrimSuffix(filepath.Base(configFile), filepath.Ext(configFile))
+	return filepath.Join(filepath.Dir(configFile), base+batchStateSuffix)
+}
+
+// readBatchState loads a state file written by a previous batch run
+func readBatchState(path string) (*BatchState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var state BatchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Version != batchStateVersion {
+		return nil, fmt.Errorf("unsupported state file version %d (expected %d)", state.Version, batchStateVersion)
+	}
+	if state.Searches == nil {
+		state.Searches = make(map[string]*BatchSearchState)
+	}
+
+	return &state, nil
+}
+
+// writeBatchState atomically replaces the state file at path
+func writeBatchState(path string, state *BatchState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode batch state: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	// Write to a temporary file and rename so an interrupted write never corrupts the state
+	tmp, err := os.CreateTemp(dir, ".tmp-batch-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write batch state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write batch state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write batch state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write batch state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pageFailingClient fails searches for one query from a given page onwards
+type pageFailingClient struct {
+	*github.MockClient
+	query    string
+	failFrom int
+}
+
+func (c *pageFailingClient) SearchCode(ctx context.Context, query string, opts *github.SearchOptions) (*github.SearchResults, error) {
+	if query == c.query && c.failFrom > 0 && opts.ListOptions.Page >= c.failFrom {
+		return nil, &github.RateLimitError{Message: "API rate limit exceeded"}
+	}
+	return c.MockClient.SearchCode(ctx, query, opts)
+}
+
+// testSearchPage builds a page of n distinct items for repo
+func testSearchPage(repo string, page, n int) *github.SearchResults {
+	items := make([]github.SearchItem, n)
+	for i := range items {
+		items[i] = github.CreateTestSearchItem(repo, fmt.Sprintf("page%d/file%d.json", page, i), "{}")
+	}
+	return github.CreateTestSearchResults(250, items...)
+}
+
+// requestedPages returns the pages requested for query, in call order
+func requestedPages(mockClient *github.MockClient, query string) []int {
+	var pages []int
+	for _, call := range mockClient.GetCallLog() {
+		if call.Method != "SearchCode" || call.Args[0] != query {
+			continue
+		}
+		pages = append(pages, call.Args[1].(*github.SearchOptions).ListOptions.Page)
+	}
+	return pages
+}
+
+func TestBatchSearchStateAddPage(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxResults   int
+		pageSizes    []int
+		wantComplete bool
+		wantItems    int
+		wantNextPage int
+	}{
+		{"full page below limit continues", 250, []int{100}, false, 100, 2},
+		{"short page completes", 250, []int{100, 30}, true, 130, 3},
+		{"limit reached completes", 150, []int{100, 100}, true, 150, 3},
+		{"empty page completes", 50, []int{0}, true, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchConfig := BatchSearchConfig{Name: "s", Query: "q", MaxResults: tt.maxResults}
+			progress := newBatchSearchState(searchConfig, "q")
+
+			for i, size := range tt.pageSizes {
+				progress.addPage(testSearchPage("owner/repo", i+1, size), tt.maxResults)
+			}
+
+			assert.Equal(t, tt.wantComplete, progress.Status == batchSearchCompleted)
+			assert.Len(t, progress.Results.Items, tt.wantItems)
+			assert.Equal(t, tt.wantNextPage, progress.NextPage)
+		})
+	}
+}
+
+func TestBatchSearchStateSearchLimit(t *testing.T) {
+	searchConfig := BatchSearchConfig{Name: "s", Query: "q", MaxResults: 5000}
+	progress := newBatchSearchState(searchConfig, "q")
+
+	for page := 1; progress.Status != batchSearchCompleted; page++ {
+		require.LessOrEqual(t, page, 10, "pagination should stop at GitHub's result limit")
+		progress.addPage(testSearchPage("owner/repo", page, GitHubMaxResultsPerPage), searchConfig.MaxResults)
+	}
+	assert.Len(t, progress.Results.Items, GitHubMaxSearchResults)
+}
+
+func TestBatchCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run"+batchStateSuffix)
+	searchConfig := BatchSearchConfig{Name: "configs", Query: "config", MaxResults: 200}
+
+	checkpoint := newBatchCheckpoint(path, "batch.yaml", nil)
+	progress := checkpoint.progress(searchConfig, "config")
+	progress.addPage(testSearchPage("owner/repo", 1, 100), searchConfig.MaxResults)
+	checkpoint.record(searchConfig.Name, progress)
+
+	state, err := readBatchState(path)
+	require.NoError(t, err)
+	assert.Equal(t, batchStateVersion, state.Version)
+	assert.True(t, filepath.IsAbs(state.ConfigFile))
+
+	resumed := newBatchCheckpoint(path, state.ConfigFile, state)
+	restored := resumed.progress(searchConfig, "config")
+	assert.Equal(t, 2, restored.NextPage)
+	assert.Len(t, restored.Results.Items, 100)
+	assert.False(t, resumed.completed(searchConfig, "config"))
+
+	// A changed query starts over instead of mixing results
+	changed := resumed.progress(searchConfig, "config language:json")
+	assert.Equal(t, 1, changed.NextPage)
+	assert.Empty(t, changed.Results.Items)
+}
+
+func TestReadBatchState_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := readBatchState(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read file")
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{not json"), 0600))
+	_, err = readBatchState(corrupt)
+	assert.ErrorContains(t, err, "failed to parse state file")
+
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"version": 99}`), 0600))
+	_, err = readBatchState(future)
+	assert.ErrorContains(t, err, "unsupported state file version")
+}
+
+func TestDefaultBatchStatePath(t *testing.T) {
+	withDirectory := &BatchConfig{Output: BatchOutputConfig{Directory: "reports/security-audit/"}}
+	assert.Equal(t, filepath.Join("reports", "security-audit.batch-state.json"),
+		defaultBatchStatePath("examples/organization-audit.yaml", withDirectory))
+
+	withoutDirectory := &BatchConfig{}
+	assert.Equal(t, filepath.Join("examples", "organization-audit.batch-state.json"),
+		defaultBatchStatePath("examples/organization-audit.yaml", withoutDirectory))
+}
+
+func TestExecuteBatchSearches_Resume(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("config", map[int]*github.SearchResults{
+		1: testSearchPage("owner/configs", 1, 100),
+		2: testSearchPage("owner/configs", 2, 20),
+	})
+	mockClient.SetPaginatedSearchResults("dockerfile", map[int]*github.SearchResults{
+		1: testSearchPage("owner/docker", 1, 100),
+		2: testSearchPage("owner/docker", 2, 100),
+		3: testSearchPage("owner/docker", 3, 50),
+	})
+
+	retries := 0
+	config := &BatchConfig{
+		Name:            "Resumable",
+		ContinueOnError: true,
+		Searches: []BatchSearchConfig{
+			{Name: "configs", Query: "config", MaxResults: 200},
+			{Name: "dockerfiles", Query: "dockerfile", MaxResults: 300, Retries: &retries},
+		},
+	}
+
+	configFile := filepath.Join(t.TempDir(), "resumable.yaml")
+	statePath := defaultBatchStatePath(configFile, config)
+
+	originalClient := batchClient
+	defer func() { batchClient = originalClient }()
+
+	// First run: the second search hits a rate limit on page 2
+	batchClient = &pageFailingClient{MockClient: mockClient, query: "dockerfile", failFrom: 2}
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), config, newBatchCheckpoint(statePath, configFile, nil))
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "dockerfiles ❌ failed")
+	assert.Contains(t, output.stderr, "--resume "+statePath)
+
+	state, err := readBatchState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, batchSearchCompleted, state.Searches["configs"].Status)
+	assert.Equal(t, batchSearchFailed, state.Searches["dockerfiles"].Status)
+	assert.Equal(t, 2, state.Searches["dockerfiles"].NextPage)
+
+	// Second run: the finished search is skipped and the other continues at page 2
+	mockClient.ClearCallLog()
+	batchClient = mockClient
+	output = captureOutput(func() error {
+		return executeBatchSearches(context.Background(), config, newBatchCheckpoint(statePath, configFile, state))
+	})
+	require.NoError(t, output.err)
+
+	assert.Empty(t, requestedPages(mockClient, "config"))
+	assert.Equal(t, []int{2, 3}, requestedPages(mockClient, "dockerfile"))
+	assert.Contains(t, output.stdout, "## 1. configs (120 results)")
+	assert.Contains(t, output.stdout, "## 2. dockerfiles (250 results)")
+
+	_, err = os.Stat(statePath)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "state file should be removed after a complete run")
+}
+
+func TestRunBatch_ResumeUsesStateConfig(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "batch.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`name: "Resume"
+searches:
+  - name: "configs"
+    query: "config"
+    max_results: 10`), 0644))
+
+	statePath := filepath.Join(dir, "batch"+batchStateSuffix)
+	checkpoint := newBatchCheckpoint(statePath, configFile, nil)
+	progress := newBatchSearchState(BatchSearchConfig{Name: "configs", Query: "config", MaxResults: 10}, "config")
+	progress.addPage(testSearchPage("owner/repo", 1, 3), 10)
+	checkpoint.record("configs", progress)
+
+	mockClient := github.NewMockClient()
+	originalClient := batchClient
+	batchClient = mockClient
+	defer func() { batchClient = originalClient }()
+
+	originalResume := batchResume
+	batchResume = statePath
+	defer func() { batchResume = originalResume }()
+
+	output := captureOutput(func() error {
+		return runBatch(batchCmd, nil)
+	})
+	require.NoError(t, output.err)
+	assert.Equal(t, 0, mockClient.GetCallCount("SearchCode"), "completed searches should not be re-run")
+	assert.Contains(t, output.stdout, "## 1. configs (3 results)")
+}
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"math"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/silouanwright/gh-scout/internal/github"
 	"github.com/silouanwright/gh-scout/internal/search"
@@ -62,6 +66,7 @@ func TestReadBatchConfig(t *testing.T) {
 				assert.Equal(t, "Test Configuration", config.Name)
 				assert.Equal(t, "Test batch search configuration", config.Description)
 				assert.Equal(t, "combined", config.Output.Format)
+				assert.Equal(t, "markdown", config.Output.Type)
 				assert.True(t, config.Output.Compare)
 				assert.Len(t, config.Searches, 2)
 
@@ -96,6 +101,64 @@ func TestReadBatchConfig(t *testing.T) {
 			wantErr:     true,
 			errContains: "must contain at least one search",
 		},
+		{
+			name: "scheduling options",
+			yamlContent: `name: "Scheduling"
+concurrency: 4
+continue_on_error: true
+searches:
+  - name: "one"
+    query: "config"
+    retries: 0`,
+			validate: func(t *testing.T, config *BatchConfig) {
+				assert.Equal(t, 4, config.Concurrency)
+				assert.True(t, config.ContinueOnError)
+				require.NotNil(t, config.Searches[0].Retries)
+				assert.Equal(t, 0, *config.Searches[0].Retries)
+			},
+		},
+		{
+			name: "invalid concurrency",
+			yamlContent: `name: "Too Many"
+concurrency: 50
+searches:
+  - name: "one"
+    query: "config"`,
+			wantErr:     true,
+			errContains: "concurrency must be between 0 and 10, 0 uses the default",
+		},
+		{
+			name: "invalid retries",
+			yamlContent: `name: "Negative Retries"
+searches:
+  - name: "one"
+    query: "config"
+    retries: -1`,
+			wantErr:     true,
+			errContains: "retries must be between",
+		},
+		{
+			name: "duplicate search names",
+			yamlContent: `name: "Duplicates"
+searches:
+  - name: "one"
+    query: "config"
+  - name: "one"
+    query: "dockerfile"`,
+			wantErr:     true,
+			errContains: `search 2: duplicate name "one"`,
+		},
+		{
+			name: "unsupported output type",
+			yamlContent: `name: "Bad Output"
+output:
+  type: "pdf"
+searches:
+  - name: "one"
+    query: "config"`,
+			wantErr:     true,
+			errContains: "unsupported output type: pdf",
+		},
 	}
 
 	for _, tt := range tests {
@@ -190,7 +253,7 @@ func TestShowDryRunInfo(t *testing.T) {
 	// In a real implementation, you might capture stdout to verify output
 }
 
-func TestExecuteSingleBatchSearch(t *testing.T) {
+func TestRunScheduledBatchSearch(t *testing.T) {
 	// Create mock client
 	mockClient := github.NewMockClient()
 
@@ -237,7 +300,8 @@ func TestExecuteSingleBatchSearch(t *testing.T) {
 	}
 
 	// Execute search
-	result, err := executeSingleBatchSearch(context.Background(), searchConfig)
+	useFastBatchScheduling(t)
+	result, err := runScheduledBatchSearch(context.Background(), searchConfig, github.NewPerformanceTracker(), nil)
 
 	// Verify results
 	require.NoError(t, err)
@@ -324,7 +388,7 @@ func TestExecuteBatchSearches(t *testing.T) {
 	}
 
 	// Execute batch searches
-	err := executeBatchSearches(context.Background(), config)
+	err := executeBatchSearches(context.Background(), config, nil)
 
 	// Verify execution completed without error
 	assert.NoError(t, err)
@@ -333,6 +397,283 @@ func TestExecuteBatchSearches(t *testing.T) {
 	assert.Equal(t, 2, mockClient.GetCallCount("SearchCode"))
 }
 
+// useFastBatchScheduling swaps in a generous token bucket and short retry
+// delays so batch tests are not paced like real searches
+func useFastBatchScheduling(t testing.TB) {
+	t.Helper()
+	originalBucket, originalLimiter := batchTokenBucket, batchRateLimiter
+	batchTokenBucket = github.NewTokenBucket(1000, time.Second)
+	batchRateLimiter = github.NewRateLimiterWithConfig(github.RateLimiterConfig{
+		MaxRetries:    3,
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      10 * time.Millisecond,
+		BackoffFactor: 2.0,
+	})
+	t.Cleanup(func() {
+		batchTokenBucket, batchRateLimiter = originalBucket, originalLimiter
+	})
+}
+
+// slowSearchClient delays each search and records peak concurrency
+type slowSearchClient struct {
+	*github.MockClient
+	delay time.Duration
+
+	mu     sync.Mutex
+	active int
+	peak   int
+}
+
+func (c *slowSearchClient) SearchCode(ctx context.Context, query string, opts *github.SearchOptions) (*github.SearchResults, error) {
+	c.mu.Lock()
+	c.active++
+	c.peak = max(c.peak, c.active)
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.active--
+	c.mu.Unlock()
+	return c.MockClient.SearchCode(ctx, query, opts)
+}
+
+func failingBatchConfig(continueOnError bool) *BatchConfig {
+	retries := 0
+	return &BatchConfig{
+		Name:            "Partial Batch",
+		ContinueOnError: continueOnError,
+		Output:          BatchOutputConfig{Format: "combined", Compare: true},
+		Searches: []BatchSearchConfig{
+			{Name: "works", Query: "config", MaxResults: 10},
+			{Name: "breaks", Query: "broken", MaxResults: 10, Retries: &retries},
+			{Name: "also-works", Query: "dockerfile", MaxResults: 10},
+		},
+	}
+}
+
+func TestExecuteBatchSearches_ContinueOnError(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("config", github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("owner/repo1", "config.json", "{}"),
+		github.CreateTestSearchItem("owner/repo2", "config.json", "{}")))
+	mockClient.SetSearchResults("dockerfile", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("owner/repo3", "Dockerfile", "FROM alpine")))
+	mockClient.SetQueryError("broken", &github.ValidationError{Message: "Validation Failed"})
+
+	originalClient := batchClient
+	batchClient = mockClient
+	defer func() { batchClient = originalClient }()
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), failingBatchConfig(true), nil)
+	})
+
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "1 of 3 searches failed")
+	assert.Contains(t, output.stdout, "## 1. works (2 results)")
+	assert.Contains(t, output.stdout, "## 2. breaks ❌ failed")
+	assert.Contains(t, output.stdout, "Validation Failed")
+	assert.Contains(t, output.stdout, "## 3. also-works (1 results)")
+}
+
+func TestExecuteBatchSearches_StopOnError(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("config", github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("owner/repo1", "config.json", "{}"),
+		github.CreateTestSearchItem("owner/repo2", "config.json", "{}")))
+	mockClient.SetSearchResults("dockerfile", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("owner/repo3", "Dockerfile", "FROM alpine")))
+	mockClient.SetQueryError("broken", &github.ValidationError{Message: "Validation Failed"})
+
+	originalClient := batchClient
+	batchClient = mockClient
+	defer func() { batchClient = originalClient }()
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), failingBatchConfig(false), nil)
+	})
+
+	require.Error(t, output.err)
+	assert.Contains(t, output.err.Error(), "failed to execute search 'breaks'")
+	assert.Contains(t, output.err.Error(), "continue_on_error")
+}
+
+func TestExecuteBatchSearches_AllFailed(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	mockClient := github.NewMockClient()
+	mockClient.SetError("SearchCode", &github.ValidationError{Message: "Validation Failed"})
+
+	originalClient := batchClient
+	batchClient = mockClient
+	defer func() { batchClient = originalClient }()
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), failingBatchConfig(true), nil)
+	})
+
+	require.Error(t, output.err)
+	assert.Contains(t, output.err.Error(), "all 3 batch searches failed")
+}
+
+func TestExecuteBatchSearches_RetryBudget(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	mockClient := github.NewMockClient()
+	mockClient.SetQueryError("broken", errors.New("502 Bad Gateway"))
+
+	originalClient := batchClient
+	batchClient = mockClient
+	defer func() { batchClient = originalClient }()
+
+	retries := 1
+	config := &BatchConfig{
+		Name:            "Retry Budget",
+		ContinueOnError: true,
+		Searches: []BatchSearchConfig{
+			{Name: "flaky", Query: "broken", MaxResults: 10, Retries: &retries},
+		},
+	}
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), config, nil)
+	})
+
+	require.Error(t, output.err)
+	assert.Equal(t, 2, mockClient.GetCallCount("SearchCode"), "initial attempt plus one retry")
+}
+
+func TestExecuteBatchSearches_Concurrency(t *testing.T) {
+	useFastBatchScheduling(t)
+
+	client := &slowSearchClient{MockClient: github.NewMockClient(), delay: 20 * time.Millisecond}
+	originalClient := batchClient
+	batchClient = client
+	defer func() { batchClient = originalClient }()
+
+	config := &BatchConfig{Name: "Concurrent", Concurrency: 2}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		config.Searches = append(config.Searches, BatchSearchConfig{Name: name, Query: name, MaxResults: 10})
+	}
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), config, nil)
+	})
+
+	require.NoError(t, output.err)
+	assert.Equal(t, 6, client.GetCallCount("SearchCode"))
+	assert.Equal(t, 2, client.peak, "searches should run two at a time")
+}
+
+func TestExecuteBatchSearches_PerSearchHost(t *testing.T) {
+	useFastBatchScheduling(t)
+	t.Setenv("GH_HOST", "github.com")
+
+	publicClient := github.NewMockClient()
+	publicClient.SetSearchResults("tsconfig", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("public/repo", "tsconfig.json", "{}")))
+	enterpriseClient := github.NewMockClient()
+	enterpriseClient.SetSearchResults("tsconfig", github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("platform/web", "tsconfig.json", "{}"),
+		github.CreateTestSearchItem("platform/api", "tsconfig.json", "{}")))
+	enterpriseClient.SetRateLimit(&github.RateLimit{Disabled: true})
+
+	originalClient, originalClients, originalBuckets := batchClient, batchHostClients, batchHostBuckets
+	batchClient = publicClient
+	batchHostClients = map[string]github.GitHubAPI{"ghe.example.com": enterpriseClient}
+	batchHostBuckets = nil
+	defer func() {
+		batchClient, batchHostClients, batchHostBuckets = originalClient, originalClients, originalBuckets
+	}()
+
+	config := &BatchConfig{
+		Name: "Hosts",
+		Searches: []BatchSearchConfig{
+			{Name: "public", Query: "tsconfig", MaxResults: 10},
+			{Name: "internal", Query: "tsconfig", MaxResults: 10, Host: "https://GHE.example.com"},
+			{Name: "explicit public", Query: "tsconfig", MaxResults: 10, Host: "github.com"},
+		},
+	}
+
+	output := captureOutput(func() error {
+		return executeBatchSearches(context.Background(), config, nil)
+	})
+
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "## 1. public (1 results)")
+	assert.Contains(t, output.stdout, "## 2. internal (2 results)")
+	assert.Contains(t, output.stdout, "## 3. explicit public (1 results)")
+	assert.Equal(t, 2, publicClient.GetCallCount("SearchCode"))
+	assert.Equal(t, 1, enterpriseClient.GetCallCount("SearchCode"))
+
+	// The enterprise search limit is read once and sizes that host's bucket
+	assert.Equal(t, 1, enterpriseClient.GetCallCount("GetRateLimit"))
+	assert.Equal(t, math.MaxInt, batchHostBuckets["ghe.example.com"].Available())
+}
+
+func TestSearchTokenBucket(t *testing.T) {
+	client := github.NewMockClient()
+	client.SetRateLimit(&github.RateLimit{Limit: 120, Remaining: 120})
+
+	// github.com's search limit is known, so it is not requested
+	assert.Equal(t, github.SearchRequestsPerMinute, searchTokenBucket(context.Background(), client, "github.com").Available())
+	assert.Zero(t, client.GetCallCount("GetRateLimit"))
+
+	assert.Equal(t, 120, searchTokenBucket(context.Background(), client, "ghe.example.com").Available())
+
+	// Without a reported limit, enterprise searches are paced like github.com
+	client.SetError("GetRateLimit", &github.NotFoundError{Message: "ghe.example.com: Not Found"})
+	assert.Equal(t, github.SearchRequestsPerMinute, searchTokenBucket(context.Background(), client, "ghe.example.com").Available())
+}
+
+func TestResolveBatchConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   int
+		flag     int
+		searches int
+		want     int
+	}{
+		{"default", 0, 0, 5, defaultBatchConcurrency},
+		{"config value", 5, 0, 8, 5},
+		{"flag overrides config", 5, 2, 8, 2},
+		{"flag at the maximum", 0, maxBatchConcurrency, 20, maxBatchConcurrency},
+		{"capped by search count", 8, 0, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalFlag := batchConcurrency
+			batchConcurrency = tt.flag
+			defer func() { batchConcurrency = originalFlag }()
+
+			config := &BatchConfig{Concurrency: tt.config, Searches: make([]BatchSearchConfig, tt.searches)}
+			assert.Equal(t, tt.want, resolveBatchConcurrency(config))
+		})
+	}
+}
+
+func TestValidateBatchConcurrency(t *testing.T) {
+	assert.NoError(t, validateBatchConcurrency("--concurrency", 0), "0 uses the default")
+	assert.NoError(t, validateBatchConcurrency("--concurrency", maxBatchConcurrency))
+	assert.EqualError(t, validateBatchConcurrency("--concurrency", maxBatchConcurrency+1), "--concurrency must be between 0 and 10, 0 uses the default")
+	assert.EqualError(t, validateBatchConcurrency("concurrency", -1), "concurrency must be between 0 and 10, 0 uses the default")
+}
+
+func TestRunBatch_RejectsConcurrencyFlag(t *testing.T) {
+	originalFlag := batchConcurrency
+	batchConcurrency = 50
+	defer func() { batchConcurrency = originalFlag }()
+
+	err := runBatch(batchCmd, []string{"unused.yaml"})
+	assert.EqualError(t, err, "--concurrency must be between 0 and 10, 0 uses the default")
+}
+
 func TestGenerateComparisons(t *testing.T) {
 	results := []BatchSearchResult{
 		{
@@ -420,7 +761,7 @@ func BenchmarkReadBatchConfig(b *testing.B) {
 	}
 }
 
-func BenchmarkExecuteSingleBatchSearch(b *testing.B) {
+func BenchmarkRunScheduledBatchSearch(b *testing.B) {
 	// Create mock client
 	mockClient := github.NewMockClient()
 	results := &github.SearchResults{
@@ -452,9 +793,12 @@ func BenchmarkExecuteSingleBatchSearch(b *testing.B) {
 		},
 	}
 
+	useFastBatchScheduling(b)
+	tracker := github.NewPerformanceTracker()
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := executeSingleBatchSearch(context.Background(), searchConfig)
+		_, err := runScheduledBatchSearch(context.Background(), searchConfig, tracker, nil)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache <command>",
+	Short: "Manage the on-disk search result cache",
+	Long: `Manage the on-disk cache of search results and repository metadata.
+
+Caching is enabled with github.cache_results in the config file, and entries
+expire after github.cache_ttl. Use --no-cache to bypass the cache for a single
+run, or --refresh to ignore cached entries and store fresh results.`,
+	Example: `  # Enable caching
+  gh scout config set github.cache_results true
+
+  # Show cache usage
+  gh scout cache stats
+
+  # Remove expired entries
+  gh scout cache prune
+
+  # Remove everything
+  gh scout cache clear`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache size and entry counts",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached entries",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired cache entries",
+	Args:  cobra.NoArgs,
+	RunE:  runCachePrune,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	cfg := currentConfig()
+	stats, err := newResultCache(cfg).Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Println("🗄️  Search Result Cache")
+	fmt.Println()
+	fmt.Printf("  • Enabled: %t\n", cfg.GitHub.CacheResults)
+	fmt.Printf("  • TTL: %s\n", cfg.CacheTTLDuration())
+	fmt.Printf("  • Location: %s\n", stats.Directory)
+	fmt.Printf("  • Entries: %d (%d expired)\n", stats.TotalEntries, stats.ExpiredEntries)
+	fmt.Printf("  • Size: %s\n", formatBytes(stats.TotalBytes))
+
+	if len(stats.ByNamespace) > 0 {
+		namespaces := make([]string, 0, len(stats.ByNamespace))
+		for namespace := range stats.ByNamespace {
+			namespaces = append(namespaces, namespace)
+		}
+		slices.Sort(namespaces)
+		for _, namespace := range namespaces {
+			fmt.Printf("    - %s: %d\n", namespace, stats.ByNamespace[namespace])
+		}
+	}
+
+	if !stats.Oldest.IsZero() {
+		fmt.Printf("  • Oldest entry: %s ago\n", formatDuration(time.Since(stats.Oldest)))
+		fmt.Printf("  • Newest entry: %s ago\n", formatDuration(time.Since(stats.Newest)))
+	}
+
+	if !cfg.GitHub.CacheResults {
+		fmt.Println()
+		fmt.Println("💡 Enable caching with: gh scout config set github.cache_results true")
+	}
+
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	removed, err := newResultCache(currentConfig()).Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	fmt.Printf("✅ Cache cleared (%d entries removed)\n", removed)
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	removed, err := newResultCache(currentConfig()).Prune()
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+
+	fmt.Printf("✅ Cache pruned (%d expired entries removed)\n", removed)
+	return nil
+}
+
+// newResultCache creates the on-disk cache from configuration
+func newResultCache(cfg *config.Config) *github.ResultCache {
+	return github.NewResultCache(config.CacheDir(), cfg.CacheTTLDuration())
+}
+
+// cacheEnabled reports whether results should be cached for this run
+func cacheEnabled() bool {
+	return !noCache && currentConfig().GitHub.CacheResults
+}
+
+// withResultCache wraps client with the on-disk cache when caching is enabled
+func withResultCache(client github.GitHubAPI, host string) github.GitHubAPI {
+	if !cacheEnabled() {
+		return client
+	}
+	return github.NewCachingClient(client, newResultCache(currentConfig()), host, refreshCache)
+}
+
+// printCacheUsage reports cache hits and misses for verbose output
+func printCacheUsage(client github.GitHubAPI) {
+	if cachingClient, ok := client.(*github.CachingClient); ok {
+		usage := cachingClient.Usage()
+		fmt.Printf("Cache: %d hits, %d misses\n", usage.Hits, usage.Misses)
+	}
+}
+
+// formatBytes formats a byte count in a human-friendly way
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
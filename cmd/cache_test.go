@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+func TestWithResultCache(t *testing.T) {
+	originalConfig := appConfig
+	originalNoCache := noCache
+	defer func() {
+		appConfig = originalConfig
+		noCache = originalNoCache
+	}()
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		noCache     bool
+		expectCache bool
+	}{
+		{name: "caching disabled", enabled: false, expectCache: false},
+		{name: "caching enabled", enabled: true, expectCache: true},
+		{name: "no-cache flag overrides config", enabled: true, noCache: true, expectCache: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSavedConfigDir(t)
+			cfg := config.Default()
+			cfg.GitHub.CacheResults = tt.enabled
+			appConfig = cfg
+			noCache = tt.noCache
+
+			client := withResultCache(github.NewMockClient(), "github.com")
+			_, isCaching := client.(*github.CachingClient)
+			assert.Equal(t, tt.expectCache, isCaching)
+		})
+	}
+}
+
+func TestCacheCommands(t *testing.T) {
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+
+	setupSavedConfigDir(t)
+	cfg := config.Default()
+	cfg.GitHub.CacheResults = true
+	appConfig = cfg
+
+	cache := newResultCache(cfg)
+	require.NoError(t, cache.Put(github.CacheNamespaceSearch, "key", "value"))
+
+	require.NoError(t, runCacheStats(cacheStatsCmd, nil))
+	require.NoError(t, runCachePrune(cachePruneCmd, nil))
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalEntries, "prune should keep fresh entries")
+
+	require.NoError(t, runCacheClear(cacheClearCmd, nil))
+
+	stats, err = cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.TotalEntries)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 MiB", formatBytes(2*1024*1024))
+}
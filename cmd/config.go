@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/rank"
 	"github.com/spf13/cobra"
 )
 
@@ -76,7 +80,10 @@ defaults.max_results, and more.`,
   gh scout config set defaults.language "typescript"
 
   # Set default result limit
-  gh scout config set defaults.max_results 25`,
+  gh scout config set defaults.max_results 25
+
+  # Search a GitHub Enterprise Server instance by default
+  gh scout config set github.host ghe.example.com`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -114,11 +121,26 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  max_content_lines: %d\n", cfg.Output.MaxContentLines)
 	fmt.Println()
 
+	// Show ranking weights used by --sort quality
+	fmt.Println("📊 Ranking:")
+	fmt.Printf("  stars_weight: %g\n", cfg.Ranking.StarsWeight)
+	fmt.Printf("  forks_weight: %g\n", cfg.Ranking.ForksWeight)
+	fmt.Printf("  recency_weight: %g\n", cfg.Ranking.RecencyWeight)
+	fmt.Printf("  relevance_weight: %g\n", cfg.Ranking.RelevanceWeight)
+	fmt.Printf("  archived_penalty: %g\n", cfg.Ranking.ArchivedPenalty)
+	fmt.Printf("  fork_penalty: %g\n", cfg.Ranking.ForkPenalty)
+	fmt.Println()
+
 	// Show GitHub settings
 	fmt.Println("🐙 GitHub API:")
+	if cfg.GitHub.Host != "" {
+		fmt.Printf("  host: %s\n", cfg.GitHub.Host)
+	}
 	fmt.Printf("  timeout: %s\n", cfg.GitHub.Timeout)
 	fmt.Printf("  retry_count: %d\n", cfg.GitHub.RetryCount)
 	fmt.Printf("  rate_limit_buffer: %d\n", cfg.GitHub.RateLimitBuffer)
+	fmt.Printf("  cache_results: %t\n", cfg.GitHub.CacheResults)
+	fmt.Printf("  cache_ttl: %s\n", cfg.GitHub.CacheTTL)
 	fmt.Println()
 
 	// Show saved searches count
@@ -199,6 +221,15 @@ func runConfigReset(cmd *cobra.Command, args []string) error {
 	case "defaults.sort_by":
 		cfg.Defaults.SortBy = "relevance"
 		fmt.Println("✅ Default sort order reset to relevance")
+	case "github.host":
+		cfg.GitHub.Host = ""
+		fmt.Println("✅ GitHub host reset to gh's default host")
+	case "github.cache_results":
+		cfg.GitHub.CacheResults = false
+		fmt.Println("✅ Result caching reset to disabled")
+	case "github.cache_ttl":
+		cfg.GitHub.CacheTTL = "1h"
+		fmt.Println("✅ Cache TTL reset to 1h")
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -228,19 +259,40 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		cfg.Defaults.Language = value
 		fmt.Printf("✅ Default language set to: %s\n", value)
 	case "defaults.output_format":
-		validFormats := []string{"default", "json", "markdown", "compact"}
+		validFormats := []string{"default", "json", "markdown", "compact", "csv", "tsv", "ndjson", "html", "sarif"}
 		if !contains(validFormats, value) {
 			return fmt.Errorf("invalid output format: %s (valid: %s)", value, strings.Join(validFormats, ", "))
 		}
 		cfg.Defaults.OutputFormat = value
 		fmt.Printf("✅ Default output format set to: %s\n", value)
 	case "defaults.sort_by":
-		validSorts := []string{"relevance", "stars", "updated", "created"}
-		if !contains(validSorts, value) {
-			return fmt.Errorf("invalid sort order: %s (valid: %s)", value, strings.Join(validSorts, ", "))
+		sortBy, err := rank.Normalize(value)
+		if err != nil {
+			return err
+		}
+		cfg.Defaults.SortBy = sortBy
+		fmt.Printf("✅ Default sort order set to: %s\n", sortBy)
+	case "github.host":
+		host := github.NormalizeHost(value)
+		if host == "" {
+			return fmt.Errorf("invalid GitHub host: %q (e.g. github.com, ghe.example.com)", value)
+		}
+		cfg.GitHub.Host = host
+		fmt.Printf("✅ GitHub host set to: %s\n", host)
+	case "github.cache_results":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for github.cache_results: %s (valid: true, false)", value)
+		}
+		cfg.GitHub.CacheResults = enabled
+		fmt.Printf("✅ Result caching set to: %t\n", enabled)
+	case "github.cache_ttl":
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid cache TTL: %s (e.g. 30m, 1h, 24h)", value)
 		}
-		cfg.Defaults.SortBy = value
-		fmt.Printf("✅ Default sort order set to: %s\n", value)
+		cfg.GitHub.CacheTTL = value
+		fmt.Printf("✅ Cache TTL set to: %s\n", value)
 	default:
 		return fmt.Errorf("unknown or unsupported configuration key: %s", key)
 	}
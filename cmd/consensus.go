@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/silouanwright/gh-scout/internal/consensus"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// validateConsensusFlags rejects flags that --consensus cannot honor
+func validateConsensusFlags() error {
+	if len(batchRepos) > 0 || len(batchOrgs) > 0 {
+		return fmt.Errorf("--consensus cannot be combined with --repos or --orgs\n\n💡 Use --repo or --owner to narrow a consensus search")
+	}
+	if pipe {
+		return fmt.Errorf("--consensus cannot be combined with --pipe\n\n💡 Use --format json for machine-readable output")
+	}
+	switch outputFormat {
+	case "default", "", "markdown", "json":
+		return nil
+	default:
+		return fmt.Errorf("--consensus supports the default, markdown and json formats, not %s", outputFormat)
+	}
+}
+
+// outputConsensus fetches and parses every matched config file, then
+// reports the settings they have in common
+func outputConsensus(ctx context.Context, results *github.SearchResults) error {
+	if len(results.Items) == 0 {
+		fmt.Println("No results found.")
+		return nil
+	}
+
+	candidates, filename := consensusCandidates(results)
+	files := github.FetchFileData(ctx, searchClient, candidates, github.ContentFetchOptions{RateLimiter: newRateLimiter(hostname, github.ResourceCore)})
+
+	var documents []consensus.Document
+	var failures []consensus.Failure
+	for _, file := range files {
+		repository := derefString(file.Item.Repository.FullName)
+		filePath := derefString(file.Item.Path)
+
+		err := file.Err
+		var values map[string]interface{}
+		if err == nil {
+			values, err = consensus.Parse(filePath, file.Data)
+		}
+		if err != nil {
+			failures = append(failures, consensus.Failure{Repository: repository, Path: filePath, Error: firstLine(err.Error())})
+			continue
+		}
+		documents = append(documents, consensus.Document{Repository: repository, Path: filePath, Values: values})
+	}
+
+	if verbose {
+		fmt.Printf("Parsed %d of %d %s files\n", len(documents), len(files), filename)
+	}
+
+	report := consensus.Build(documents, consensus.Options{})
+	report.Filename = filename
+	report.Skipped = len(results.Items) - len(candidates.Items)
+	report.Failures = failures
+
+	var rendered string
+	var err error
+	switch outputFormat {
+	case "json":
+		rendered, err = consensus.FormatJSON(report)
+	case "markdown":
+		rendered = consensus.FormatMarkdown(report)
+	default:
+		rendered = consensus.FormatText(report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if outputFile != "" {
+		return writeToFile(rendered, outputFile)
+	}
+	fmt.Print(rendered)
+	return nil
+}
+
+// consensusCandidates keeps the results sharing the most common file name,
+// so a search for "tsconfig.json" does not mix in files that merely mention it
+func consensusCandidates(results *github.SearchResults) (*github.SearchResults, string) {
+	counts := make(map[string]int)
+	filename := ""
+	for _, item := range results.Items {
+		name := path.Base(derefString(item.Path))
+		counts[name]++
+		if counts[name] > counts[filename] {
+			filename = name
+		}
+	}
+
+	candidates := &github.SearchResults{Total: results.Total}
+	for _, item := range results.Items {
+		if path.Base(derefString(item.Path)) == filename {
+			candidates.Items = append(candidates.Items, item)
+		}
+	}
+	return candidates, filename
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/consensus"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// setupConsensusClient serves three tsconfig.json files, one unparseable, and a README mentioning them
+func setupConsensusClient(t *testing.T, query string) *github.MockClient {
+	t.Helper()
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults(query, github.CreateTestSearchResults(4,
+		github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", `"strict": true`),
+		github.CreateTestSearchItem("facebook/react", "packages/tsconfig.json", `"strict": true`),
+		github.CreateTestSearchItem("broken/repo", "tsconfig.json", `"strict"`),
+		github.CreateTestSearchItem("docs/site", "README.md", "see tsconfig.json"),
+	))
+	mockClient.SetFileContent("vercel", "next.js", "tsconfig.json", "main",
+		[]byte("{\n  // comments are allowed\n  \"compilerOptions\": {\"strict\": true, \"target\": \"ES2020\"},\n}\n"))
+	mockClient.SetFileContent("facebook", "react", "packages/tsconfig.json", "main",
+		[]byte(`{"compilerOptions": {"strict": false}}`))
+	mockClient.SetFileContent("broken", "repo", "tsconfig.json", "main", []byte("{oops"))
+
+	originalClient := searchClient
+	searchClient = mockClient
+	t.Cleanup(func() {
+		searchClient = originalClient
+		resetSearchFlags()
+	})
+	return mockClient
+}
+
+func TestSearchConsensus(t *testing.T) {
+	resetSearchFlags()
+	mockClient := setupConsensusClient(t, "tsconfig.json language:json")
+	consensusMode = true
+
+	output := captureOutput(func() error {
+		searchCmd.SetContext(context.Background())
+		return runSearch(searchCmd, []string{"tsconfig"})
+	})
+
+	require.NoError(t, output.err)
+	assert.Equal(t, 3, mockClient.GetCallCount("GetFileContent"), "only files named like the majority are fetched")
+	assert.Contains(t, output.stdout, "📋 Consensus across 2 tsconfig.json files (1 could not be fetched or parsed, 1 other files skipped)")
+	assert.Contains(t, output.stdout, "compilerOptions.strict  ")
+	assert.Contains(t, output.stdout, "100%  false 50%, true 50%")
+	assert.Contains(t, output.stdout, "broken/repo/tsconfig.json: invalid JSON")
+}
+
+func TestSearchConsensus_Formats(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		resetSearchFlags()
+		setupConsensusClient(t, "tsconfig.json")
+		consensusMode = true
+		outputFormat = "json"
+
+		output := captureOutput(func() error {
+			searchCmd.SetContext(context.Background())
+			return runSearch(searchCmd, []string{"tsconfig.json"})
+		})
+		require.NoError(t, output.err)
+
+		var report consensus.Report
+		require.NoError(t, json.Unmarshal([]byte(output.stdout), &report))
+		assert.Equal(t, 2, report.Files)
+		assert.Equal(t, "tsconfig.json", report.Filename)
+		require.NotEmpty(t, report.Keys)
+		assert.Equal(t, "compilerOptions.strict", report.Keys[0].Path)
+		require.Len(t, report.Failures, 1)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		resetSearchFlags()
+		setupConsensusClient(t, "tsconfig.json")
+		consensusMode = true
+		outputFormat = "markdown"
+
+		output := captureOutput(func() error {
+			searchCmd.SetContext(context.Background())
+			return runSearch(searchCmd, []string{"tsconfig.json"})
+		})
+		require.NoError(t, output.err)
+		assert.Contains(t, output.stdout, "| `compilerOptions.target` | 50% | `\"ES2020\"` 50% |")
+	})
+}
+
+func TestValidateConsensusFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func()
+		errContains string
+	}{
+		{name: "default format", setup: func() {}},
+		{name: "json format", setup: func() { outputFormat = "json" }},
+		{name: "compact format", setup: func() { outputFormat = "compact" }, errContains: "supports the default, markdown and json formats"},
+		{name: "pipe", setup: func() { pipe = true }, errContains: "cannot be combined with --pipe"},
+		{name: "repos", setup: func() { batchRepos = []string{"a/b"} }, errContains: "cannot be combined with --repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			consensusMode = true
+			tt.setup()
+
+			err := validateSearchFlags()
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
@@ -99,20 +99,39 @@ const (
 	OutputFormatMarkdown = "markdown"
 	OutputFormatCompact  = "compact"
 	OutputFormatPipe     = "pipe"
+	OutputFormatCSV      = "csv"
+	OutputFormatTSV      = "tsv"
+	OutputFormatHTML     = "html"
+	OutputFormatSARIF    = "sarif"
+
+	// Batch output layouts
+	BatchLayoutCombined   = "combined"
+	BatchLayoutSeparate   = "separate"
+	BatchLayoutComparison = "comparison"
 
 	// GitHub API constants
 	GitHubMaxResultsPerPage = 100
+	GitHubMaxSearchResults  = 1000 // Code search never returns more than this per query
 	GitHubSearchRateLimit   = 30
 
 	// File extensions for language detection
 	ExtensionGo         = ".go"
 	ExtensionJavaScript = ".js"
+	ExtensionJSX        = ".jsx"
+	ExtensionMJS        = ".mjs"
+	ExtensionCJS        = ".cjs"
 	ExtensionTypeScript = ".ts"
 	ExtensionTSX        = ".tsx"
+	ExtensionMTS        = ".mts"
+	ExtensionCTS        = ".cts"
 	ExtensionPython     = ".py"
+	ExtensionRuby       = ".rb"
+	ExtensionRust       = ".rs"
+	ExtensionJava       = ".java"
 	ExtensionJSON       = ".json"
 	ExtensionYAML       = ".yaml"
 	ExtensionYML        = ".yml"
+	ExtensionTOML       = ".toml"
 	ExtensionMarkdown   = ".md"
 	ExtensionDockerfile = ".dockerfile"
 
@@ -121,8 +140,12 @@ const (
 	LanguageJavaScript = "javascript"
 	LanguageTypeScript = "typescript"
 	LanguagePython     = "python"
+	LanguageRuby       = "ruby"
+	LanguageRust       = "rust"
+	LanguageJava       = "java"
 	LanguageJSON       = "json"
 	LanguageYAML       = "yaml"
+	LanguageTOML       = "toml"
 	LanguageMarkdown   = "markdown"
 	LanguageDockerfile = "dockerfile"
 
@@ -160,12 +183,21 @@ var ValidSortOptions = []string{
 var LanguageExtensionMap = map[string]string{
 	ExtensionGo:         LanguageGo,
 	ExtensionJavaScript: LanguageJavaScript,
+	ExtensionJSX:        LanguageJavaScript,
+	ExtensionMJS:        LanguageJavaScript,
+	ExtensionCJS:        LanguageJavaScript,
 	ExtensionTypeScript: LanguageTypeScript,
 	ExtensionTSX:        LanguageTypeScript,
+	ExtensionMTS:        LanguageTypeScript,
+	ExtensionCTS:        LanguageTypeScript,
 	ExtensionPython:     LanguagePython,
+	ExtensionRuby:       LanguageRuby,
+	ExtensionRust:       LanguageRust,
+	ExtensionJava:       LanguageJava,
 	ExtensionJSON:       LanguageJSON,
 	ExtensionYAML:       LanguageYAML,
 	ExtensionYML:        LanguageYAML,
+	ExtensionTOML:       LanguageTOML,
 	ExtensionMarkdown:   LanguageMarkdown,
 }
 
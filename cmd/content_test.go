@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+func TestSearchWithFetchContent(t *testing.T) {
+	item := github.CreateTestSearchItem("owner/repo", "main.go", "func main() {")
+	item.TextMatches[0].Matches = []github.Match{{Text: github.StringPtr("func main")}}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected []string
+	}{
+		{
+			name:     "default format shows numbered lines",
+			format:   "default",
+			expected: []string{"3: func main() {", "2- ", "4- \tfmt.Println"},
+		},
+		{
+			name:     "markdown format shows numbered lines",
+			format:   "markdown",
+			expected: []string{"**Code:** (main, 6 lines)", "3: func main() {"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			mockClient := github.NewMockClient()
+			mockClient.SetSearchResults("main", github.CreateTestSearchResults(1, item))
+			mockClient.SetFileContent("owner", "repo", "main.go", "main",
+				[]byte("package main\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n// end\n"))
+
+			originalClient := searchClient
+			searchClient = mockClient
+			defer func() {
+				searchClient = originalClient
+				resetSearchFlags()
+			}()
+
+			fetchContent = true
+			contextLines = 1
+			outputFormat = tt.format
+
+			output := captureOutput(func() error {
+				searchCmd.SetContext(context.Background())
+				return runSearch(searchCmd, []string{"main"})
+			})
+
+			require.NoError(t, output.err)
+			assert.Equal(t, 1, mockClient.GetCallCount("GetFileContent"))
+			for _, expected := range tt.expected {
+				assert.Contains(t, output.stdout, expected)
+			}
+		})
+	}
+}
+
+func TestSearchWithFetchContentJSON(t *testing.T) {
+	resetSearchFlags()
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("strict", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("owner/repo", "tsconfig.json", `"strict": true`),
+	))
+	mockClient.SetFileContent("owner", "repo", "tsconfig.json", "main", []byte("{\n  \"strict\": true\n}\n"))
+
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() {
+		searchClient = originalClient
+		resetSearchFlags()
+	}()
+
+	fetchContent = true
+	contextLines = 0
+	outputFormat = "json"
+
+	output := captureOutput(func() error {
+		searchCmd.SetContext(context.Background())
+		return runSearch(searchCmd, []string{"strict"})
+	})
+	require.NoError(t, output.err)
+
+	var parsed github.SearchResults
+	require.NoError(t, json.Unmarshal([]byte(output.stdout), &parsed))
+	require.Len(t, parsed.Items, 1)
+	content := parsed.Items[0].Content
+	require.NotNil(t, content)
+	require.Len(t, content.Snippets, 1)
+	assert.Equal(t, 2, content.Snippets[0].StartLine)
+	assert.Equal(t, `  "strict": true`, content.Snippets[0].Lines[0].Text)
+}
+
+func TestContentSearchTerms(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "useState language:typescript", expected: []string{"useState"}},
+		{query: `"exact phrase" filename:package.json`, expected: []string{"exact phrase"}},
+		{query: "react NOT test -path:vendor OR hooks", expected: []string{"react", "hooks"}},
+		{query: "language:go", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, contentSearchTerms(tt.query))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+
+	"github.com/silouanwright/gh-scout/internal/output"
+)
+
+var (
+	jsonFields   []string // --json fields to export, as in gh
+	jqExpr       string   // --jq expression applied to the --json output
+	templateText string   // --template Go template applied to the --json output
+)
+
+// addExportFlags registers gh's --json, --jq and --template flags on cmd
+func addExportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&jsonFields, "json", nil, "output JSON with the specified fields (run with --json and no fields to list them)")
+	cmd.Flags().StringVarP(&jqExpr, "jq", "q", "", "filter --json output using a jq expression")
+	cmd.Flags().StringVarP(&templateText, "template", "t", "", "format --json output using a Go template; see \"gh help formatting\"")
+	cmd.SetFlagErrorFunc(exportFlagError)
+}
+
+// exportFlagError lists the available fields when --json is given none,
+// the way gh does
+func exportFlagError(cmd *cobra.Command, err error) error {
+	if err.Error() == "flag needs an argument: --json" {
+		return errors.New(output.FieldsHelp("Specify one or more comma-separated fields for `--json`:"))
+	}
+	return err
+}
+
+// exportActive reports whether --json selects the output
+func exportActive() bool {
+	return len(jsonFields) > 0
+}
+
+// validateExportFlags rejects --jq and --template without --json, and
+// --json with flags that produce their own output
+func validateExportFlags() error {
+	if !exportActive() {
+		if jqExpr != "" {
+			return fmt.Errorf("cannot use `--jq` without specifying `--json`")
+		}
+		if templateText != "" {
+			return fmt.Errorf("cannot use `--template` without specifying `--json`")
+		}
+		return nil
+	}
+	if jqExpr != "" && templateText != "" {
+		return fmt.Errorf("cannot use `--jq` and `--template` together")
+	}
+	if _, err := output.ParseFields(jsonFields); err != nil {
+		return err
+	}
+	switch {
+	case pipe:
+		return fmt.Errorf("--json cannot be combined with --pipe\n\n💡 Use --jq or --template to shape the output for other tools")
+	case consensusMode:
+		return fmt.Errorf("--json cannot be combined with --consensus\n\n💡 Use --format json for the consensus report")
+	case len(batchRepos) > 0 || len(batchOrgs) > 0:
+		return fmt.Errorf("--json cannot be combined with --repos or --orgs\n\n💡 Use --repo or --owner to search several repositories at once")
+	}
+	return nil
+}
+
+// exportFormatter builds the formatter for --json, --jq and --template.
+// Colors and the terminal width apply only when writing to a terminal.
+func exportFormatter() (*output.ExportFormatter, error) {
+	fields, err := output.ParseFields(jsonFields)
+	if err != nil {
+		return nil, err
+	}
+
+	terminal := term.FromEnv()
+	width := 80
+	if terminal.IsTerminalOutput() {
+		if w, _, err := terminal.Size(); err == nil && w > 0 {
+			width = w
+		}
+	}
+
+	return &output.ExportFormatter{
+		Fields:   fields,
+		JQ:       jqExpr,
+		Template: templateText,
+		Color:    exportColorEnabled(terminal),
+		Width:    width,
+	}, nil
+}
+
+// exportColorEnabled follows --no-color and output.color_mode, otherwise
+// coloring only terminal output as gh does
+func exportColorEnabled(terminal term.Term) bool {
+	if noColor || outputFile != "" {
+		return false
+	}
+	if currentConfig().Output.ColorMode == "always" {
+		return true
+	}
+	return terminal.IsColorEnabled()
+}
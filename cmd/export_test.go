@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+func TestValidateExportFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr string
+	}{
+		{"no export flags", func() {}, ""},
+		{"fields", func() { jsonFields = []string{"path,stars"} }, ""},
+		{"jq", func() { jsonFields, jqExpr = []string{"path"}, ".[].path" }, ""},
+		{"template", func() { jsonFields, templateText = []string{"path"}, "{{len .}}" }, ""},
+		{"jq without json", func() { jqExpr = ".[]" }, "cannot use `--jq` without specifying `--json`"},
+		{"template without json", func() { templateText = "{{.}}" }, "cannot use `--template` without specifying `--json`"},
+		{"jq and template", func() { jsonFields, jqExpr, templateText = []string{"path"}, ".", "{{.}}" }, "cannot use `--jq` and `--template` together"},
+		{"unknown field", func() { jsonFields = []string{"owner"} }, "Unknown JSON field: \"owner\""},
+		{"pipe", func() { jsonFields, pipe = []string{"path"}, true }, "--json cannot be combined with --pipe"},
+		{"consensus", func() { jsonFields, consensusMode = []string{"path"}, true }, "--json cannot be combined with --consensus"},
+		{"batch", func() { jsonFields, batchRepos = []string{"path"}, []string{"cli/cli"} }, "--json cannot be combined with --repos or --orgs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			tt.setup()
+
+			err := validateSearchFlags()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExportFlagError(t *testing.T) {
+	err := exportFlagError(searchCmd, errors.New("flag needs an argument: --json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Specify one or more comma-separated fields for `--json`:\n  name\n  path\n")
+
+	other := errors.New("unknown flag: --jsn")
+	assert.Equal(t, other, exportFlagError(searchCmd, other))
+}
+
+func TestRunSearchQuery_JSONFields(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	noColor = true
+	defer func() { noColor = false }()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	mockClient := github.NewMockClient()
+	popular := github.CreateTestSearchItem("owner/popular", "vite.config.ts", "export default {}")
+	popular.Repository.StargazersCount = github.IntPtr(5000)
+	small := github.CreateTestSearchItem("owner/small", "vite.config.js", "export default {}")
+	small.Repository.StargazersCount = github.IntPtr(3)
+	mockClient.SetSearchResults("vite.config", github.CreateTestSearchResults(2, popular, small))
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = mockClient
+
+	jsonFields = []string{"repository.fullName,stars"}
+	out := captureOutput(func() error { return runSearchQuery(context.Background(), "vite.config") })
+	require.NoError(t, out.err)
+	assert.JSONEq(t, `[
+		{"repository": {"fullName": "owner/popular"}, "stars": 5000},
+		{"repository": {"fullName": "owner/small"}, "stars": 3}
+	]`, out.stdout)
+
+	jqExpr = ".[] | select(.stars > 100) | .repository.fullName"
+	out = captureOutput(func() error { return runSearchQuery(context.Background(), "vite.config") })
+	require.NoError(t, out.err)
+	assert.Equal(t, "owner/popular\n", out.stdout)
+
+	jqExpr, templateText = "", `{{range .}}{{.repository.fullName}}: {{.stars}}{{"\n"}}{{end}}`
+	out = captureOutput(func() error { return runSearchQuery(context.Background(), "vite.config") })
+	require.NoError(t, out.err)
+	assert.Equal(t, "owner/popular: 5000\nowner/small: 3\n", out.stdout)
+}
+
+func TestRunSearchQuery_JSONFieldsNoResults(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	noColor = true
+	defer func() { noColor = false }()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = github.NewMockClient()
+
+	jsonFields = []string{"path"}
+	out := captureOutput(func() error { return runSearchQuery(context.Background(), "nothing") })
+	require.NoError(t, out.err)
+	assert.Equal(t, "[]\n", out.stdout)
+}
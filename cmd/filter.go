@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/silouanwright/gh-scout/internal/dedupe"
+	"github.com/silouanwright/gh-scout/internal/filter"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// maxBackfillPages caps the extra pages fetched to replace filtered and collapsed results
+const maxBackfillPages = 5
+
+// resultFilter builds the client-side result filter from the analysis settings
+func resultFilter() *filter.Filter {
+	settings := currentConfig().Analysis
+	return filter.New(filter.Rules{
+		ExcludeTests:     settings.ExcludeTests,
+		TestPaths:        settings.TestPaths,
+		ExcludePaths:     settings.ExcludePaths,
+		ExcludeLanguages: settings.ExcludeLanguages,
+		DetectLanguage:   detectLanguage,
+	})
+}
+
+// filterResults drops tests, vendored paths and excluded languages from
+// results and collapses copies with --dedupe, then fetches following pages
+// until --limit results remain or the search runs out of matches
+func filterResults(ctx context.Context, query string, results *github.SearchResults) {
+	f := resultFilter()
+	if (!f.Active() && dedupeMode == "") || len(results.Items) == 0 {
+		return
+	}
+
+	// Results the search asked for and where they started, matching executeSearch
+	perPage := min(searchLimit, GitHubMaxResultsPerPage)
+	wanted, offset := searchLimit, 0
+	if searchPage > 0 {
+		wanted, offset = perPage, (searchPage-1)*perPage
+	}
+	exhausted := len(results.Items) < wanted
+	offset += len(results.Items)
+
+	kept, dropped := f.Apply(results.Items)
+	kept, collapsed := dedupe.Apply(kept, dedupeMode)
+	for pages := 0; len(kept) < wanted && !exhausted && pages < maxBackfillPages && offset < GitHubMaxSearchResults; pages++ {
+		// Pace backfill pages like any other paginated search
+		page := offset/perPage + 1
+		err := delayNextPage(ctx, page)
+		var more *github.SearchResults
+		if err == nil {
+			more, err = fetchSearchPage(ctx, query, page, perPage)
+		}
+		if err != nil {
+			if verbose {
+				fmt.Printf("⚠️  Stopped backfilling filtered results: %v\n", err)
+			}
+			break
+		}
+
+		items := more.Items
+		exhausted = len(items) < perPage
+		items = items[min(offset%perPage, len(items)):]
+		offset += len(items)
+
+		pageKept, pageDropped := f.Apply(items)
+		dropped = append(dropped, pageDropped...)
+
+		var pageCollapsed int
+		kept, pageCollapsed = dedupe.Apply(append(kept, pageKept...), dedupeMode)
+		collapsed += pageCollapsed
+	}
+
+	if len(kept) > wanted {
+		kept = kept[:wanted]
+	}
+	results.Items = kept
+
+	if verbose && len(dropped) > 0 {
+		fmt.Printf("🧹 Filtered out %d results:\n", len(dropped))
+		for _, d := range dropped {
+			fmt.Printf("  - %s/%s (%s)\n", derefString(d.Item.Repository.FullName), derefString(d.Item.Path), d.Reason)
+		}
+	}
+	if verbose && collapsed > 0 {
+		fmt.Printf("🧬 Collapsed %d duplicate results (--dedupe=%s)\n", collapsed, dedupeMode)
+	}
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/analysis"
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// resultPaths lists the file paths of search results in order
+func resultPaths(results *github.SearchResults) []string {
+	paths := make([]string, len(results.Items))
+	for i, item := range results.Items {
+		paths[i] = derefString(item.Path)
+	}
+	return paths
+}
+
+// setupFilterClient serves three pages of two results, with tests and vendored code mixed in
+func setupFilterClient() *github.MockClient {
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("tsconfig", map[int]*github.SearchResults{
+		1: github.CreateTestSearchResults(6,
+			github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", "{}"),
+			github.CreateTestSearchItem("vercel/next.js", "test/fixtures/tsconfig.json", "{}"),
+		),
+		2: github.CreateTestSearchResults(6,
+			github.CreateTestSearchItem("facebook/react", "node_modules/pkg/tsconfig.json", "{}"),
+			github.CreateTestSearchItem("facebook/react", "tsconfig.json", "{}"),
+		),
+		3: github.CreateTestSearchResults(6,
+			github.CreateTestSearchItem("vitejs/vite", "tsconfig.json", "{}"),
+			github.CreateTestSearchItem("vitejs/vite", "packages/vite/tsconfig.json", "{}"),
+		),
+	})
+	return mockClient
+}
+
+func TestFilterResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		page      int
+		configure func(*config.Config)
+		wantPaths []string
+		wantCalls int
+	}{
+		{
+			name:      "backfills from the next page to honor the limit",
+			page:      1,
+			wantPaths: []string{"tsconfig.json", "tsconfig.json"},
+			wantCalls: 2,
+		},
+		{
+			name:      "auto pagination backfills the same way",
+			wantPaths: []string{"tsconfig.json", "tsconfig.json"},
+			wantCalls: 2,
+		},
+		{
+			name: "exclude_tests disabled keeps fixtures",
+			page: 1,
+			configure: func(c *config.Config) {
+				c.Analysis.ExcludeTests = false
+			},
+			wantPaths: []string{"tsconfig.json", "test/fixtures/tsconfig.json"},
+			wantCalls: 1,
+		},
+		{
+			name: "all filters disabled",
+			page: 1,
+			configure: func(c *config.Config) {
+				c.Analysis.ExcludeTests = false
+				c.Analysis.ExcludePaths = []string{}
+			},
+			wantPaths: []string{"tsconfig.json", "test/fixtures/tsconfig.json"},
+			wantCalls: 1,
+		},
+		{
+			name: "excluded languages stop when results run out",
+			page: 2,
+			configure: func(c *config.Config) {
+				c.Analysis.ExcludeLanguages = []string{"json"}
+			},
+			wantPaths: []string{},
+			wantCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			searchLimit = 2
+			searchPage = tt.page
+
+			originalConfig := appConfig
+			defer func() { appConfig = originalConfig }()
+			appConfig = config.Default()
+			if tt.configure != nil {
+				tt.configure(appConfig)
+			}
+
+			mockClient := setupFilterClient()
+			originalClient := searchClient
+			defer func() { searchClient = originalClient }()
+			searchClient = mockClient
+
+			ctx := context.Background()
+			results, err := executeSearch(ctx, "tsconfig")
+			require.NoError(t, err)
+			filterResults(ctx, "tsconfig", results)
+
+			assert.Equal(t, tt.wantPaths, resultPaths(results))
+			assert.Equal(t, tt.wantCalls, mockClient.GetCallCount("SearchCode"))
+		})
+	}
+}
+
+func TestFilterResults_BackfillOffsets(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	searchLimit = 2
+	searchPage = 2
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	mockClient := setupFilterClient()
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = mockClient
+
+	ctx := context.Background()
+	results, err := executeSearch(ctx, "tsconfig")
+	require.NoError(t, err)
+	filterResults(ctx, "tsconfig", results)
+
+	// Page 2 loses its vendored result and takes the first result of page 3
+	assert.Equal(t, []string{"tsconfig.json", "tsconfig.json"}, resultPaths(results))
+	assert.Equal(t, "vitejs/vite", derefString(results.Items[1].Repository.FullName))
+
+	calls := mockClient.GetCallLog()
+	require.Len(t, calls, 2)
+	opts, ok := calls[1].Args[1].(*github.SearchOptions)
+	require.True(t, ok)
+	assert.Equal(t, 3, opts.ListOptions.Page)
+	assert.Equal(t, 2, opts.ListOptions.PerPage)
+}
+
+func TestRunSearchQueryListsFilteredResults(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	searchLimit = 2
+	searchPage = 1
+	verbose = true
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = setupFilterClient()
+
+	out := captureOutput(func() error {
+		return runSearchQuery(context.Background(), "tsconfig")
+	})
+	require.NoError(t, out.err)
+	assert.Contains(t, out.stdout, "🧹 Filtered out 2 results:")
+	assert.Contains(t, out.stdout, "  - vercel/next.js/test/fixtures/tsconfig.json (test or fixture path)")
+	assert.Contains(t, out.stdout, "  - facebook/react/node_modules/pkg/tsconfig.json (excluded path)")
+	assert.Contains(t, out.stdout, "Found 2 results")
+}
+
+func TestFilterResults_Dedupe(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	searchLimit = 2
+	searchPage = 1
+	dedupeMode = "sha"
+	verbose = true
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	// Two vendored lodash copies on page 1 and the original on page 2
+	vendored := github.CreateTestSearchItem("someone/app", "lib/lodash.js", "module.exports = _")
+	vendored.SHA = github.StringPtr("abc")
+	vendored.Repository.StargazersCount = github.IntPtr(3)
+	original := github.CreateTestSearchItem("lodash/lodash", "lodash.js", "module.exports = _")
+	original.SHA = github.StringPtr("abc")
+	original.Repository.StargazersCount = github.IntPtr(58000)
+	fork := github.CreateTestSearchItem("fork/app", "lib/lodash.js", "module.exports = _")
+	fork.SHA = github.StringPtr("abc")
+	other := github.CreateTestSearchItem("acme/app", "src/index.js", "import _ from 'lodash'")
+	other.SHA = github.StringPtr("def")
+
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("lodash", map[int]*github.SearchResults{
+		1: github.CreateTestSearchResults(5, vendored, fork),
+		2: github.CreateTestSearchResults(5, original, other),
+	})
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = mockClient
+
+	ctx := context.Background()
+	var results *github.SearchResults
+	out := captureOutput(func() error {
+		var err error
+		results, err = executeSearch(ctx, "lodash")
+		if err == nil {
+			filterResults(ctx, "lodash", results)
+		}
+		return err
+	})
+	require.NoError(t, out.err)
+
+	require.Len(t, results.Items, 2)
+	canonical := results.Items[0]
+	assert.Equal(t, "lodash/lodash", derefString(canonical.Repository.FullName))
+	assert.Equal(t, []string{"fork/app", "someone/app"}, canonical.DuplicateRepositories())
+	assert.Equal(t, "acme/app", derefString(results.Items[1].Repository.FullName))
+	assert.Contains(t, out.stdout, "🧬 Collapsed 2 duplicate results (--dedupe=sha)")
+	assert.Contains(t, out.stdout, "Adding delay between pages", "backfill pages are paced")
+}
+
+func TestPatternSettingsUseResultFilter(t *testing.T) {
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+	appConfig.Analysis.MinPatternCount = 1
+	appConfig.Analysis.TestPaths = []string{"**/e2e/**"}
+	appConfig.Analysis.ExcludeLanguages = []string{"markdown"}
+
+	results := github.CreateTestSearchResults(4,
+		github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", `"strict": true`),
+		github.CreateTestSearchItem("vercel/next.js", "e2e/tsconfig.json", `"strict": false`),
+		github.CreateTestSearchItem("vercel/next.js", "docs/README.md", `strict: true`),
+		github.CreateTestSearchItem("vercel/next.js", "node_modules/pkg/tsconfig.json", `"strict": false`),
+	)
+
+	settings, _ := patternSettings()
+	report := analysis.Analyze(results, settings)
+	assert.Equal(t, 1, report.Results)
+	assert.Equal(t, 3, report.Excluded, "custom test paths, languages and vendored paths are skipped")
+}
+
+func TestValidateSearchFlags_Dedupe(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	dedupeMode = "path"
+	assert.EqualError(t, validateSearchFlags(), "unsupported dedupe mode: path (supported: sha, content, repo)")
+
+	dedupeMode = "content"
+	assert.NoError(t, validateSearchFlags())
+
+	batchRepos = []string{"facebook/react"}
+	assert.ErrorContains(t, validateSearchFlags(), "--dedupe cannot be combined with --repos or --orgs")
+}
@@ -67,7 +67,7 @@ func handleSearchError(err error, query string) error {
 
 💡 **Fix Authentication**:
   • Check current status: gh auth status
-  • Login to GitHub: gh auth login
+  • Login to GitHub: %s
   • Refresh if needed: gh auth refresh --scopes repo
 
 📈 **Benefits of Authentication**:
@@ -76,7 +76,7 @@ func handleSearchError(err error, query string) error {
   • Detailed repository metadata
 
 🚀 **After Authentication**:
-  gh scout "your query here"`, err)
+  gh scout "your query here"`, err, authLoginCommand())
 	}
 
 	// Authorization/Permission errors
@@ -112,7 +112,7 @@ func handleSearchError(err error, query string) error {
   • Check if repository still exists on GitHub
   • Search with broader terms: remove --repo filter
   • Use wildcards in repository names: --repo "**/react"
-  • Search similar repositories: --repo facebook/* --repo vercel/*
+  • Search similar repositories: --orgs facebook,vercel
 
 🔍 **Broader Search**:
   gh scout "your terms" --language typescript --min-stars 50`, err)
@@ -242,7 +242,7 @@ func handleClientError(err error) error {
      • Linux: See installation docs
 
   2. Authenticate with GitHub:
-     gh auth login --web
+     %s --web
 
   3. Verify authentication:
      gh auth status
@@ -256,7 +256,16 @@ func handleClientError(err error) error {
   • Better error handling and diagnostics
 
 🚀 **After Setup**:
-  gh scout "your search terms here"`, err)
+  gh scout "your search terms here"`, err, authLoginCommand())
+}
+
+// authLoginCommand returns the gh command that authenticates with the host
+// being searched
+func authLoginCommand() string {
+	if host := github.NormalizeHost(hostname); host != "" && host != github.DefaultHost {
+		return "gh auth login --hostname " + host
+	}
+	return "gh auth login"
 }
 
 // Helper functions for formatting
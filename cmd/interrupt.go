@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// stopReason describes why ctx ended a command early
+func stopReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "timed out"
+	}
+	return "interrupted"
+}
+
+// warnPartialResults tells the user that a search stopped early and that
+// the results gathered so far follow
+func warnPartialResults(ctx context.Context, count int) {
+	fmt.Fprintf(os.Stderr, "⚠️  Search %s, showing the %d results gathered so far\n", stopReason(ctx), count)
+}
+
+// stoppedEarly returns the error a command reports after printing partial
+// results, or nil when ctx is still live
+func stoppedEarly(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return fmt.Errorf("search %s before it completed: %w", stopReason(ctx), ctx.Err())
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// interruptingClient cancels the search after serving a number of calls,
+// the way Ctrl-C cancels the command context
+type interruptingClient struct {
+	*github.MockClient
+	cancel context.CancelFunc
+	after  int
+	calls  int
+}
+
+func (c *interruptingClient) SearchCode(ctx context.Context, query string, opts *github.SearchOptions) (*github.SearchResults, error) {
+	c.calls++
+	if c.calls > c.after {
+		c.cancel()
+		return nil, fmt.Errorf("operation cancelled: %w", ctx.Err())
+	}
+	results, err := c.MockClient.SearchCode(ctx, query, opts)
+	if c.calls == c.after {
+		c.cancel()
+	}
+	return results, err
+}
+
+// fullPage builds a page of perPage results from distinct repositories
+func fullPage(page, perPage int) *github.SearchResults {
+	items := make([]github.SearchItem, perPage)
+	for i := range items {
+		items[i] = github.CreateTestSearchItem(fmt.Sprintf("owner/repo-%d-%d", page, i), "config.json", "{}")
+	}
+	return github.CreateTestSearchResults(1000, items...)
+}
+
+func TestRunSearchQuery_InterruptPrintsPartialResults(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	searchLimit = 200
+	outputFormat = "compact"
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("config", map[int]*github.SearchResults{1: fullPage(1, 100), 2: fullPage(2, 100)})
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = &interruptingClient{MockClient: mockClient, cancel: cancel, after: 1}
+
+	out := captureOutput(func() error {
+		return runSearchQuery(ctx, "config")
+	})
+
+	require.Error(t, out.err)
+	assert.Contains(t, out.err.Error(), "search interrupted before it completed")
+	assert.Contains(t, out.stderr, "Search interrupted, showing the 100 results gathered so far")
+	assert.Contains(t, out.stdout, "owner/repo-1-0:config.json")
+	assert.NotContains(t, out.stdout, "owner/repo-2-0")
+}
+
+func TestRunSearchQuery_InterruptBeforeResults(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	outputFormat = "compact"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = &interruptingClient{MockClient: github.NewMockClient(), cancel: cancel}
+
+	out := captureOutput(func() error {
+		return runSearchQuery(ctx, "config")
+	})
+
+	require.Error(t, out.err)
+	assert.EqualError(t, out.err, "search interrupted before any results arrived: context canceled")
+	assert.Empty(t, out.stdout)
+}
+
+func TestExecuteBatchRepoSearch_InterruptPrintsPartialResults(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	batchRepos = []string{"owner/first", "owner/second", "owner/third"}
+	outputFormat = "compact"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("config repo:owner/first", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("owner/first", "config.json", "{}")))
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	client := &interruptingClient{MockClient: mockClient, cancel: cancel, after: 1}
+	searchClient = client
+
+	out := captureOutput(func() error {
+		return executeBatchRepoSearch(ctx, []string{"config"})
+	})
+
+	require.Error(t, out.err)
+	assert.Contains(t, out.err.Error(), "search interrupted")
+	assert.Contains(t, out.stderr, "showing the 1 results gathered so far")
+	assert.Contains(t, out.stdout, "owner/first:config.json")
+	assert.Equal(t, 1, client.calls, "no targets are searched after the interrupt")
+}
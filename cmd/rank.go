@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/rank"
+)
+
+// rankWeights returns the --sort quality weights from the ranking settings
+func rankWeights() rank.Weights {
+	settings := currentConfig().Ranking
+	return rank.Weights{
+		Stars:           settings.StarsWeight,
+		Forks:           settings.ForksWeight,
+		Recency:         settings.RecencyWeight,
+		Relevance:       settings.RelevanceWeight,
+		ArchivedPenalty: settings.ArchivedPenalty,
+		ForkPenalty:     settings.ForkPenalty,
+	}
+}
+
+// rankResults reorders fetched results for sorts GitHub cannot apply itself.
+// Only the fetched page is reordered, not the full result set.
+func rankResults(results *github.SearchResults) {
+	if !rank.ClientSide(sort) {
+		return
+	}
+	if liteMode {
+		fmt.Fprintf(os.Stderr, "⚠️  --sort %s needs repository details, which --lite skips; results keep GitHub's order\n", sort)
+		return
+	}
+
+	rank.Apply(results.Items, sort, order, rankWeights(), time.Now())
+	if verbose {
+		fmt.Printf("📊 Ranked %d results by %s\n", len(results.Items), sort)
+	}
+}
+
+// rankBatchTargets reorders each --repos/--orgs target's results for --compare
+func rankBatchTargets(targets []batchTarget) {
+	if !rank.ClientSide(sort) || liteMode {
+		return
+	}
+
+	weights, now := rankWeights(), time.Now()
+	for _, target := range targets {
+		if target.Results != nil {
+			rank.Apply(target.Results.Items, sort, order, weights, now)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// setupRankClient serves three results in GitHub's relevance order
+func setupRankClient(query string) *github.MockClient {
+	item := func(name string, stars, forks int, pushed time.Time) github.SearchItem {
+		result := github.CreateTestSearchItem(name, "vite.config.ts", "export default {}")
+		result.Repository = github.CreateTestRepository(name, stars)
+		result.Repository.ForksCount = github.IntPtr(forks)
+		result.Repository.PushedAt = &pushed
+		return result
+	}
+
+	now := time.Now()
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults(query, github.CreateTestSearchResults(3,
+		item("small/app", 12, 400, now.AddDate(0, 0, -1)),
+		item("vitejs/vite", 70000, 6000, now.AddDate(0, 0, -2)),
+		item("old/app", 900, 50, now.AddDate(-3, 0, 0)),
+	))
+	return mockClient
+}
+
+func TestRunSearchQuerySort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		order   string
+		want    string
+		wantAPI string
+	}{
+		{"relevance", "desc", "small/app:vite.config.ts\nvitejs/vite:vite.config.ts\nold/app:vite.config.ts\n", ""},
+		{"indexed", "desc", "small/app:vite.config.ts\nvitejs/vite:vite.config.ts\nold/app:vite.config.ts\n", "indexed"},
+		{"stars", "desc", "vitejs/vite:vite.config.ts\nold/app:vite.config.ts\nsmall/app:vite.config.ts\n", ""},
+		{"stars", "asc", "small/app:vite.config.ts\nold/app:vite.config.ts\nvitejs/vite:vite.config.ts\n", ""},
+		{"forks", "desc", "vitejs/vite:vite.config.ts\nsmall/app:vite.config.ts\nold/app:vite.config.ts\n", ""},
+		{"recent", "desc", "small/app:vite.config.ts\nvitejs/vite:vite.config.ts\nold/app:vite.config.ts\n", ""},
+		{"quality", "desc", "vitejs/vite:vite.config.ts\nsmall/app:vite.config.ts\nold/app:vite.config.ts\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort+" "+tt.order, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			sort = tt.sort
+			order = tt.order
+			outputFormat = "compact"
+
+			originalConfig := appConfig
+			defer func() { appConfig = originalConfig }()
+			appConfig = config.Default()
+
+			mockClient := setupRankClient("vite.config")
+			originalClient := searchClient
+			defer func() { searchClient = originalClient }()
+			searchClient = mockClient
+
+			out := captureOutput(func() error {
+				return runSearchQuery(context.Background(), "vite.config")
+			})
+			require.NoError(t, out.err)
+			assert.Equal(t, tt.want, out.stdout)
+
+			calls := mockClient.GetCallLog()
+			require.NotEmpty(t, calls)
+			opts := calls[0].Args[1].(*github.SearchOptions)
+			assert.Equal(t, tt.wantAPI, opts.Sort, "only indexed is sent to GitHub")
+		})
+	}
+}
+
+func TestRunSearchQuerySortWeights(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	sort = "quality"
+	outputFormat = "compact"
+
+	// Weighing only recency puts the most recently pushed repository first
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+	appConfig.Ranking = config.RankingSettings{RecencyWeight: 1}
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = setupRankClient("vite.config")
+
+	out := captureOutput(func() error {
+		return runSearchQuery(context.Background(), "vite.config")
+	})
+	require.NoError(t, out.err)
+	assert.Equal(t, "small/app:vite.config.ts\nvitejs/vite:vite.config.ts\nold/app:vite.config.ts\n", out.stdout)
+}
+
+func TestRunSearchQuerySortLite(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	sort = "stars"
+	liteMode = true
+	outputFormat = "compact"
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	originalClient := searchClient
+	defer func() { searchClient = originalClient }()
+	searchClient = setupRankClient("vite.config")
+
+	out := captureOutput(func() error {
+		return runSearchQuery(context.Background(), "vite.config")
+	})
+	require.NoError(t, out.err)
+	assert.Equal(t, "small/app:vite.config.ts\nvitejs/vite:vite.config.ts\nold/app:vite.config.ts\n", out.stdout)
+	assert.Contains(t, out.stderr, "--sort stars needs repository details, which --lite skips")
+}
+
+func TestExecuteBatchRepoSearchSort(t *testing.T) {
+	for _, compare := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compare=%t", compare), func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			sort = "stars"
+			outputFormat = "compact"
+			batchRepos = []string{"acme/configs"}
+			compareMode = compare
+
+			originalConfig := appConfig
+			defer func() { appConfig = originalConfig }()
+			appConfig = config.Default()
+
+			originalClient := searchClient
+			defer func() { searchClient = originalClient }()
+			searchClient = setupRankClient("vite.config repo:acme/configs")
+
+			out := captureOutput(func() error {
+				return executeBatchRepoSearch(context.Background(), []string{"vite.config"})
+			})
+			require.NoError(t, out.err)
+
+			vite := strings.Index(out.stdout, "vitejs/vite")
+			old := strings.Index(out.stdout, "old/app")
+			small := strings.Index(out.stdout, "small/app")
+			require.True(t, vite >= 0 && old >= 0 && small >= 0, out.stdout)
+			assert.Less(t, vite, old)
+			assert.Less(t, old, small)
+		})
+	}
+}
+
+func TestApplyConfigDefaultsSort(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		want string
+	}{
+		{name: "config sort applies without --sort", want: "stars"},
+		{name: "explicit --sort relevance wins", flag: "relevance", want: "relevance"},
+		{name: "explicit --sort recent wins", flag: "recent", want: "recent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			sortFlag := searchCmd.Flags().Lookup("sort")
+			defer func() { sortFlag.Changed = false }()
+			if tt.flag != "" {
+				require.NoError(t, searchCmd.Flags().Set("sort", tt.flag))
+			}
+
+			cfg := config.Default()
+			cfg.Defaults.SortBy = "stars"
+			applyConfigDefaults(cfg)
+			assert.Equal(t, tt.want, sort)
+		})
+	}
+}
+
+func TestValidateSearchFlags_Sort(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	sort = "popularity"
+	assert.EqualError(t, validateSearchFlags(), "invalid sort: popularity (supported: relevance, indexed, stars, forks, recent, created, quality)")
+
+	sort = "updated"
+	assert.NoError(t, validateSearchFlags())
+}
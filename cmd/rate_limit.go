@@ -2,109 +2,304 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/silouanwright/gh-scout/internal/github"
 	"github.com/spf13/cobra"
 )
 
+var (
+	rateLimitJSON     bool
+	rateLimitWatch    bool
+	rateLimitInterval time.Duration
+)
+
+// rateLimitResources lists the resources gh-scout spends, in display order
+var rateLimitResources = []struct {
+	name  string
+	label string
+	use   string
+}{
+	{github.ResourceCodeSearch, "🔍 **Code Search API**", "code searches"},
+	{github.ResourceSearch, "🔎 **Search API**", "other searches"},
+	{github.ResourceCore, "📦 **Core API**", "repository details and file contents"},
+	{github.ResourceGraphQL, "🕸️  **GraphQL API**", "bulk repository details"},
+}
+
 // rateLimitCmd represents the rate-limit command
 var rateLimitCmd = &cobra.Command{
 	Use:   "rate-limit",
 	Short: "Check current GitHub API rate limit status",
 	Long: `Display current GitHub API rate limit status and usage information.
 
-Shows remaining requests, limit, and reset time for the GitHub Search API.
+Shows remaining requests, limit, and reset time for each API gh-scout uses:
+code search, search, core (repository details and file contents) and GraphQL,
+along with an estimate of how much work fits in the remaining budget.
 Useful for understanding when you can make more requests after hitting limits.`,
 	Example: `  # Check current rate limit status
   gh scout rate-limit
 
   # Check rate limits after hitting a limit
-  gh scout rate-limit --verbose`,
+  gh scout rate-limit --verbose
+
+  # Refresh every 10 seconds until the limits reset
+  gh scout rate-limit --watch
+
+  # Machine-readable output
+  gh scout rate-limit --json
+
+  # Check a GitHub Enterprise Server instance
+  gh scout rate-limit --hostname ghe.example.com`,
 	RunE: runRateLimit,
 }
 
+// rateLimitBudget estimates how much work fits in the remaining quotas,
+// keeping github.rate_limit_buffer requests of each in reserve
+type rateLimitBudget struct {
+	Searches    int `json:"searches"`     // search pages of up to 100 results
+	Enrichments int `json:"enrichments"`  // repositories whose details can be fetched
+	FileFetches int `json:"file_fetches"` // files whose contents can be fetched
+	Reserve     int `json:"reserve"`
+}
+
+// rateLimitReport is the --json form of the rate limit status
+type rateLimitReport struct {
+	Host      string                              `json:"host"`
+	Disabled  bool                                `json:"disabled,omitempty"`
+	Resources map[string]github.ResourceRateLimit `json:"resources,omitempty"`
+	Budget    *rateLimitBudget                    `json:"budget,omitempty"`
+}
+
 func runRateLimit(cmd *cobra.Command, args []string) error {
+	if rateLimitWatch && rateLimitInterval <= 0 {
+		return fmt.Errorf("invalid --interval: %s (must be positive)", rateLimitInterval)
+	}
+
 	// Initialize client if not set
 	var client github.GitHubAPI
 	if searchClient != nil {
 		client = searchClient
 	} else {
-		realClient, err := github.NewRealClient()
+		realClient, err := github.NewRealClientForHost(hostname)
 		if err != nil {
 			return handleClientError(err)
 		}
 		client = realClient
 	}
 
-	// Get rate limit info
-	rateLimit, err := client.GetRateLimit(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get rate limit information: %w", err)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	// Display rate limit status
-	fmt.Println("📊 GitHub API Rate Limit Status")
-	fmt.Println()
+	for {
+		rateLimit, err := client.GetRateLimit(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get rate limit information: %w", err)
+		}
+
+		if rateLimitJSON {
+			err = printRateLimitJSON(rateLimit)
+		} else {
+			printRateLimit(rateLimit)
+		}
+		if err != nil {
+			return err
+		}
+
+		wait := rateLimitWatchDelay(rateLimit, time.Now())
+		if !rateLimitWatch || wait <= 0 {
+			if rateLimitWatch && !rateLimitJSON {
+				fmt.Println("\n✅ All rate limits have reset")
+			}
+			return nil
+		}
+		if !rateLimitJSON {
+			fmt.Printf("\n🔄 Refreshing in %s (Ctrl-C to stop)\n\n", formatDuration(wait))
+		}
+
+		// Interrupting a watch just stops it
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// rateLimitWatchDelay returns how long --watch waits before refreshing:
+// the interval, or less if a used quota resets sooner. It returns 0 once
+// every used quota has reset.
+func rateLimitWatchDelay(rateLimit *github.RateLimit, now time.Time) time.Duration {
+	if rateLimit.Disabled {
+		return 0
+	}
+
+	var wait time.Duration
+	for _, resource := range rateLimitResources {
+		limit, ok := rateLimit.Resource(resource.name)
+		if !ok || limit.Remaining >= limit.Limit {
+			continue
+		}
+		untilReset := limit.Reset.Sub(now)
+		if untilReset <= 0 {
+			// Already reset; the next refresh picks up the new window
+			untilReset = time.Second
+		}
+		if wait == 0 || untilReset < wait {
+			wait = untilReset
+		}
+	}
+	if wait > rateLimitInterval {
+		return rateLimitInterval
+	}
+	return wait
+}
 
-	// Basic status
-	fmt.Printf("🔍 **Search API**:\n")
-	fmt.Printf("  • Limit: %d requests per hour\n", rateLimit.Limit)
-	fmt.Printf("  • Remaining: %d requests\n", rateLimit.Remaining)
-	fmt.Printf("  • Reset: %s\n", rateLimit.Reset.Format("15:04:05 MST"))
+// estimateRateLimitBudget estimates the searches, repository enrichments
+// and file fetches left before the rate limiter starts waiting for resets
+func estimateRateLimitBudget(rateLimit *github.RateLimit, reserve int) rateLimitBudget {
+	remaining := func(resources ...string) (int, bool) {
+		for _, resource := range resources {
+			if limit, ok := rateLimit.Resource(resource); ok {
+				return max(limit.Remaining-reserve, 0), true
+			}
+		}
+		return 0, false
+	}
 
-	// Calculate time until reset
-	timeUntilReset := time.Until(rateLimit.Reset)
-	if timeUntilReset > 0 {
-		fmt.Printf("  • Time until reset: %s\n", formatDuration(timeUntilReset))
+	budget := rateLimitBudget{Reserve: reserve}
+	budget.Searches, _ = remaining(github.ResourceCodeSearch, github.ResourceSearch)
+	budget.FileFetches, _ = remaining(github.ResourceCore)
+
+	// Repository details come from GraphQL, a hundred per query, with REST
+	// requests on the core quota as the fallback
+	if queries, ok := remaining(github.ResourceGraphQL); ok {
+		budget.Enrichments = queries * github.MaxGraphQLRepositories
 	} else {
-		fmt.Printf("  • Status: ✅ Reset time has passed\n")
+		budget.Enrichments = budget.FileFetches
+	}
+	return budget
+}
+
+// printRateLimitJSON writes the rate limit status as JSON. --watch writes
+// one compact object per refresh.
+func printRateLimitJSON(rateLimit *github.RateLimit) error {
+	report := rateLimitReport{
+		Host:     github.ResolveHost(hostname),
+		Disabled: rateLimit.Disabled,
+	}
+	if !rateLimit.Disabled {
+		report.Resources = make(map[string]github.ResourceRateLimit)
+		for _, resource := range rateLimitResources {
+			if limit, ok := rateLimit.Resource(resource.name); ok {
+				report.Resources[resource.name] = limit
+			}
+		}
+		budget := estimateRateLimitBudget(rateLimit, currentConfig().GitHub.RateLimitBuffer)
+		report.Budget = &budget
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	if !rateLimitWatch {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode rate limit status: %w", err)
+	}
+	return nil
+}
+
+// printRateLimit displays the rate limit status of every resource
+func printRateLimit(rateLimit *github.RateLimit) {
+	fmt.Println("📊 GitHub API Rate Limit Status")
+	if host := github.NormalizeHost(hostname); host != "" {
+		fmt.Printf("🏢 Host: %s\n", host)
 	}
+	fmt.Println()
 
-	// Usage percentage
-	usedPercent := float64(rateLimit.Limit-rateLimit.Remaining) / float64(rateLimit.Limit) * 100
-	fmt.Printf("  • Usage: %.1f%% (%d/%d)\n", usedPercent, rateLimit.Limit-rateLimit.Remaining, rateLimit.Limit)
+	// GitHub Enterprise Server administrators can turn rate limiting off
+	if rateLimit.Disabled {
+		fmt.Println("✅ **Rate Limiting Disabled**")
+		fmt.Println("This GitHub Enterprise Server does not limit API requests.")
+		return
+	}
 
+	for _, resource := range rateLimitResources {
+		limit, ok := rateLimit.Resource(resource.name)
+		if !ok {
+			continue
+		}
+
+		used := limit.Limit - limit.Remaining
+		fmt.Printf("%s (%s, used for %s):\n", resource.label, resource.name, resource.use)
+		fmt.Printf("  • Remaining: %d of %d requests\n", limit.Remaining, limit.Limit)
+		if limit.Limit > 0 {
+			fmt.Printf("  • Usage: %.1f%% (%d/%d)\n", float64(used)/float64(limit.Limit)*100, used, limit.Limit)
+		}
+		if timeUntilReset := time.Until(limit.Reset); timeUntilReset > 0 {
+			fmt.Printf("  • Reset: %s (in %s)\n", limit.Reset.Format("15:04:05 MST"), formatDuration(timeUntilReset))
+		} else {
+			fmt.Printf("  • Status: ✅ Reset time has passed\n")
+		}
+		fmt.Println()
+	}
+
+	budget := estimateRateLimitBudget(rateLimit, currentConfig().GitHub.RateLimitBuffer)
+	fmt.Printf("🧮 **Remaining Budget** (keeping %d requests of each in reserve):\n", budget.Reserve)
+	fmt.Printf("  • ~%d searches of up to 100 results each\n", budget.Searches)
+	fmt.Printf("  • ~%d repositories enriched with stars and details\n", budget.Enrichments)
+	fmt.Printf("  • ~%d file contents fetched (--context)\n", budget.FileFetches)
 	fmt.Println()
 
-	// Status indicators and advice
-	if rateLimit.Remaining == 0 {
-		fmt.Println("🚨 **Rate Limit Exhausted**")
-		fmt.Printf("You've used all %d requests. Wait %s for reset.\n",
-			rateLimit.Limit, formatDuration(timeUntilReset))
+	// Status indicators and advice follow the quota searches spend
+	search, ok := rateLimit.Resource(github.ResourceCodeSearch)
+	if !ok {
+		search, ok = rateLimit.Resource(github.ResourceSearch)
+	}
+	if !ok {
+		return
+	}
+	timeUntilReset := time.Until(search.Reset)
+
+	if search.Remaining == 0 {
+		fmt.Println("🚨 **Search Rate Limit Exhausted**")
+		fmt.Printf("You've used all %d search requests. Wait %s for reset.\n",
+			search.Limit, formatDuration(timeUntilReset))
 		fmt.Println()
 		fmt.Println("💡 **While You Wait**:")
 		fmt.Println("  • Use more specific filters: --language, --repo, --filename")
 		fmt.Println("  • Try saved searches: gh scout saved list")
 		fmt.Println("  • Plan your searches to be more targeted")
-	} else if rateLimit.Remaining < 5 {
+	} else if search.Remaining < 5 {
 		fmt.Println("⚠️  **Low on Requests**")
-		fmt.Printf("Only %d requests remaining. Use them wisely!\n", rateLimit.Remaining)
+		fmt.Printf("Only %d search requests remaining. Use them wisely!\n", search.Remaining)
 		fmt.Println()
 		fmt.Println("💡 **Conservation Tips**:")
 		fmt.Println("  • Use --page instead of high --limit values")
 		fmt.Println("  • Add filters to reduce result sets")
 		fmt.Println("  • Save frequently used searches")
-	} else if rateLimit.Remaining < rateLimit.Limit/2 {
+	} else if search.Remaining < search.Limit/2 {
 		fmt.Println("📈 **Moderate Usage**")
-		fmt.Printf("You have %d requests remaining (%.1f%% used).\n",
-			rateLimit.Remaining, usedPercent)
+		fmt.Printf("You have %d search requests remaining (%.1f%% used).\n",
+			search.Remaining, float64(search.Limit-search.Remaining)/float64(search.Limit)*100)
 	} else {
 		fmt.Println("✅ **Plenty of Requests Available**")
-		fmt.Printf("You have %d requests remaining. Happy searching!\n", rateLimit.Remaining)
+		fmt.Printf("You have %d search requests remaining. Happy searching!\n", search.Remaining)
 	}
 
 	if verbose {
 		fmt.Println()
 		fmt.Println("🔧 **Technical Details**:")
-		fmt.Printf("  • API Endpoint: Search API\n")
-		fmt.Printf("  • Rate Limit Type: Per-user, per-hour\n")
-		fmt.Printf("  • Reset Time: %s\n", rateLimit.Reset.Format(time.RFC3339))
+		fmt.Printf("  • Rate Limit Type: Per-user, per resource\n")
+		fmt.Printf("  • Search Reset Time: %s\n", search.Reset.Format(time.RFC3339))
 		fmt.Printf("  • Current Time: %s\n", time.Now().Format(time.RFC3339))
 
 		if timeUntilReset > 0 {
-			fmt.Printf("  • Seconds until reset: %.0f\n", timeUntilReset.Seconds())
+			fmt.Printf("  • Seconds until search reset: %.0f\n", timeUntilReset.Seconds())
 		}
 
 		// Authentication status
@@ -114,11 +309,13 @@ func runRateLimit(cmd *cobra.Command, args []string) error {
 		fmt.Println("  • Higher limits available with authentication")
 		fmt.Println("  • Run 'gh auth status' to verify authentication")
 	}
-
-	return nil
 }
 
 func init() {
 	// Add rate-limit command to root
 	rootCmd.AddCommand(rateLimitCmd)
+
+	rateLimitCmd.Flags().BoolVar(&rateLimitJSON, "json", false, "output rate limit status as JSON")
+	rateLimitCmd.Flags().BoolVar(&rateLimitWatch, "watch", false, "refresh until every used rate limit resets")
+	rateLimitCmd.Flags().DurationVar(&rateLimitInterval, "interval", 10*time.Second, "refresh interval for --watch")
 }
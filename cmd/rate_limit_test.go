@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// testRateLimit builds a rate limit reporting every resource gh-scout uses
+func testRateLimit(reset time.Time, codeSearch, core, graphql int) *github.RateLimit {
+	return &github.RateLimit{
+		Limit:     30,
+		Remaining: 30,
+		Reset:     reset,
+		Resources: map[string]github.ResourceRateLimit{
+			"code_search": {Limit: 10, Remaining: codeSearch, Used: 10 - codeSearch, Reset: reset},
+			"search":      {Limit: 30, Remaining: 30, Reset: reset},
+			"core":        {Limit: 5000, Remaining: core, Used: 5000 - core, Reset: reset},
+			"graphql":     {Limit: 5000, Remaining: graphql, Used: 5000 - graphql, Reset: reset},
+		},
+	}
+}
+
+// setupRateLimitTest installs client and the default config, resetting the
+// rate-limit flags afterwards
+func setupRateLimitTest(t *testing.T, client github.GitHubAPI) {
+	t.Helper()
+	originalClient, originalConfig := searchClient, appConfig
+	searchClient, appConfig = client, config.Default()
+	t.Cleanup(func() {
+		searchClient, appConfig = originalClient, originalConfig
+		rateLimitJSON, rateLimitWatch, rateLimitInterval = false, false, 10*time.Second
+	})
+}
+
+func TestRunRateLimit(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.SetRateLimit(testRateLimit(time.Now().Add(time.Minute), 8, 4000, 4990))
+	setupRateLimitTest(t, mockClient)
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+	assert.Contains(t, out.stdout, "**Code Search API** (code_search, used for code searches):\n  • Remaining: 8 of 10 requests")
+	assert.Contains(t, out.stdout, "**Core API** (core, used for repository details and file contents):\n  • Remaining: 4000 of 5000 requests")
+	assert.Contains(t, out.stdout, "**GraphQL API** (graphql, used for bulk repository details)")
+	assert.Contains(t, out.stdout, "keeping 5 requests of each in reserve")
+	assert.Contains(t, out.stdout, "~3 searches")
+	assert.Contains(t, out.stdout, "~498500 repositories enriched")
+	assert.Contains(t, out.stdout, "~3995 file contents fetched")
+	assert.Contains(t, out.stdout, "You have 8 search requests remaining")
+}
+
+func TestRunRateLimit_SearchOnly(t *testing.T) {
+	// Servers reporting only the search quota still show it
+	setupRateLimitTest(t, github.NewMockClient())
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+	assert.Contains(t, out.stdout, "**Search API** (search, used for other searches):\n  • Remaining: 29 of 30 requests")
+	assert.NotContains(t, out.stdout, "Core API")
+	assert.Contains(t, out.stdout, "~24 searches")
+}
+
+func TestRunRateLimit_JSON(t *testing.T) {
+	reset := time.Unix(1700000000, 0).UTC()
+	mockClient := github.NewMockClient()
+	mockClient.SetRateLimit(testRateLimit(reset, 0, 4000, 4990))
+	setupRateLimitTest(t, mockClient)
+	rateLimitJSON = true
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+
+	var report rateLimitReport
+	require.NoError(t, json.Unmarshal([]byte(out.stdout), &report))
+	assert.NotEmpty(t, report.Host)
+	assert.Len(t, report.Resources, 4)
+	assert.Equal(t, github.ResourceRateLimit{Limit: 10, Remaining: 0, Used: 10, Reset: reset}, report.Resources["code_search"])
+	assert.Equal(t, &rateLimitBudget{Searches: 0, Enrichments: 498500, FileFetches: 3995, Reserve: 5}, report.Budget)
+}
+
+func TestRunRateLimit_Disabled(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.SetRateLimit(&github.RateLimit{Disabled: true})
+	setupRateLimitTest(t, mockClient)
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+	assert.Contains(t, out.stdout, "Rate Limiting Disabled")
+	assert.NotContains(t, out.stdout, "Remaining Budget")
+
+	rateLimitJSON = true
+	out = captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+	assert.JSONEq(t, `{"host": "`+github.ResolveHost(hostname)+`", "disabled": true}`, out.stdout)
+}
+
+// resettingClient reports a used quota until it has been asked a number of
+// times, then a fresh one
+type resettingClient struct {
+	*github.MockClient
+	after int
+	calls int
+}
+
+func (c *resettingClient) GetRateLimit(ctx context.Context) (*github.RateLimit, error) {
+	c.calls++
+	if c.calls > c.after {
+		return testRateLimit(time.Now().Add(time.Minute), 10, 5000, 5000), nil
+	}
+	return testRateLimit(time.Now().Add(time.Minute), 3, 5000, 5000), nil
+}
+
+func TestRunRateLimit_Watch(t *testing.T) {
+	client := &resettingClient{MockClient: github.NewMockClient(), after: 2}
+	setupRateLimitTest(t, client)
+	rateLimitWatch = true
+	rateLimitJSON = true
+	rateLimitInterval = time.Millisecond
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err)
+	assert.Equal(t, 3, client.calls, "refreshes until the used quota resets")
+
+	lines := strings.Split(strings.TrimSpace(out.stdout), "\n")
+	require.Len(t, lines, 3, "one compact object per refresh")
+	var last rateLimitReport
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &last))
+	assert.Equal(t, 10, last.Resources["code_search"].Remaining)
+}
+
+func TestRunRateLimit_WatchInterrupted(t *testing.T) {
+	client := &resettingClient{MockClient: github.NewMockClient(), after: 1000}
+	setupRateLimitTest(t, client)
+	rateLimitWatch = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	rateLimitCmd.SetContext(ctx)
+	defer rateLimitCmd.SetContext(context.Background())
+
+	out := captureOutput(func() error { return runRateLimit(rateLimitCmd, nil) })
+	require.NoError(t, out.err, "Ctrl-C just stops watching")
+	assert.Equal(t, 1, client.calls)
+	assert.Contains(t, out.stdout, "Refreshing in 10s (Ctrl-C to stop)")
+}
+
+func TestRunRateLimit_InvalidInterval(t *testing.T) {
+	setupRateLimitTest(t, github.NewMockClient())
+	rateLimitWatch = true
+	rateLimitInterval = 0
+
+	assert.EqualError(t, runRateLimit(rateLimitCmd, nil), "invalid --interval: 0s (must be positive)")
+}
+
+func TestRateLimitWatchDelay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rateLimitInterval = 10 * time.Second
+	defer func() { rateLimitInterval = 10 * time.Second }()
+
+	tests := []struct {
+		name      string
+		rateLimit *github.RateLimit
+		want      time.Duration
+	}{
+		{"nothing used", testRateLimit(now.Add(time.Hour), 10, 5000, 5000), 0},
+		{"reset beyond the interval", testRateLimit(now.Add(time.Hour), 3, 5000, 5000), 10 * time.Second},
+		{"reset within the interval", testRateLimit(now.Add(4*time.Second), 3, 5000, 5000), 4 * time.Second},
+		{"reset already passed", testRateLimit(now.Add(-time.Second), 3, 5000, 5000), time.Second},
+		{"disabled", &github.RateLimit{Disabled: true}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rateLimitWatchDelay(tt.rateLimit, now))
+		})
+	}
+}
+
+func TestEstimateRateLimitBudget(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+
+	budget := estimateRateLimitBudget(testRateLimit(reset, 10, 2000, 3), 5)
+	assert.Equal(t, rateLimitBudget{Searches: 5, Enrichments: 0, FileFetches: 1995, Reserve: 5}, budget, "an exhausted GraphQL quota enriches nothing more")
+
+	// Without a GraphQL quota enrichment falls back to REST on the core quota
+	rateLimit := testRateLimit(reset, 10, 2000, 3)
+	delete(rateLimit.Resources, "graphql")
+	assert.Equal(t, 1995, estimateRateLimitBudget(rateLimit, 5).Enrichments)
+
+	assert.Equal(t, 10, estimateRateLimitBudget(testRateLimit(reset, 10, 2000, 3), 0).Searches)
+}
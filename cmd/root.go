@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/silouanwright/gh-scout/internal/config"
 	"github.com/spf13/cobra"
@@ -14,6 +17,14 @@ var (
 	dryRun     bool
 	configFile string
 	noColor    bool
+	hostname   string
+
+	// Cache flags
+	noCache      bool
+	refreshCache bool
+
+	// Configuration loaded during initialization
+	appConfig *config.Config
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -51,8 +62,16 @@ ranking based on repository quality indicators.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The first Ctrl-C cancels the command's context so in-flight requests stop
+// and partial results are printed; a second one exits immediately.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -63,6 +82,9 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "show what would be searched without executing")
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file path (default: ~/.gh-scout.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().StringVar(&hostname, "hostname", "", "GitHub host to search, e.g. a GitHub Enterprise Server instance (default: github.host config, then gh's host)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "bypass the result cache for this run")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "ignore cached results and refresh the cache")
 
 	// Mark flags as hidden if they're primarily for debugging
 	_ = rootCmd.PersistentFlags().MarkHidden("dry-run")
@@ -84,9 +106,10 @@ func initConfig() {
 		if verbose {
 			fmt.Fprintf(os.Stderr, "Config load failed, using defaults: %v\n", err)
 		}
-		// Load() already returns default config on failure, but we need a fallback
-		cfg, _ = config.Load() // This will return defaults if no config exists
+		// Fall back to defaults so a broken config file never blocks searching
+		cfg = config.Default()
 	}
+	appConfig = cfg
 
 	// Apply configuration defaults to CLI flags if not explicitly set
 	applyConfigDefaults(cfg)
@@ -127,6 +150,14 @@ func applyConfigDefaults(cfg *config.Config) {
 		minStars = cfg.Defaults.MinStars
 	}
 
+	if !searchCmd.Flags().Changed("sort") && cfg.Defaults.SortBy != "" {
+		sort = cfg.Defaults.SortBy
+	}
+
+	if hostname == "" && cfg.GitHub.Host != "" {
+		hostname = cfg.GitHub.Host
+	}
+
 	// Apply output settings
 	if !noColor && cfg.Output.ColorMode == "never" {
 		noColor = true
@@ -136,6 +167,19 @@ func applyConfigDefaults(cfg *config.Config) {
 	// This covers the most commonly used configuration options
 }
 
+// currentConfig returns the loaded configuration, loading it on first use
+func currentConfig() *config.Config {
+	if appConfig != nil {
+		return appConfig
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return config.Default()
+	}
+	return cfg
+}
+
 // GetRootCmd returns the root command for testing
 func GetRootCmd() *cobra.Command {
 	return rootCmd
@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/search"
+)
+
+var (
+	// Editor runner for dependency injection (tests can override)
+	savedEditorRunner = runEditor
+)
+
+// savedCmd represents the saved command family
+var savedCmd = &cobra.Command{
+	Use:   "saved <command>",
+	Short: "Manage saved searches",
+	Long: heredoc.Doc(`
+		Manage saved searches for repeated configuration research workflows.
+
+		Searches are saved with 'gh scout search <query> --save <name>' and stored
+		in the gh-scout configuration file together with all of their filters.
+	`),
+	Example: heredoc.Doc(`
+		# Save a search while running it
+		$ gh scout search "tsconfig.json" --language json --min-stars 100 --save ts-configs
+
+		# List and inspect saved searches
+		$ gh scout saved list
+		$ gh scout saved show ts-configs
+
+		# Run a saved search (output flags can be overridden)
+		$ gh scout saved run ts-configs --limit 20 --format markdown
+
+		# Manage saved searches
+		$ gh scout saved rename ts-configs typescript-configs
+		$ gh scout saved edit typescript-configs
+		$ gh scout saved delete typescript-configs
+	`),
+}
+
+var savedListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all saved searches",
+	Args:    cobra.NoArgs,
+	RunE:    runSavedList,
+}
+
+var savedShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the details of a saved search",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSavedShow,
+}
+
+var savedRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Execute a saved search",
+	Long: heredoc.Doc(`
+		Execute a saved search with its stored query and filters.
+
+		Output flags such as --limit and --format can be overridden for this run.
+		The search's use count and last used time are updated after it runs.
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: runSavedRun,
+}
+
+var savedDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved search",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runSavedDelete,
+}
+
+var savedRenameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename a saved search",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runSavedRename,
+}
+
+var savedEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit a saved search in your editor",
+	Long: heredoc.Doc(`
+		Open a saved search as YAML in your editor.
+
+		The editor is taken from defaults.editor, output.editor_command,
+		$VISUAL or $EDITOR, in that order.
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: runSavedEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(savedCmd)
+
+	savedCmd.AddCommand(savedListCmd)
+	savedCmd.AddCommand(savedShowCmd)
+	savedCmd.AddCommand(savedRunCmd)
+	savedCmd.AddCommand(savedDeleteCmd)
+	savedCmd.AddCommand(savedRenameCmd)
+	savedCmd.AddCommand(savedEditCmd)
+
+	// Output overrides share the search command's flag variables
+	savedRunCmd.Flags().IntVar(&searchLimit, "limit", 50, "maximum results per page (default: 50, max: 100)")
+	savedRunCmd.Flags().IntVar(&searchPage, "page", 0, "specific page number (more API efficient than auto-pagination)")
+	savedRunCmd.Flags().StringVar(&outputFormat, "format", "default", "output format: default, json, markdown, compact, csv, tsv, ndjson, html, sarif")
+	savedRunCmd.Flags().StringSliceVar(&searchColumns, "columns", nil, "csv/tsv columns: repo, path, url, stars, forks, language, pushed_at, score, sha, fragment")
+	savedRunCmd.Flags().StringVar(&outputFile, "output", "", "export results to file (e.g., results.md, data.json, results.csv)")
+	savedRunCmd.Flags().BoolVar(&pipe, "pipe", false, "output to stdout (for piping to other tools)")
+	addExportFlags(savedRunCmd)
+	savedRunCmd.Flags().BoolVar(&liteMode, "lite", false, "lite mode: faster search, skips star counts (saves API quota)")
+}
+
+func runSavedList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	searches := cfg.ListSavedSearches()
+	if len(searches) == 0 {
+		fmt.Println("💾 No saved searches yet")
+		fmt.Println()
+		fmt.Println("💡 Save one with: gh scout search \"tsconfig.json\" --language json --save ts-configs")
+		return nil
+	}
+
+	fmt.Printf("💾 Saved searches (%d):\n\n", len(searches))
+	for _, saved := range searches {
+		fmt.Printf("  %s\n", saved.Name)
+		fmt.Printf("     Query: %s\n", buildSavedQuery(saved))
+		if saved.Description != "" {
+			fmt.Printf("     Description: %s\n", saved.Description)
+		}
+		fmt.Printf("     Used: %d times, last %s\n", saved.UseCount, formatLastUsed(saved.LastUsed))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func runSavedShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	saved, exists := cfg.GetSavedSearch(args[0])
+	if !exists {
+		return savedNotFoundError(args[0])
+	}
+
+	fmt.Printf("💾 %s\n\n", saved.Name)
+	if saved.Description != "" {
+		fmt.Printf("📋 %s\n\n", saved.Description)
+	}
+	fmt.Printf("  query: %s\n", saved.Query)
+	fmt.Printf("  full query: %s\n", buildSavedQuery(saved))
+	if len(saved.Tags) > 0 {
+		fmt.Printf("  tags: %s\n", strings.Join(saved.Tags, ", "))
+	}
+
+	filters, err := yaml.Marshal(saved.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to format filters: %w", err)
+	}
+	if strings.TrimSpace(string(filters)) != "{}" {
+		fmt.Println("  filters:")
+		for _, line := range strings.Split(strings.TrimRight(string(filters), "\n"), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	fmt.Printf("  created: %s\n", saved.Created.Format(time.RFC3339))
+	fmt.Printf("  last used: %s\n", formatLastUsed(saved.LastUsed))
+	fmt.Printf("  use count: %d\n", saved.UseCount)
+
+	return nil
+}
+
+func runSavedRun(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	saved, exists := cfg.GetSavedSearch(name)
+	if !exists {
+		return savedNotFoundError(name)
+	}
+
+	if err := validateSearchFlags(); err != nil {
+		return err
+	}
+
+	if err := ensureSearchClient(); err != nil {
+		return err
+	}
+
+	// Apply the saved filters for this run only
+	restore := applySavedSearch(saved)
+	defer restore()
+
+	if verbose {
+		fmt.Printf("Running saved search: %s\n", name)
+	}
+
+	if err := runSearchQuery(cmd.Context(), buildSearchQuery(strings.Fields(saved.Query))); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	// Track usage so list ordering reflects what's actually used
+	if err := cfg.UseSavedSearch(name); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return nil
+}
+
+func runSavedDelete(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.DeleteSavedSearch(args[0]); err != nil {
+		return savedNotFoundError(args[0])
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✅ Deleted saved search: %s\n", args[0])
+	return nil
+}
+
+func runSavedRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := cfg.RenameSavedSearch(oldName, newName); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✅ Renamed saved search: %s → %s\n", oldName, newName)
+	return nil
+}
+
+func runSavedEdit(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	saved, exists := cfg.GetSavedSearch(name)
+	if !exists {
+		return savedNotFoundError(name)
+	}
+
+	// Only the user-editable fields are exposed in the editor
+	editable := savedSearchDocument{
+		Query:       saved.Query,
+		Description: saved.Description,
+		Tags:        saved.Tags,
+		Filters:     saved.Filters,
+	}
+
+	data, err := yaml.Marshal(editable)
+	if err != nil {
+		return fmt.Errorf("failed to format saved search: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "gh-scout-saved-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := savedEditorRunner(resolveEditor(cfg), tmpFile.Name()); err != nil {
+		return fmt.Errorf("editor failed: %w", err)
+	}
+
+	edited, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return fmt.Errorf("failed to read edited search: %w", err)
+	}
+
+	var updated savedSearchDocument
+	if err := yaml.Unmarshal(edited, &updated); err != nil {
+		return fmt.Errorf("failed to parse edited search: %w", err)
+	}
+	if strings.TrimSpace(updated.Query) == "" {
+		return fmt.Errorf("saved search '%s': query is required", name)
+	}
+
+	saved.Query = updated.Query
+	saved.Description = updated.Description
+	saved.Tags = updated.Tags
+	saved.Filters = updated.Filters
+	cfg.AddSavedSearch(saved)
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✅ Updated saved search: %s\n", name)
+	return nil
+}
+
+// savedSearchDocument is the editable YAML representation of a saved search
+type savedSearchDocument struct {
+	Query       string               `yaml:"query"`
+	Description string               `yaml:"description"`
+	Tags        []string             `yaml:"tags"`
+	Filters     search.SearchFilters `yaml:"filters"`
+}
+
+// saveCurrentSearch stores the query terms and active filters under the given name
+func saveCurrentSearch(name string, terms []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	saved := config.SavedSearch{
+		Name:    name,
+		Query:   strings.Join(terms, " "),
+		Filters: currentSearchFilters(),
+	}
+
+	// Keep user-provided metadata when overwriting an existing search
+	if existing, exists := cfg.GetSavedSearch(name); exists {
+		saved.Description = existing.Description
+		saved.Tags = existing.Tags
+		saved.LastUsed = existing.LastUsed
+	}
+
+	cfg.AddSavedSearch(saved)
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save search: %w", err)
+	}
+
+	fmt.Printf("💾 Saved search as: %s (run with: gh scout saved run %s)\n", name, name)
+	return nil
+}
+
+// applySavedSearch sets the search filter flags from a saved search and
+// returns a function that restores the previous values
+func applySavedSearch(saved config.SavedSearch) func() {
+	previous := currentSearchFilters()
+	previousSaved := savedFilters
+
+	setSearchFilters(saved.Filters)
+	filters := saved.Filters
+	savedFilters = &filters
+
+	return func() {
+		setSearchFilters(previous)
+		savedFilters = previousSaved
+	}
+}
+
+// setSearchFilters sets the search filter flags from a SearchFilters struct
+func setSearchFilters(filters search.SearchFilters) {
+	searchLanguage = filters.Language
+	searchFilename = filters.Filename
+	searchExtension = filters.Extension
+	searchRepo = filters.Repository
+	searchPath = filters.Path
+	searchOwner = filters.Owner
+	searchSize = filters.Size
+	minStars = filters.MinStars
+}
+
+// buildSavedQuery returns the full GitHub query for a saved search
+func buildSavedQuery(saved config.SavedSearch) string {
+	return search.NewQueryBuilderFromFilters(strings.Fields(saved.Query), saved.Filters).Build()
+}
+
+// resolveEditor picks the editor command from config and environment
+func resolveEditor(cfg *config.Config) string {
+	for _, editor := range []string{
+		cfg.Defaults.Editor,
+		cfg.Output.EditorCommand,
+		os.Getenv("VISUAL"),
+		os.Getenv("EDITOR"),
+	} {
+		if strings.TrimSpace(editor) != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// runEditor opens the file in the editor and waits for it to exit
+func runEditor(editor, path string) error {
+	parts := strings.Fields(editor)
+	editorCmd := exec.Command(parts[0], append(parts[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	return editorCmd.Run()
+}
+
+// formatLastUsed formats a last used time, handling never-used searches
+func formatLastUsed(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return formatDuration(time.Since(t)) + " ago"
+}
+
+// savedNotFoundError provides guidance when a saved search doesn't exist
+func savedNotFoundError(name string) error {
+	return fmt.Errorf(`saved search '%s' not found
+
+💡 **Try**:
+  gh scout saved list                                  # See available searches
+  gh scout search "query" --language go --save %s   # Save a new search`, name, name)
+}
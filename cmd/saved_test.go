@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSavedConfigDir isolates config reads and writes in a temporary directory
+func setupSavedConfigDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("GH_SEARCH_CONFIG_DIR", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+}
+
+// seedSavedSearch stores a saved search in the isolated config
+func seedSavedSearch(t *testing.T, saved config.SavedSearch) {
+	t.Helper()
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	cfg.AddSavedSearch(saved)
+	require.NoError(t, cfg.Save())
+}
+
+func TestSearchWithSaveFlag(t *testing.T) {
+	setupSavedConfigDir(t)
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("strict language:json filename:tsconfig.json stars:>=100", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "tsconfig.json", `"strict": true`),
+	))
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() { searchClient = originalClient }()
+
+	searchLanguage = "json"
+	searchFilename = "tsconfig.json"
+	minStars = 100
+	saveAs = "ts-strict"
+
+	output := captureOutput(func() error {
+		return runSearch(searchCmd, []string{"strict"})
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "Saved search as: ts-strict")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	saved, exists := cfg.GetSavedSearch("ts-strict")
+	require.True(t, exists)
+	assert.Equal(t, "strict", saved.Query)
+	assert.Equal(t, search.SearchFilters{
+		Language: "json",
+		Filename: "tsconfig.json",
+		MinStars: 100,
+	}, saved.Filters)
+}
+
+func TestSearchWithSaveFlag_RejectsBatchTargets(t *testing.T) {
+	setupSavedConfigDir(t)
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	saveAs = "orgs"
+	batchOrgs = []string{"vercel"}
+
+	output := captureOutput(func() error {
+		return runSearch(searchCmd, []string{"next.config"})
+	})
+	require.Error(t, output.err)
+	assert.Contains(t, output.err.Error(), "--save cannot be combined with --repos or --orgs")
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	_, exists := cfg.GetSavedSearch("orgs")
+	assert.False(t, exists)
+}
+
+func TestSavedRun(t *testing.T) {
+	setupSavedConfigDir(t)
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	seedSavedSearch(t, config.SavedSearch{
+		Name:  "react-hooks",
+		Query: "useState",
+		Filters: search.SearchFilters{
+			Language:   "typescript",
+			Repository: []string{"facebook/react"},
+			Fork:       "true",
+		},
+	})
+
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("useState language:typescript fork:true repo:facebook/react", github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "packages/react/src/ReactHooks.js", "function useState"),
+	))
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() { searchClient = originalClient }()
+
+	output := captureOutput(func() error {
+		savedRunCmd.SetContext(context.Background())
+		return runSavedRun(savedRunCmd, []string{"react-hooks"})
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "facebook/react")
+	assert.True(t, mockClient.VerifyCall("SearchCode", "useState language:typescript fork:true repo:facebook/react"))
+
+	// Saved filters must not leak into later searches
+	assert.Empty(t, searchLanguage)
+	assert.Nil(t, savedFilters)
+
+	// Usage is tracked and persisted
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	saved, _ := cfg.GetSavedSearch("react-hooks")
+	assert.Equal(t, 1, saved.UseCount)
+	assert.False(t, saved.LastUsed.IsZero())
+}
+
+func TestSavedRun_NotFound(t *testing.T) {
+	setupSavedConfigDir(t)
+
+	err := runSavedRun(savedRunCmd, []string{"missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "saved search 'missing' not found")
+}
+
+func TestSavedListShowRenameDelete(t *testing.T) {
+	setupSavedConfigDir(t)
+	seedSavedSearch(t, config.SavedSearch{
+		Name:        "docker",
+		Query:       "FROM node",
+		Description: "Node Dockerfiles",
+		Filters:     search.SearchFilters{Filename: "Dockerfile"},
+	})
+
+	output := captureOutput(func() error {
+		return runSavedList(savedListCmd, nil)
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "docker")
+	assert.Contains(t, output.stdout, "FROM node filename:Dockerfile")
+	assert.Contains(t, output.stdout, "never")
+
+	output = captureOutput(func() error {
+		return runSavedShow(savedShowCmd, []string{"docker"})
+	})
+	require.NoError(t, output.err)
+	assert.Contains(t, output.stdout, "Node Dockerfiles")
+	assert.Contains(t, output.stdout, "filename: Dockerfile")
+
+	output = captureOutput(func() error {
+		return runSavedRename(savedRenameCmd, []string{"docker", "node-docker"})
+	})
+	require.NoError(t, output.err)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	_, exists := cfg.GetSavedSearch("node-docker")
+	assert.True(t, exists)
+
+	output = captureOutput(func() error {
+		return runSavedDelete(savedDeleteCmd, []string{"node-docker"})
+	})
+	require.NoError(t, output.err)
+
+	cfg, err = config.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.SavedSearches)
+}
+
+func TestSavedEdit(t *testing.T) {
+	setupSavedConfigDir(t)
+	seedSavedSearch(t, config.SavedSearch{Name: "vite", Query: "vite.config"})
+
+	originalRunner := savedEditorRunner
+	defer func() { savedEditorRunner = originalRunner }()
+	savedEditorRunner = func(editor, path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		edited := strings.Replace(string(data), "query: vite.config", "query: defineConfig", 1)
+		edited = strings.Replace(edited, `description: ""`, "description: Vite configs", 1)
+		return os.WriteFile(path, []byte(edited), 0600)
+	}
+
+	output := captureOutput(func() error {
+		return runSavedEdit(savedEditCmd, []string{"vite"})
+	})
+	require.NoError(t, output.err)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	saved, exists := cfg.GetSavedSearch("vite")
+	require.True(t, exists)
+	assert.Equal(t, "defineConfig", saved.Query)
+	assert.Equal(t, "Vite configs", saved.Description)
+}
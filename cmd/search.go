@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/silouanwright/gh-scout/internal/analysis"
+	"github.com/silouanwright/gh-scout/internal/dedupe"
 	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/output"
+	"github.com/silouanwright/gh-scout/internal/rank"
 	"github.com/silouanwright/gh-scout/internal/search"
 	"github.com/spf13/cobra"
 )
@@ -35,20 +39,38 @@ var (
 	searchPage      int // New: page-based pagination
 	contextLines    int
 	outputFormat    string
-	outputFile      string // New: export to file
+	outputFile      string   // New: export to file
+	searchColumns   []string // --columns for the csv and tsv formats
 	pipe            bool
 	minStars        int
 	sort            string
 	order           string
-	liteMode        bool // --lite flag for lightweight results (saves API quota)
+	liteMode        bool   // --lite flag for lightweight results (saves API quota)
+	fetchContent    bool   // --fetch-content to download matched files for real context lines
+	consensusMode   bool   // --consensus to parse matched config files and report common settings
+	dedupeMode      string // --dedupe to collapse copies of the same file: sha, content or repo
 
 	// Batch search flags (Phase 2)
 	batchRepos    []string // --repos flag for multiple repositories
 	batchOrgs     []string // --orgs flag for multiple organizations
 	aggregateMode bool     // --aggregate flag
 	compareMode   bool     // --compare flag
+
+	// Organization repository filters for --orgs
+	orgTopics          []string
+	orgLanguage        string
+	orgMinStars        int
+	orgIncludeArchived bool
+	orgIncludeForks    bool
+
+	// Saved search flags
+	saveAs       string                // --save flag to store the search under a name
+	savedFilters *search.SearchFilters // filters without CLI flags, set when running a saved search
 )
 
+// queryTokenPattern splits a query into quoted phrases and bare words
+var queryTokenPattern = regexp.MustCompile(`"[^"]*"|\S+`)
+
 // searchCmd represents the search command
 var searchCmd = &cobra.Command{
 	Use:   "search <query> [flags]",
@@ -63,6 +85,8 @@ ranking based on repository quality indicators.`,
 	Example: `  # Configuration discovery workflows
   gh scout "tsconfig.json" --language json --limit 10
   gh scout "vite.config" --language javascript --context 30
+  gh scout "tsconfig.json" --language json --consensus   # Most common settings
+  gh scout "lodash" --filename lodash.js --dedupe         # Collapse vendored copies
   gh scout "dockerfile" --filename dockerfile --repo "**/react"
 
   # Page-based search (API efficient for large datasets)
@@ -84,7 +108,7 @@ ranking based on repository quality indicators.`,
   # Multi-repository batch searches (Phase 2)
   gh scout "docker-compose.yml" --repos microsoft/vscode,facebook/react,vercel/next.js --aggregate
   gh scout "tsconfig.json" --orgs microsoft,google,facebook --min-stars 500 --compare
-  gh scout "webpack OR vite" --repos facebook/*,vercel/* --compare
+  gh scout "webpack OR vite" --orgs facebook,vercel --compare
 
   # Auto-pagination (less API efficient but convenient)
   gh scout "hooks" --limit 200                  # Automatically fetches 2 pages
@@ -94,13 +118,51 @@ ranking based on repository quality indicators.`,
   gh scout "hooks" --pipe --output data.txt                 # Pipe format export
 
   # Pipe results for further processing
-  gh scout "react hooks" --language typescript --pipe`,
+  gh scout "react hooks" --language typescript --pipe
+
+  # Select fields like other gh commands, then filter with jq or a template
+  gh scout "vite.config" --json repository.fullName,stars,path,url
+  gh scout "vite.config" --json repository.fullName,stars --jq '.[] | select(.stars > 1000) | .repository.fullName'
+  gh scout "vite.config" --json path,url --template '{{range .}}{{hyperlink .url .path}}{{"\n"}}{{end}}'`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runSearch,
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	// Validate input parameters to prevent panics
+	if err := validateSearchFlags(); err != nil {
+		return err
+	}
+
+	if err := ensureSearchClient(); err != nil {
+		return err
+	}
+
+	// Check if batch flags are used (Phase 2 functionality)
+	if len(batchRepos) > 0 || len(batchOrgs) > 0 {
+		return executeBatchRepoSearch(cmd.Context(), args)
+	}
+
+	// Build search query from args and flags (migrated from ghx)
+	query := buildSearchQuery(args)
+	if consensusMode {
+		// Known config types (tsconfig, package, ...) expand to their filename and language
+		query = search.BuildConfigQuery(strings.Join(args, " "), currentSearchFilters())
+	}
+
+	if err := runSearchQuery(cmd.Context(), query); err != nil {
+		return err
+	}
+
+	// Save the search for reuse once it has run successfully
+	if saveAs != "" && !dryRun {
+		return saveCurrentSearch(saveAs, args)
+	}
+
+	return nil
+}
+
+// validateSearchFlags validates input parameters to prevent panics
+func validateSearchFlags() error {
 	if searchLimit <= 0 {
 		return fmt.Errorf("invalid limit: %d (must be greater than 0)", searchLimit)
 	}
@@ -113,41 +175,78 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	if searchPage > maxPage {
 		return fmt.Errorf("page number too large (max: %d)", maxPage)
 	}
-
-	// Initialize client if not set (thread-safe)
-	if searchClient == nil {
-		searchClientMutex.Lock()
-		// Double-check after acquiring lock
-		if searchClient == nil {
-			client, err := createGitHubClient()
-			if err != nil {
-				searchClientMutex.Unlock()
-				return handleClientError(err)
-			}
-			searchClient = client
+	if _, err := rank.Normalize(sort); err != nil {
+		return err
+	}
+	if err := dedupe.ValidateMode(dedupeMode); err != nil {
+		return err
+	}
+	if dedupeMode != "" && (len(batchRepos) > 0 || len(batchOrgs) > 0) {
+		return fmt.Errorf("--dedupe cannot be combined with --repos or --orgs\n\n💡 Use --repo or --owner to search several repositories at once")
+	}
+	if saveAs != "" && (len(batchRepos) > 0 || len(batchOrgs) > 0) {
+		return fmt.Errorf("--save cannot be combined with --repos or --orgs, saved searches do not keep them\n\n💡 Use --repo or --owner to save a search across several repositories")
+	}
+	if len(searchColumns) > 0 {
+		if _, err := output.ParseColumns(searchColumns); err != nil {
+			return err
+		}
+		if format := searchOutputFormat(); format != output.FormatCSV && format != output.FormatTSV {
+			return fmt.Errorf("--columns only applies to --format csv or tsv")
 		}
-		searchClientMutex.Unlock()
+	}
+	if err := validateExportFlags(); err != nil {
+		return err
+	}
+	if consensusMode {
+		return validateConsensusFlags()
+	}
+	return nil
+}
+
+// ensureSearchClient initializes the search client if not set (thread-safe)
+func ensureSearchClient() error {
+	if searchClient != nil {
+		return nil
 	}
 
-	// Check if batch flags are used (Phase 2 functionality)
-	if len(batchRepos) > 0 || len(batchOrgs) > 0 {
-		return executeBatchRepoSearch(cmd.Context(), args)
+	searchClientMutex.Lock()
+	defer searchClientMutex.Unlock()
+
+	// Double-check after acquiring lock
+	if searchClient == nil {
+		client, err := createGitHubClient(hostname)
+		if err != nil {
+			return handleClientError(err)
+		}
+		searchClient = client
 	}
+	return nil
+}
 
-	// Build search query from args and flags (migrated from ghx)
-	query := buildSearchQuery(args)
+// newRateLimiter creates a rate limiter that waits for resource's quota on
+// host to reset once only github.rate_limit_buffer requests remain
+func newRateLimiter(host, resource string) *github.RateLimiter {
+	buffer := currentConfig().GitHub.RateLimitBuffer
+	return github.NewRateLimiter().
+		WithQuota(github.SharedQuotaTracker(), github.ResolveHost(host), resource, buffer).
+		OnQuotaWait(func(resource string, wait time.Duration) {
+			fmt.Fprintf(os.Stderr, "⏳ GitHub %s rate limit nearly used up, waiting %s for it to reset\n", resource, wait.Round(time.Second))
+		})
+}
 
+// runSearchQuery executes a fully built query and outputs the results
+func runSearchQuery(ctx context.Context, query string) error {
 	if dryRun {
 		fmt.Printf("Would search GitHub with query: %s\n", query)
 		return nil
 	}
 
 	if verbose {
-		fmt.Printf("Searching GitHub with query: %s\n", query)
+		progressf("Searching GitHub with query: %s\n", query)
 	}
 
 	// Execute search with error handling and timeout
-	ctx := cmd.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -158,58 +257,115 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		defer cancel()
 	}
 
+	// NDJSON goes out page by page rather than after the whole search
+	if streamingOutput() {
+		return streamSearch(ctx, query, os.Stdout)
+	}
+
 	results, err := executeSearch(ctx, query)
 	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("search %s before any results arrived: %w", stopReason(ctx), ctx.Err())
+		}
 		return handleSearchError(err, query)
 	}
 
+	// Drop tests, vendored code and excluded languages per the analysis settings,
+	// and collapse copies of the same file with --dedupe
+	filterResults(ctx, query, results)
+
+	// Reorder by --sort modes GitHub cannot sort by
+	rankResults(results)
+
 	if verbose {
 		fmt.Printf("Found %d results\n", len(results.Items))
+		printCacheUsage(searchClient)
+		printEnrichmentUsage(searchClient)
 	}
 
-	// Process and output results
-	return outputResults(results)
-}
-
-// buildSearchQuery constructs GitHub search query from args and flags using QueryBuilder
-func buildSearchQuery(terms []string) string {
-	// Use existing QueryBuilder to eliminate code duplication
-	qb := search.NewQueryBuilder(terms)
-
-	// Apply all CLI flags using the QueryBuilder methods
-	if searchLanguage != "" {
-		qb = qb.WithLanguage(searchLanguage)
+	if consensusMode {
+		return outputConsensus(ctx, results)
 	}
 
-	if searchFilename != "" {
-		qb = qb.WithFilename(searchFilename)
+	if fetchContent {
+		fetchResultContents(ctx, results, query)
 	}
 
-	if searchExtension != "" {
-		qb = qb.WithExtension(searchExtension)
+	// Process and output results
+	if err := outputResults(results, query); err != nil {
+		return err
 	}
+	return stoppedEarly(ctx)
+}
 
-	if len(searchRepo) > 0 {
-		qb = qb.WithRepositories(searchRepo)
-	}
+// fetchResultContents downloads matched files and attaches context snippets
+func fetchResultContents(ctx context.Context, results *github.SearchResults, query string) {
+	stats := github.FetchFileContents(ctx, searchClient, results, github.ContentFetchOptions{
+		ContextLines: contextLines,
+		Terms:        contentSearchTerms(query),
+		RateLimiter:  newRateLimiter(hostname, github.ResourceCore),
+	})
 
-	if searchPath != "" {
-		qb = qb.WithPath(searchPath)
+	if verbose {
+		failed := ""
+		if stats.Failed > 0 {
+			failed = fmt.Sprintf(" (%d failed)", stats.Failed)
+		}
+		progressf("Fetched content for %d files%s\n", stats.Fetched, failed)
 	}
+}
 
-	if len(searchOwner) > 0 {
-		qb = qb.WithOwners(searchOwner)
+// contentSearchTerms extracts the free-text terms from a query, dropping
+// qualifiers (language:go), excluded terms and boolean operators
+func contentSearchTerms(query string) []string {
+	var terms []string
+	negated := false
+	for _, token := range queryTokenPattern.FindAllString(query, -1) {
+		if token == "NOT" {
+			negated = true
+			continue
+		}
+		if negated || strings.HasPrefix(token, "-") || token == "AND" || token == "OR" {
+			negated = false
+			continue
+		}
+		if !strings.HasPrefix(token, `"`) && strings.Contains(token, ":") {
+			continue
+		}
+		if term := strings.Trim(token, `"`); term != "" {
+			terms = append(terms, term)
+		}
 	}
+	return terms
+}
+
+// buildSearchQuery constructs GitHub search query from args and flags using QueryBuilder
+func buildSearchQuery(terms []string) string {
+	// Use existing QueryBuilder to eliminate code duplication
+	return search.NewQueryBuilderFromFilters(terms, currentSearchFilters()).Build()
+}
 
-	if searchSize != "" {
-		qb = qb.WithSize(searchSize)
+// currentSearchFilters collects the filter flags into a SearchFilters struct
+func currentSearchFilters() search.SearchFilters {
+	filters := search.SearchFilters{
+		Language:   searchLanguage,
+		Filename:   searchFilename,
+		Extension:  searchExtension,
+		Repository: searchRepo,
+		Path:       searchPath,
+		Owner:      searchOwner,
+		Size:       searchSize,
+		MinStars:   minStars,
 	}
 
-	if minStars > 0 {
-		qb = qb.WithMinStars(minStars)
+	// Filters without a dedicated flag come from saved searches
+	if savedFilters != nil {
+		filters.MaxAge = savedFilters.MaxAge
+		filters.Fork = savedFilters.Fork
+		filters.Match = savedFilters.Match
 	}
 
-	return qb.Build()
+	return filters
 }
 
 // executeSearch performs the GitHub search with optional pagination
@@ -232,7 +388,7 @@ func executeSinglePageSearch(ctx context.Context, query string) (*github.SearchR
 	}
 
 	opts := &github.SearchOptions{
-		Sort:  sort,
+		Sort:  rank.APISort(sort),
 		Order: order,
 		ListOptions: github.ListOptions{
 			Page:    searchPage,
@@ -260,11 +416,6 @@ func executeSinglePageSearch(ctx context.Context, query string) (*github.SearchR
 
 // executeAutoPageSearch automatically paginates for high limits (legacy behavior)
 func executeAutoPageSearch(ctx context.Context, query string) (*github.SearchResults, error) {
-	// Initialize rate limiter if not set
-	if searchRateLimiter == nil {
-		searchRateLimiter = github.NewRateLimiter()
-	}
-
 	var allResults *github.SearchResults
 	page := 1
 	remaining := searchLimit
@@ -276,24 +427,13 @@ func executeAutoPageSearch(ctx context.Context, query string) (*github.SearchRes
 			perPage = GitHubMaxResultsPerPage
 		}
 
-		opts := &github.SearchOptions{
-			Sort:  sort,
-			Order: order,
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: perPage,
-			},
-			SkipEnrichment: liteMode,
-		}
-
-		// Execute search with rate limiting and retry logic
-		var results *github.SearchResults
-		err := searchRateLimiter.WithRetry(ctx, fmt.Sprintf("search page %d", page), func() error {
-			var searchErr error
-			results, searchErr = searchClient.SearchCode(ctx, query, opts)
-			return searchErr
-		})
+		results, err := fetchSearchPage(ctx, query, page, perPage)
 		if err != nil {
+			// Keep the pages already fetched when the search is interrupted
+			if allResults != nil && ctx.Err() != nil {
+				warnPartialResults(ctx, len(allResults.Items))
+				break
+			}
 			return nil, err
 		}
 
@@ -314,22 +454,10 @@ func executeAutoPageSearch(ctx context.Context, query string) (*github.SearchRes
 		page++
 
 		// Add intelligent delay between paginated calls (except after last page)
-		if remaining > 0 && len(results.Items) == perPage {
-			// Estimate complexity based on current pagination
-			complexity := github.MediumComplexity
-			if page > 10 { // High pagination suggests complex query
-				complexity = github.HighComplexity
-			} else if page <= 3 {
-				complexity = github.LowComplexity
-			}
-
-			if verbose {
-				fmt.Printf("  Adding delay between pages (complexity: %v)...\n", complexity)
-			}
-
-			err := searchRateLimiter.IntelligentDelay(ctx, complexity)
-			if err != nil {
-				return nil, fmt.Errorf("operation cancelled during pagination delay: %w", err)
+		if remaining > 0 {
+			if err := delayNextPage(ctx, page); err != nil {
+				warnPartialResults(ctx, len(allResults.Items))
+				break
 			}
 		}
 	}
@@ -337,162 +465,183 @@ func executeAutoPageSearch(ctx context.Context, query string) (*github.SearchRes
 	return allResults, nil
 }
 
-// outputResults formats and outputs the search results
-func outputResults(results *github.SearchResults) error {
-	if results.Total != nil && *results.Total == 0 {
+// fetchSearchPage fetches one page of an auto-paginated search with rate
+// limiting and retries
+func fetchSearchPage(ctx context.Context, query string, page, perPage int) (*github.SearchResults, error) {
+	opts := &github.SearchOptions{
+		Sort:  rank.APISort(sort),
+		Order: order,
+		ListOptions: github.ListOptions{
+			Page:    page,
+			PerPage: perPage,
+		},
+		SkipEnrichment: liteMode,
+	}
+
+	var results *github.SearchResults
+	err := codeSearchLimiter().WithRetry(ctx, fmt.Sprintf("search page %d", page), func() error {
+		var searchErr error
+		results, searchErr = searchClient.SearchCode(ctx, query, opts)
+		return searchErr
+	})
+	return results, err
+}
+
+// delayNextPage waits before fetching page, longer the deeper the search
+// paginates
+func delayNextPage(ctx context.Context, page int) error {
+	// Estimate complexity based on current pagination
+	complexity := github.MediumComplexity
+	if page > 10 { // High pagination suggests complex query
+		complexity = github.HighComplexity
+	} else if page <= 3 {
+		complexity = github.LowComplexity
+	}
+
+	if verbose {
+		progressf("  Adding delay between pages (complexity: %v)...\n", complexity)
+	}
+	return codeSearchLimiter().IntelligentDelay(ctx, complexity)
+}
+
+// codeSearchLimiter returns the rate limiter for code search pages,
+// creating it on first use
+func codeSearchLimiter() *github.RateLimiter {
+	if searchRateLimiter == nil {
+		searchRateLimiter = newRateLimiter(hostname, github.ResourceCodeSearch)
+	}
+	return searchRateLimiter
+}
+
+// outputResults renders results in the selected --format and writes them to stdout or --output
+func outputResults(results *github.SearchResults, query string) error {
+	// NDJSON still ends with its summary record, SARIF with an empty run and
+	// --json with an empty array so pipelines can tell the search finished
+	format := searchOutputFormat()
+	if results.Total != nil && *results.Total == 0 && format != output.FormatNDJSON && format != output.FormatSARIF && !exportActive() {
 		fmt.Println("No results found.")
 		return nil
 	}
 
-	// Determine output destination and format
-	var output string
-	var err error
-
-	if pipe || outputFormat == "pipe" {
-		output, err = formatPipeResults(results)
-	} else {
-		switch outputFormat {
-		case "json":
-			output, err = formatJSONResults(results)
-		case "markdown":
-			output, err = formatMarkdownResults(results)
-		case "compact":
-			output, err = formatCompactResults(results)
-		case "default", "":
-			output, err = formatDefaultResults(results)
-		default:
-			return fmt.Errorf("unsupported format: %s (supported: default, json, markdown, compact, pipe)", outputFormat)
-		}
+	formatter, err := searchFormatter(format)
+	if err != nil {
+		return err
 	}
 
+	rendered, err := formatter.Format(results, query)
 	if err != nil {
 		return err
 	}
+	if !exportActive() {
+		rendered += formatPatternReport(results, format)
+	}
 
 	// Output to file or stdout
 	if outputFile != "" {
-		return writeToFile(output, outputFile)
+		return writeToFile(rendered, outputFile)
 	}
 
-	fmt.Print(output)
+	fmt.Print(rendered)
 	return nil
 }
 
-// detectLanguage detects programming language from file path using constants map
-func detectLanguage(path string) string {
-	ext := filepath.Ext(path)
-	if lang, ok := LanguageExtensionMap[ext]; ok {
-		return lang
+// searchFormatter returns the formatter for format, or for --json when set
+func searchFormatter(format string) (output.Formatter, error) {
+	if exportActive() {
+		return exportFormatter()
 	}
 
-	// Special case for Dockerfile (no extension)
-	if strings.Contains(strings.ToLower(filepath.Base(path)), "dockerfile") {
-		return LanguageDockerfile
+	opts := searchOutputOptions()
+	if format == output.FormatCSV || format == output.FormatTSV {
+		columns, err := output.ParseColumns(searchColumns)
+		if err != nil {
+			return nil, err
+		}
+		opts.Columns = columns
 	}
-
-	return ""
+	return output.NewFormatter(format, opts)
 }
 
-// formatPipeResults formats results for pipe output
-func formatPipeResults(results *github.SearchResults) (string, error) {
-	var output strings.Builder
-
-	for _, item := range results.Items {
-		if item.Repository.FullName != nil && item.Path != nil && item.HTMLURL != nil {
-			output.WriteString(fmt.Sprintf("%s:%s:%s\n", *item.Repository.FullName, *item.Path, *item.HTMLURL))
+// searchOutputFormat returns the format to render: pipe for --pipe, or csv,
+// tsv, ndjson, html and sarif from the --output extension when no --format was chosen
+func searchOutputFormat() string {
+	if pipe {
+		return output.FormatPipe
+	}
+	if outputFormat == output.FormatDefault && outputFile != "" {
+		switch strings.ToLower(filepath.Ext(outputFile)) {
+		case ".csv":
+			return output.FormatCSV
+		case ".tsv":
+			return output.FormatTSV
+		case ".ndjson", ".jsonl":
+			return output.FormatNDJSON
+		case ".html", ".htm":
+			return output.FormatHTML
+		case ".sarif":
+			return output.FormatSARIF
 		}
 	}
-
-	return output.String(), nil
+	return outputFormat
 }
 
-// formatDefaultResults formats results for default output
-func formatDefaultResults(results *github.SearchResults) (string, error) {
-	var output strings.Builder
-
-	// Show pagination-aware results summary
-	if results.Total != nil {
-		totalResults := *results.Total
-		displayedCount := len(results.Items)
-
-		// Calculate result range based on pagination
-		var startResult, endResult int
-		if searchPage > 0 {
-			// Single page mode - calculate exact range
-			startResult = ((searchPage - 1) * searchLimit) + 1
-			endResult = startResult + displayedCount - 1
-		} else {
-			// Auto pagination mode (legacy)
-			startResult = 1
-			endResult = displayedCount
-		}
-
-		// Format the pagination-aware header
-		if totalResults > displayedCount {
-			output.WriteString(fmt.Sprintf("🔍 Found %d total results (showing %d-%d)\n\n",
-				totalResults, startResult, endResult))
-		} else {
-			output.WriteString(fmt.Sprintf("🔍 Found %d results\n\n", totalResults))
-		}
-
-		// Add pagination guidance if there are more results
-		if totalResults > endResult {
-			remainingResults := totalResults - endResult
-			nextPage := searchPage + 1
-			if searchPage == 0 {
-				nextPage = 2 // Auto pagination starts at page 1, next is page 2
-			}
-
-			output.WriteString(fmt.Sprintf("💡 **%d more results available** - Use `--page %d` to see results %d-%d\n\n",
-				remainingResults, nextPage, endResult+1, min(totalResults, endResult+searchLimit)))
-		}
+// searchOutputOptions combines the output configuration with the requested page
+func searchOutputOptions() output.Options {
+	settings := currentConfig().Output
+	return output.Options{
+		ShowStars:       settings.ShowStars,
+		ShowRepository:  settings.ShowRepository,
+		ShowLineNumbers: settings.ShowLineNumbers,
+		ShowPatterns:    settings.ShowPatterns,
+		MaxContentLines: settings.MaxContentLines,
+		Page:            searchPage,
+		Limit:           searchLimit,
 	}
+}
 
-	for i, item := range results.Items {
-		if i >= searchLimit {
-			break
-		}
-
-		// Repository header with stars
-		repoName := ""
-		repoURL := ""
-		stars := 0
-		if item.Repository.FullName != nil {
-			repoName = *item.Repository.FullName
-		}
-		if item.Repository.HTMLURL != nil {
-			repoURL = *item.Repository.HTMLURL
-		}
-		if item.Repository.StargazersCount != nil {
-			stars = *item.Repository.StargazersCount
-		}
-
-		output.WriteString(fmt.Sprintf("📁 [%s](%s) ⭐ %d\n", repoName, repoURL, stars))
+// patternSettings returns the configured pattern analysis settings and
+// whether pattern reports are enabled
+func patternSettings() (analysis.Settings, bool) {
+	cfg := currentConfig()
+	settings := analysis.Settings{
+		MinPatternCount:  cfg.Analysis.MinPatternCount,
+		PatternThreshold: cfg.Analysis.PatternThreshold,
+		Filter:           resultFilter(),
+	}
+	return settings, cfg.Analysis.EnablePatterns && cfg.Output.ShowPatterns
+}
 
-		// File path
-		if item.Path != nil {
-			output.WriteString(fmt.Sprintf("📄 **%s**\n\n", *item.Path))
-		}
+// formatPatternReport renders recurring patterns for the human-readable formats.
+// Machine-readable formats are left untouched.
+func formatPatternReport(results *github.SearchResults, format string) string {
+	settings, enabled := patternSettings()
+	if !enabled || (format != output.FormatDefault && format != output.FormatMarkdown && format != "") {
+		return ""
+	}
 
-		// Code content with basic formatting
-		if len(item.TextMatches) > 0 {
-			for _, match := range item.TextMatches {
-				if match.Fragment != nil {
-					lang := detectLanguage(*item.Path)
-					output.WriteString(fmt.Sprintf("```%s\n%s\n```\n", lang, *match.Fragment))
-				}
-			}
-		}
+	report := analysis.Analyze(results, settings)
+	if report.Empty() {
+		return ""
+	}
+	if format == output.FormatMarkdown {
+		return "\n## 📊 Common Patterns\n\n" + analysis.FormatMarkdown(report)
+	}
+	return "\n" + analysis.FormatText(report)
+}
 
-		// Link to file
-		if item.HTMLURL != nil {
-			output.WriteString(fmt.Sprintf("🔗 [View on GitHub](%s)\n\n", *item.HTMLURL))
-		}
+// detectLanguage detects programming language from file path using constants map
+func detectLanguage(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if lang, ok := LanguageExtensionMap[ext]; ok {
+		return lang
+	}
 
-		output.WriteString("---\n")
+	// Special case for Dockerfile (no extension)
+	if strings.Contains(strings.ToLower(filepath.Base(path)), "dockerfile") {
+		return LanguageDockerfile
 	}
 
-	return output.String(), nil
+	return ""
 }
 
 // writeToFile writes content to a file
@@ -526,9 +675,42 @@ func writeToFile(content, filename string) error {
 	return nil
 }
 
-// createGitHubClient creates a new GitHub API client
-func createGitHubClient() (github.GitHubAPI, error) {
-	return github.NewRealClient()
+// createGitHubClient creates a new GitHub API client for host, where an
+// empty host uses gh's default host
+func createGitHubClient(host string) (github.GitHubAPI, error) {
+	client, err := github.NewRealClientForHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Persist repository metadata alongside cached search results
+	if cacheEnabled() {
+		github.SharedRepoMetadataCache().SetStore(newResultCache(currentConfig()), refreshCache)
+	}
+
+	return withResultCache(client, client.Host()), nil
+}
+
+// enrichmentStats returns cumulative enrichment stats for clients that report them
+func enrichmentStats(client github.GitHubAPI) github.EnrichmentStats {
+	if reporter, ok := client.(github.EnrichmentReporter); ok {
+		return reporter.EnrichmentStats()
+	}
+	return github.EnrichmentStats{}
+}
+
+// printEnrichmentUsage reports repository metadata enrichment cost for verbose output
+func printEnrichmentUsage(client github.GitHubAPI) {
+	stats := enrichmentStats(client)
+	if stats.Repositories == 0 {
+		return
+	}
+	fmt.Printf("Enrichment: %d repositories, %d API requests, %d cached, %d coalesced (%s)\n",
+		stats.Repositories, stats.APIRequests, stats.CacheHits, stats.Coalesced,
+		stats.Duration.Round(time.Millisecond))
+	if stats.Fallbacks > 0 {
+		fmt.Printf("Enrichment: %d repositories fetched over REST after GraphQL failed\n", stats.Fallbacks)
+	}
 }
 
 func init() {
@@ -546,27 +728,37 @@ func init() {
 
 	// Quality & ranking flags (enhanced from ghx)
 	searchCmd.Flags().IntVar(&minStars, "min-stars", 0, "minimum repository stars")
-	searchCmd.Flags().StringVar(&sort, "sort", "relevance", "sort by: relevance, stars, updated, created")
+	searchCmd.Flags().StringVar(&sort, "sort", "relevance", "sort by: relevance, indexed, stars, forks, recent, created, quality (all but relevance and indexed reorder fetched results)")
 	searchCmd.Flags().StringVar(&order, "order", "desc", "sort order: asc, desc")
 	searchCmd.Flags().BoolVar(&liteMode, "lite", false, "lite mode: faster search, skips star counts (saves API quota)")
 
 	// Output control flags (migrated from ghx)
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "maximum results per page (default: 50, max: 100)")
 	searchCmd.Flags().IntVar(&searchPage, "page", 0, "specific page number (more API efficient than auto-pagination)")
-	searchCmd.Flags().IntVar(&contextLines, "context", 20, "context lines around matches (GitHub API controls actual fragment size)")
-	searchCmd.Flags().StringVar(&outputFormat, "format", "default", "output format: default, json, markdown, compact")
-	searchCmd.Flags().StringVar(&outputFile, "output", "", "export results to file (e.g., results.md, data.json)")
+	searchCmd.Flags().IntVar(&contextLines, "context", 20, "context lines around matches (requires --fetch-content; otherwise GitHub controls fragment size)")
+	searchCmd.Flags().BoolVar(&fetchContent, "fetch-content", false, "download matched files to show --context lines with line numbers (one API call per result)")
+	searchCmd.Flags().BoolVar(&consensusMode, "consensus", false, "parse matched JSON/JSONC/YAML/TOML config files and report the most common settings (one API call per result)")
+	searchCmd.Flags().StringVar(&dedupeMode, "dedupe", "", "collapse copies of the same file into the most starred repository: sha, content, repo (default sha when set without a value)")
+	searchCmd.Flags().Lookup("dedupe").NoOptDefVal = dedupe.ModeSHA
+	searchCmd.Flags().StringVar(&outputFormat, "format", "default", "output format: default, json, markdown, compact, csv, tsv, ndjson (streams one result per line), html (self-contained report), sarif (code scanning log)")
+	searchCmd.Flags().StringSliceVar(&searchColumns, "columns", nil, "csv/tsv columns: repo, path, url, stars, forks, language, pushed_at, score, sha, fragment (default repo,path,url,stars,language)")
+	searchCmd.Flags().StringVar(&outputFile, "output", "", "export results to file (e.g., results.md, data.json, results.csv)")
 	searchCmd.Flags().BoolVarP(&pipe, "pipe", "", false, "output to stdout (for piping to other tools)")
+	addExportFlags(searchCmd)
 
 	// Batch search flags (Phase 2)
 	searchCmd.Flags().StringSliceVar(&batchRepos, "repos", nil, "search across multiple repositories (comma-separated)")
 	searchCmd.Flags().StringSliceVar(&batchOrgs, "orgs", nil, "search across multiple organizations (comma-separated)")
 	searchCmd.Flags().BoolVar(&aggregateMode, "aggregate", false, "aggregate results from multiple repositories")
 	searchCmd.Flags().BoolVar(&compareMode, "compare", false, "enable comparison mode for multi-repository results")
+	searchCmd.Flags().StringSliceVar(&orgTopics, "org-topic", nil, "with --orgs, only search repositories with these topics")
+	searchCmd.Flags().StringVar(&orgLanguage, "org-language", "", "with --orgs, only search repositories with this primary language")
+	searchCmd.Flags().IntVar(&orgMinStars, "org-min-stars", 0, "with --orgs, only search repositories with at least this many stars")
+	searchCmd.Flags().BoolVar(&orgIncludeArchived, "org-include-archived", false, "with --orgs, include archived repositories")
+	searchCmd.Flags().BoolVar(&orgIncludeForks, "org-include-forks", false, "with --orgs, include forked repositories")
 
-	// Workflow integration flags (new)
-	// TODO: Implement saved searches functionality
-	// searchCmd.Flags().StringVar(&saveAs, "save", "", "save search with given name")
+	// Workflow integration flags
+	searchCmd.Flags().StringVar(&saveAs, "save", "", "save search with given name (run later with: gh scout saved run <name>)")
 
 	// Set flag usage examples
 	_ = searchCmd.Flags().SetAnnotation("language", "examples", []string{"typescript", "go", "python", "javascript"})
@@ -574,150 +766,25 @@ func init() {
 	_ = searchCmd.Flags().SetAnnotation("filename", "examples", []string{"package.json", "tsconfig.json", "Dockerfile"})
 	_ = searchCmd.Flags().SetAnnotation("extension", "examples", []string{"ts", "go", "py", "js"})
 	_ = searchCmd.Flags().SetAnnotation("size", "examples", []string{">1000", "<500", "100..200"})
-	_ = searchCmd.Flags().SetAnnotation("repos", "examples", []string{"microsoft/vscode,facebook/react", "vercel/*,netlify/*"})
+	_ = searchCmd.Flags().SetAnnotation("repos", "examples", []string{"microsoft/vscode,facebook/react", "vercel/next.js,netlify/cli"})
 	_ = searchCmd.Flags().SetAnnotation("orgs", "examples", []string{"microsoft,google,facebook", "vercel,netlify"})
 }
 
-// formatJSONResults formats search results as JSON with pagination metadata
-func formatJSONResults(results *github.SearchResults) (string, error) {
-	// Create enhanced response with pagination info
-	enhancedResults := struct {
-		*github.SearchResults
-		Pagination *PaginationInfo `json:"pagination,omitempty"`
-	}{
-		SearchResults: results,
-	}
-
-	// Add pagination metadata if available
-	if results.Total != nil {
-		totalResults := *results.Total
-		displayedCount := len(results.Items)
-
-		var startResult, endResult int
-		if searchPage > 0 {
-			startResult = ((searchPage - 1) * searchLimit) + 1
-			endResult = startResult + displayedCount - 1
-		} else {
-			startResult = 1
-			endResult = displayedCount
-		}
-
-		enhancedResults.Pagination = &PaginationInfo{
-			TotalResults:     totalResults,
-			DisplayedResults: displayedCount,
-			StartResult:      startResult,
-			EndResult:        endResult,
-			CurrentPage:      max(1, searchPage),
-			PerPage:          searchLimit,
-			HasNextPage:      totalResults > endResult,
-		}
-
-		if enhancedResults.Pagination.HasNextPage {
-			enhancedResults.Pagination.NextPage = enhancedResults.Pagination.CurrentPage + 1
-		}
-	}
-
-	data, err := json.MarshalIndent(enhancedResults, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-	return string(data), nil
-}
-
-// formatMarkdownResults formats search results as Markdown
-func formatMarkdownResults(results *github.SearchResults) (string, error) {
-	var builder strings.Builder
-
-	if results.Total != nil {
-		builder.WriteString(fmt.Sprintf("# Search Results (%d total)\n\n", *results.Total))
-	} else {
-		builder.WriteString("# Search Results\n\n")
-	}
-
-	for i, item := range results.Items {
-		path := ""
-		if item.Path != nil {
-			path = *item.Path
-		}
-		fullName := ""
-		if item.Repository.FullName != nil {
-			fullName = *item.Repository.FullName
-		}
-		htmlURL := ""
-		if item.HTMLURL != nil {
-			htmlURL = *item.HTMLURL
-		}
-
-		builder.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, path))
-		builder.WriteString(fmt.Sprintf("**Repository:** %s\n", fullName))
-		if item.Repository.Description != nil && *item.Repository.Description != "" {
-			builder.WriteString(fmt.Sprintf("**Description:** %s\n", *item.Repository.Description))
-		}
-		builder.WriteString(fmt.Sprintf("**URL:** %s\n\n", htmlURL))
-
-		if len(item.TextMatches) > 0 {
-			builder.WriteString("**Code:**\n\n```")
-			if item.Repository.Language != nil && *item.Repository.Language != "" {
-				builder.WriteString(strings.ToLower(*item.Repository.Language))
-			}
-			builder.WriteString("\n")
-			for _, match := range item.TextMatches {
-				if match.Fragment != nil {
-					builder.WriteString(*match.Fragment + "\n")
-				}
-			}
-			builder.WriteString("```\n\n")
-		}
-		builder.WriteString("---\n\n")
-	}
-
-	return builder.String(), nil
-}
-
-// formatCompactResults formats search results in compact format
-func formatCompactResults(results *github.SearchResults) (string, error) {
-	var builder strings.Builder
-
-	// Add pagination info for compact format too
-	if results.Total != nil {
-		totalResults := *results.Total
-		displayedCount := len(results.Items)
-
-		if searchPage > 0 {
-			startResult := ((searchPage - 1) * searchLimit) + 1
-			endResult := startResult + displayedCount - 1
-			if totalResults > displayedCount {
-				builder.WriteString(fmt.Sprintf("# Results %d-%d of %d total\n",
-					startResult, endResult, totalResults))
-			}
-		} else if totalResults > displayedCount {
-			builder.WriteString(fmt.Sprintf("# Results 1-%d of %d total\n",
-				displayedCount, totalResults))
-		}
-	}
-
-	for _, item := range results.Items {
-		fullName := ""
-		if item.Repository.FullName != nil {
-			fullName = *item.Repository.FullName
-		}
-		path := ""
-		if item.Path != nil {
-			path = *item.Path
-		}
-		builder.WriteString(fmt.Sprintf("%s:%s\n", fullName, path))
-	}
-
-	return builder.String(), nil
-}
-
 // isTestEnvironment checks if we're running in test mode
 func isTestEnvironment() bool {
 	// Check if running under go test
 	return os.Getenv("GO_TEST") == "1" || strings.HasSuffix(os.Args[0], ".test")
 }
 
-// executeBatchRepoSearch handles multi-repository search with aggregation (Phase 2)
+// batchTarget is a repository pattern or organization searched via --repos/--orgs
+type batchTarget struct {
+	Name    string   // repository pattern or organization login
+	IsOrg   bool     // true when Repos were expanded from an organization
+	Repos   []string // repositories searched for this target
+	Results *github.SearchResults
+}
+
+// executeBatchRepoSearch searches multiple repositories and organizations
 func executeBatchRepoSearch(ctx context.Context, args []string) error {
 	// Add timeout for batch operations (longer due to multiple searches)
 	if ctx == nil {
@@ -742,141 +809,214 @@ func executeBatchRepoSearch(ctx context.Context, args []string) error {
 		return nil
 	}
 
-	// Collect all target repositories
-	var targetRepos []string
-
-	// Add explicit repos
-	targetRepos = append(targetRepos, batchRepos...)
-
-	// Add repos from organizations (simplified - in real implementation would use GitHub API)
-	for _, org := range batchOrgs {
-		if strings.Contains(org, "*") {
-			// Handle wildcard patterns
-			targetRepos = append(targetRepos, org+"/*")
-		} else {
-			// For now, treat as repo pattern
-			targetRepos = append(targetRepos, org+"/*")
-		}
+	targets := resolveBatchTargets(ctx)
+	if err := stoppedEarly(ctx); err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no repositories to search: organizations could not be listed or no repositories matched the --org-* filters")
 	}
 
+	// Repository qualifiers are added per chunk, so build the base query without them
+	filters := currentSearchFilters()
+	filters.Repository = nil
+	baseQuery := search.NewQueryBuilderFromFilters([]string{query}, filters).Build()
+
 	if verbose {
-		fmt.Printf("Executing batch search across %d repository patterns\n", len(targetRepos))
+		fmt.Printf("Executing batch search across %d targets\n", len(targets))
 		fmt.Printf("Query: %s\n", query)
 	}
 
-	// Execute searches across all repositories
+	// Execute searches across all targets
 	var allResults *github.SearchResults
 	totalResults := 0
 
-	for i, repoPattern := range targetRepos {
-		if verbose {
-			fmt.Printf("Searching %d/%d: %s\n", i+1, len(targetRepos), repoPattern)
-		}
-
-		// Build query with repository filter (commented out - using QueryBuilder instead)
-
-		// Apply other filters
-		qb := search.NewQueryBuilder([]string{query})
-		if searchLanguage != "" {
-			qb = qb.WithLanguage(searchLanguage)
-		}
-		if searchFilename != "" {
-			qb = qb.WithFilename(searchFilename)
-		}
-		if searchExtension != "" {
-			qb = qb.WithExtension(searchExtension)
-		}
-		if searchPath != "" {
-			qb = qb.WithPath(searchPath)
-		}
-		if searchSize != "" {
-			qb = qb.WithSize(searchSize)
+	for i := range targets {
+		target := &targets[i]
+		if ctx.Err() != nil {
+			break
 		}
-		if minStars > 0 {
-			qb = qb.WithMinStars(minStars)
+		if i > 0 {
+			if err := delayNextPage(ctx, i+1); err != nil {
+				break
+			}
 		}
-
-		// Add repository filter
-		qb = qb.WithRepositories([]string{repoPattern})
-		finalQuery := qb.Build()
-
-		// Execute single repository search
-		opts := &github.SearchOptions{
-			Sort:  sort,
-			Order: order,
-			ListOptions: github.ListOptions{
-				Page:    1,
-				PerPage: searchLimit,
-			},
-			SkipEnrichment: liteMode,
+		if verbose {
+			fmt.Printf("Searching %d/%d: %s (%d repositories)\n", i+1, len(targets), target.Name, len(target.Repos))
 		}
 
-		results, err := searchClient.SearchCode(ctx, finalQuery, opts)
-		if err != nil {
-			if verbose {
-				fmt.Printf("  Warning: Search failed for %s: %v\n", repoPattern, err)
-			}
+		target.Results = searchBatchTarget(ctx, baseQuery, target)
+		if target.Results == nil {
 			continue
 		}
 
 		if verbose {
-			fmt.Printf("  Found %d results\n", len(results.Items))
+			fmt.Printf("  Found %d results\n", len(target.Results.Items))
 		}
 
 		// Aggregate results
 		if allResults == nil {
-			allResults = results
-		} else {
-			allResults.Items = append(allResults.Items, results.Items...)
-			if results.Total != nil {
-				if allResults.Total == nil {
-					allResults.Total = github.IntPtr(0)
-				}
-				*allResults.Total += *results.Total
-			}
+			allResults = &github.SearchResults{Total: github.IntPtr(0)}
+		}
+		allResults.Items = append(allResults.Items, target.Results.Items...)
+		if target.Results.Total != nil {
+			*allResults.Total += *target.Results.Total
 		}
 
-		totalResults += len(results.Items)
+		totalResults += len(target.Results.Items)
 	}
 
 	if allResults == nil {
+		if err := stoppedEarly(ctx); err != nil {
+			return err
+		}
 		fmt.Println("No results found across any repositories.")
 		return nil
 	}
+	if ctx.Err() != nil {
+		warnPartialResults(ctx, totalResults)
+	}
 
 	if verbose {
-		fmt.Printf("Batch search completed: %d total results from %d repositories\n", totalResults, len(targetRepos))
+		fmt.Printf("Batch search completed: %d total results from %d targets\n", totalResults, len(targets))
 	}
 
-	// Handle comparison mode
+	// Reorder by --sort modes GitHub cannot sort by, across and within targets
+	rankResults(allResults)
 	if compareMode {
-		return outputBatchComparison(allResults, targetRepos)
+		rankBatchTargets(targets)
 	}
 
-	// Handle aggregate mode or default output
-	return outputResults(allResults)
+	// Handle comparison mode, or aggregate mode and default output
+	var err error
+	if compareMode {
+		err = outputBatchComparison(allResults, targets)
+	} else {
+		err = outputResults(allResults, baseQuery)
+	}
+	if err != nil {
+		return err
+	}
+	return stoppedEarly(ctx)
+}
+
+// resolveBatchTargets expands --repos and --orgs into searchable targets.
+// Organizations are enumerated through the API and filtered by the --org-* flags.
+func resolveBatchTargets(ctx context.Context) []batchTarget {
+	var targets []batchTarget
+
+	for _, repo := range batchRepos {
+		targets = append(targets, batchTarget{Name: repo, Repos: []string{repo}})
+	}
+
+	filter := github.RepoFilter{
+		Topics:          orgTopics,
+		Language:        orgLanguage,
+		MinStars:        orgMinStars,
+		IncludeArchived: orgIncludeArchived,
+		IncludeForks:    orgIncludeForks,
+	}
+
+	for _, org := range batchOrgs {
+		repos, err := searchClient.ListOrgRepositories(ctx, org)
+		if err != nil {
+			fmt.Printf("⚠️  Could not list repositories for %s: %v\n", org, err)
+			continue
+		}
+
+		filtered := github.FilterRepositories(repos, filter)
+		if verbose {
+			fmt.Printf("Organization %s: %d of %d repositories match filters\n", org, len(filtered), len(repos))
+		}
+		if len(filtered) == 0 {
+			continue
+		}
+
+		target := batchTarget{Name: org, IsOrg: true}
+		for _, repo := range filtered {
+			if repo.FullName != nil {
+				target.Repos = append(target.Repos, *repo.FullName)
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// searchBatchTarget searches every repository of a target, splitting them into
+// repo: groups that fit GitHub's query length limit, and merges the results.
+// It returns nil if every search failed.
+func searchBatchTarget(ctx context.Context, baseQuery string, target *batchTarget) *github.SearchResults {
+	perPage := min(searchLimit, GitHubMaxResultsPerPage)
+
+	var merged *github.SearchResults
+	chunks := search.ChunkRepositories(baseQuery, target.Repos, search.MaxQueryLength)
+
+	for i, chunk := range chunks {
+		// Stop once the target has enough results for the per-search limit
+		if merged != nil && len(merged.Items) >= searchLimit {
+			break
+		}
+		// Pace chunks like pages, large organizations need many queries
+		if i > 0 {
+			if err := delayNextPage(ctx, i+1); err != nil {
+				break
+			}
+		}
+
+		finalQuery := search.NewQueryBuilder([]string{baseQuery}).WithRepositories(chunk).Build()
+
+		results, err := fetchSearchPage(ctx, finalQuery, 1, perPage)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			if verbose {
+				fmt.Printf("  Warning: Search failed for %s: %v\n", target.Name, err)
+			}
+			continue
+		}
+
+		if merged == nil {
+			merged = &github.SearchResults{Total: github.IntPtr(0)}
+		}
+		merged.Items = append(merged.Items, results.Items...)
+		if results.Total != nil {
+			*merged.Total += *results.Total
+		}
+	}
+
+	// Keep each organization within the per-search limit
+	if merged != nil && len(merged.Items) > searchLimit {
+		merged.Items = merged.Items[:searchLimit]
+	}
+
+	return merged
 }
 
 // outputBatchComparison outputs results in comparison format
-func outputBatchComparison(results *github.SearchResults, repos []string) error {
+func outputBatchComparison(results *github.SearchResults, targets []batchTarget) error {
 	fmt.Printf("# Multi-Repository Search Comparison\n\n")
-	fmt.Printf("**Query executed across %d repository patterns**\n", len(repos))
+	fmt.Printf("**Query executed across %d targets**\n", len(targets))
 	fmt.Printf("**Total results found: %d**\n\n", len(results.Items))
 
-	// Group results by repository
-	repoResults := make(map[string][]github.SearchItem)
-	for _, item := range results.Items {
-		if item.Repository.FullName != nil {
-			repoName := *item.Repository.FullName
-			repoResults[repoName] = append(repoResults[repoName], item)
+	fmt.Printf("## Results by Target\n\n")
+	targetsWithResults := 0
+	for _, target := range targets {
+		if target.Results == nil || len(target.Results.Items) == 0 {
+			continue
 		}
-	}
+		targetsWithResults++
+		items := target.Results.Items
 
-	fmt.Printf("## Results by Repository\n\n")
-	for repo, items := range repoResults {
-		fmt.Printf("### %s (%d results)\n", repo, len(items))
+		if target.IsOrg {
+			fmt.Printf("### %s (%d results across %d repositories)\n", target.Name, len(items), len(target.Repos))
+		} else {
+			fmt.Printf("### %s (%d results)\n", target.Name, len(items))
+		}
 
-		// Show top 3 results per repository
+		// Show top 3 results per target
 		maxShow := 3
 		if len(items) < maxShow {
 			maxShow = len(items)
@@ -885,7 +1025,11 @@ func outputBatchComparison(results *github.SearchResults, repos []string) error
 		for i := 0; i < maxShow; i++ {
 			item := items[i]
 			if item.Path != nil && item.HTMLURL != nil {
-				fmt.Printf("- **%s** - [View](%s)\n", *item.Path, *item.HTMLURL)
+				repoName := ""
+				if target.IsOrg && item.Repository.FullName != nil {
+					repoName = *item.Repository.FullName + ": "
+				}
+				fmt.Printf("- %s**%s** - [View](%s)\n", repoName, *item.Path, *item.HTMLURL)
 			}
 		}
 
@@ -897,8 +1041,8 @@ func outputBatchComparison(results *github.SearchResults, repos []string) error
 
 	// Simple pattern analysis
 	fmt.Printf("## Analysis\n")
-	fmt.Printf("- **Repositories with results**: %d\n", len(repoResults))
-	fmt.Printf("- **Average results per repository**: %.1f\n", float64(len(results.Items))/float64(len(repoResults)))
+	fmt.Printf("- **Targets with results**: %d\n", targetsWithResults)
+	fmt.Printf("- **Average results per target**: %.1f\n", float64(len(results.Items))/float64(max(targetsWithResults, 1)))
 
 	return nil
 }
@@ -918,15 +1062,3 @@ func max(a, b int) int {
 	}
 	return b
 }
-
-// PaginationInfo contains metadata about pagination
-type PaginationInfo struct {
-	TotalResults     int  `json:"total_results"`
-	DisplayedResults int  `json:"displayed_results"`
-	StartResult      int  `json:"start_result"`
-	EndResult        int  `json:"end_result"`
-	CurrentPage      int  `json:"current_page"`
-	PerPage          int  `json:"per_page"`
-	HasNextPage      bool `json:"has_next_page"`
-	NextPage         int  `json:"next_page,omitempty"`
-}
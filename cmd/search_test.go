@@ -3,13 +3,20 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/silouanwright/gh-scout/internal/config"
 	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/output"
+	"github.com/silouanwright/gh-scout/internal/search"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -426,7 +433,7 @@ func TestOutputFormats(t *testing.T) {
 			}
 
 			output := captureOutput(func() error {
-				return outputResults(mockResults)
+				return outputResults(mockResults, "useState")
 			})
 
 			assert.NoError(t, output.err)
@@ -435,6 +442,192 @@ func TestOutputFormats(t *testing.T) {
 	}
 }
 
+// TestOutputResultsAppliesOutputSettings tests that config output settings reach the formatters
+func TestOutputResultsAppliesOutputSettings(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+
+	cfg := config.Default()
+	cfg.Output.ShowStars = false
+	cfg.Output.ShowLineNumbers = true
+	appConfig = cfg
+
+	results := github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "src/ReactHooks.ts", "function useState()"),
+	)
+
+	for _, format := range []string{"default", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			outputFormat = format
+			output := captureOutput(func() error {
+				return outputResults(results, "useState")
+			})
+
+			require.NoError(t, output.err)
+			assert.Contains(t, output.stdout, "facebook/react")
+			assert.Contains(t, output.stdout, "  1: function useState()")
+			assert.NotContains(t, output.stdout, "⭐")
+		})
+	}
+
+	outputFormat = "xml"
+	output := captureOutput(func() error {
+		return outputResults(results, "useState")
+	})
+	assert.ErrorContains(t, output.err, "unsupported format: xml")
+}
+
+// TestOutputResultsTable tests csv and tsv output with selected columns
+func TestOutputResultsTable(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	results := github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "src/ReactHooks.ts", "function useState(a, b)"),
+	)
+
+	outputFormat = "csv"
+	searchColumns = []string{"repo,path", "fragment"}
+	out := captureOutput(func() error {
+		return outputResults(results, "useState")
+	})
+	require.NoError(t, out.err)
+	assert.Equal(t, "repo,path,fragment\nfacebook/react,src/ReactHooks.ts,\"function useState(a, b)\"\n", out.stdout)
+	assert.NotContains(t, out.stdout, "Common patterns", "tables stay machine-readable")
+
+	// --output picks tsv up from the file extension
+	outputFormat = "default"
+	searchColumns = []string{"repo"}
+	outputFile = filepath.Join(t.TempDir(), "results.tsv")
+	out = captureOutput(func() error {
+		return outputResults(results, "useState")
+	})
+	require.NoError(t, out.err)
+	written, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "repo\nfacebook/react\n", string(written))
+}
+
+func TestOutputResultsHTML(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	results := github.CreateTestSearchResults(1,
+		github.CreateTestSearchItem("facebook/react", "src/ReactHooks.ts", "function useState(a, b)"),
+	)
+
+	// --output picks html up from the file extension
+	outputFile = filepath.Join(t.TempDir(), "report.html")
+	out := captureOutput(func() error {
+		return outputResults(results, "useState")
+	})
+	require.NoError(t, out.err)
+	written, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	report := string(written)
+	assert.True(t, strings.HasPrefix(report, "<!DOCTYPE html>"))
+	assert.Contains(t, report, "<title>Search results: useState</title>")
+	assert.Contains(t, report, "facebook/react</a></h3>")
+	assert.Contains(t, report, `<span class="kd">function</span>`)
+	assert.NotContains(t, report, "Common Patterns", "the report has no text pattern summary")
+}
+
+// TestValidateSearchFlags_Columns tests that --columns needs a table format
+func TestValidateSearchFlags_Columns(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	searchColumns = []string{"repo", "owner"}
+	outputFormat = "csv"
+	assert.ErrorContains(t, validateSearchFlags(), "unknown column: owner")
+
+	searchColumns = []string{"repo", "stars"}
+	assert.NoError(t, validateSearchFlags())
+
+	outputFormat = "json"
+	assert.EqualError(t, validateSearchFlags(), "--columns only applies to --format csv or tsv")
+
+	outputFormat = "default"
+	outputFile = "audit.csv"
+	assert.NoError(t, validateSearchFlags())
+}
+
+// TestOutputResultsPatternReport tests the pattern report printed after human-readable results
+func TestOutputResultsPatternReport(t *testing.T) {
+	results := github.CreateTestSearchResults(3,
+		github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", `"strict": true,`),
+		github.CreateTestSearchItem("facebook/react", "tsconfig.json", `"strict": true,`),
+		github.CreateTestSearchItem("facebook/react", "src/__tests__/tsconfig.json", `"strict": true,`),
+	)
+
+	tests := []struct {
+		name     string
+		format   string
+		disable  bool
+		expected []string
+		unwanted []string
+	}{
+		{
+			name:     "default format",
+			format:   "default",
+			expected: []string{"📊 Common patterns", "Patterns in at least 2 of 2 results (30% threshold), 1 excluded", "strict: true"},
+		},
+		{
+			name:     "markdown format",
+			format:   "markdown",
+			expected: []string{"## 📊 Common Patterns", "| value | `strict: true` | 2 | 100% |"},
+		},
+		{
+			name:     "compact format stays machine-readable",
+			format:   "compact",
+			unwanted: []string{"Common patterns"},
+		},
+		{
+			name:     "disabled by show_patterns",
+			format:   "default",
+			disable:  true,
+			unwanted: []string{"Common patterns"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+
+			originalConfig := appConfig
+			defer func() { appConfig = originalConfig }()
+			cfg := config.Default()
+			cfg.Output.ShowPatterns = !tt.disable
+			appConfig = cfg
+
+			outputFormat = tt.format
+			output := captureOutput(func() error {
+				return outputResults(results, "strict")
+			})
+
+			require.NoError(t, output.err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, output.stdout, expected)
+			}
+			for _, unwanted := range tt.unwanted {
+				assert.NotContains(t, output.stdout, unwanted)
+			}
+		})
+	}
+}
+
 // TestDetectLanguage tests language detection from file paths
 func TestDetectLanguage(t *testing.T) {
 	tests := []struct {
@@ -444,6 +637,10 @@ func TestDetectLanguage(t *testing.T) {
 		{"src/main.go", "go"},
 		{"components/Button.tsx", "typescript"},
 		{"utils/helper.js", "javascript"},
+		{"lib/index.mjs", "javascript"},
+		{"app/models/user.rb", "ruby"},
+		{"src/Main.JAVA", "java"},
+		{"Cargo.toml", "toml"},
 		{"config/settings.json", "json"},
 		{"docker/Dockerfile", "dockerfile"},
 		{"scripts/build.sh", ""},
@@ -507,6 +704,24 @@ func TestErrorHandling(t *testing.T) {
 	}
 }
 
+// TestErrorHandling_EnterpriseHost points authentication guidance at the searched host
+func TestErrorHandling_EnterpriseHost(t *testing.T) {
+	originalHostname := hostname
+	defer func() { hostname = originalHostname }()
+	hostname = "https://ghe.example.com/"
+
+	err := handleSearchError(&github.AuthenticationError{Message: "ghe.example.com: Bad credentials"}, "config")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ghe.example.com: Bad credentials")
+	assert.Contains(t, err.Error(), "gh auth login --hostname ghe.example.com")
+
+	err = handleClientError(errors.New("authentication token not found for host ghe.example.com"))
+	assert.Contains(t, err.Error(), "gh auth login --hostname ghe.example.com --web")
+
+	hostname = "github.com"
+	assert.Equal(t, "gh auth login", authLoginCommand())
+}
+
 // Helper functions for testing
 
 type capturedOutput struct {
@@ -562,12 +777,32 @@ func resetSearchFlags() {
 	searchOwner = nil
 	searchSize = ""
 	searchLimit = 50
+	searchPage = 0
 	contextLines = 20
 	outputFormat = "default"
+	outputFile = ""
+	searchColumns = nil
+	jsonFields = nil
+	jqExpr = ""
+	templateText = ""
 	pipe = false
 	minStars = 0
 	sort = "relevance"
 	order = "desc"
+	saveAs = ""
+	savedFilters = nil
+	fetchContent = false
+	liteMode = false
+	consensusMode = false
+	dedupeMode = ""
+	batchRepos = nil
+	batchOrgs = nil
+	compareMode = false
+	orgTopics = nil
+	orgLanguage = ""
+	orgMinStars = 0
+	orgIncludeArchived = false
+	orgIncludeForks = false
 
 	// Reset global flags
 	dryRun = false
@@ -1077,3 +1312,230 @@ func TestSearchLimitEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestOrgExpansion tests --orgs enumerating repositories through the API
+func TestOrgExpansion(t *testing.T) {
+	archived := github.CreateTestRepository("acme/legacy", 500)
+	archived.Archived = github.BoolPtr(true)
+	fork := github.CreateTestRepository("acme/forked", 500)
+	fork.Fork = github.BoolPtr(true)
+	tooling := github.CreateTestRepository("acme/tooling", 50)
+	tooling.Topics = []string{"cli"}
+	tooling.Language = github.StringPtr("Go")
+	web := github.CreateTestRepository("acme/web", 900)
+	web.Topics = []string{"frontend"}
+	web.Language = github.StringPtr("TypeScript")
+
+	orgRepos := []github.Repository{archived, fork, tooling, web}
+
+	tests := []struct {
+		name          string
+		setupFlags    func()
+		expectedQuery string
+	}{
+		{
+			name:          "archived and forked repositories excluded by default",
+			expectedQuery: "config repo:acme/tooling repo:acme/web",
+		},
+		{
+			name:          "include archived and forks",
+			setupFlags:    func() { orgIncludeArchived = true; orgIncludeForks = true },
+			expectedQuery: "config repo:acme/legacy repo:acme/forked repo:acme/tooling repo:acme/web",
+		},
+		{
+			name:          "topic filter",
+			setupFlags:    func() { orgTopics = []string{"CLI"} },
+			expectedQuery: "config repo:acme/tooling",
+		},
+		{
+			name:          "language and stars filters",
+			setupFlags:    func() { orgLanguage = "typescript"; orgMinStars = 100 },
+			expectedQuery: "config repo:acme/web",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			mockClient := github.NewMockClient()
+			mockClient.SetOrgRepositories("acme", orgRepos)
+			mockClient.SetSearchResults(tt.expectedQuery, github.CreateTestSearchResults(1,
+				github.CreateTestSearchItem("acme/web", "config.json", "{}"),
+			))
+
+			originalClient := searchClient
+			searchClient = mockClient
+			defer func() {
+				searchClient = originalClient
+				resetSearchFlags()
+			}()
+
+			batchOrgs = []string{"acme"}
+			if tt.setupFlags != nil {
+				tt.setupFlags()
+			}
+
+			output := captureOutput(func() error {
+				return executeBatchRepoSearch(context.Background(), []string{"config"})
+			})
+
+			require.NoError(t, output.err)
+			assert.True(t, mockClient.VerifyCall("ListOrgRepositories", "acme"))
+			assert.True(t, mockClient.VerifyCall("SearchCode", tt.expectedQuery), "expected query %q", tt.expectedQuery)
+			assert.Contains(t, output.stdout, "acme/web")
+		})
+	}
+}
+
+// TestOrgExpansionChunking tests splitting large organizations across queries
+func TestOrgExpansionChunking(t *testing.T) {
+	resetSearchFlags()
+	mockClient := github.NewMockClient()
+
+	var repos []github.Repository
+	for i := 0; i < 40; i++ {
+		repos = append(repos, github.CreateTestRepository(fmt.Sprintf("acme/service-%02d", i), 10))
+	}
+	mockClient.SetOrgRepositories("acme", repos)
+
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() {
+		searchClient = originalClient
+		resetSearchFlags()
+	}()
+
+	batchOrgs = []string{"acme", "missing"}
+	compareMode = true
+
+	output := captureOutput(func() error {
+		return executeBatchRepoSearch(context.Background(), []string{"config"})
+	})
+	require.NoError(t, output.err)
+
+	searchCalls := 0
+	searched := make(map[string]bool)
+	for _, call := range mockClient.GetCallLog() {
+		if call.Method != "SearchCode" {
+			continue
+		}
+		searchCalls++
+		query := call.Args[0].(string)
+		assert.LessOrEqual(t, len(query), search.MaxQueryLength)
+		for _, field := range strings.Fields(query) {
+			if strings.HasPrefix(field, "repo:") {
+				searched[strings.TrimPrefix(field, "repo:")] = true
+			}
+		}
+	}
+
+	assert.Greater(t, searchCalls, 1, "40 repositories should not fit in a single query")
+	assert.Len(t, searched, 40, "every repository should be searched exactly once")
+	assert.Contains(t, output.stdout, "Could not list repositories for missing")
+}
+
+// TestOrgExpansionStopsAtLimit tests that chunks stop once the limit is reached
+func TestOrgExpansionStopsAtLimit(t *testing.T) {
+	resetSearchFlags()
+	mockClient := github.NewMockClient()
+
+	var repos []github.Repository
+	var names []string
+	for i := 0; i < 40; i++ {
+		name := fmt.Sprintf("acme/service-%02d", i)
+		repos = append(repos, github.CreateTestRepository(name, 10))
+		names = append(names, name)
+	}
+	mockClient.SetOrgRepositories("acme", repos)
+
+	chunks := search.ChunkRepositories("config", names, search.MaxQueryLength)
+	require.Greater(t, len(chunks), 1)
+	firstQuery := search.NewQueryBuilder([]string{"config"}).WithRepositories(chunks[0]).Build()
+	mockClient.SetSearchResults(firstQuery, github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("acme/service-00", "config.json", "{}"),
+		github.CreateTestSearchItem("acme/service-01", "config.json", "{}"),
+	))
+
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() {
+		searchClient = originalClient
+		resetSearchFlags()
+	}()
+
+	batchOrgs = []string{"acme"}
+	searchLimit = 2
+
+	output := captureOutput(func() error {
+		return executeBatchRepoSearch(context.Background(), []string{"config"})
+	})
+	require.NoError(t, output.err)
+	assert.Equal(t, 1, mockClient.GetCallCount("SearchCode"), "remaining chunks are skipped once the limit is reached")
+	assert.Contains(t, output.stdout, "acme/service-01")
+}
+
+// TestOrgExpansionNoMatches tests the error when filters exclude everything
+func TestOrgExpansionNoMatches(t *testing.T) {
+	resetSearchFlags()
+	mockClient := github.NewMockClient()
+	mockClient.SetOrgRepositories("acme", []github.Repository{github.CreateTestRepository("acme/web", 10)})
+
+	originalClient := searchClient
+	searchClient = mockClient
+	defer func() {
+		searchClient = originalClient
+		resetSearchFlags()
+	}()
+
+	batchOrgs = []string{"acme"}
+	orgMinStars = 1000
+
+	output := captureOutput(func() error {
+		return executeBatchRepoSearch(context.Background(), []string{"config"})
+	})
+
+	require.Error(t, output.err)
+	assert.Contains(t, output.err.Error(), "no repositories to search")
+	assert.Equal(t, 0, mockClient.GetCallCount("SearchCode"))
+}
+
+func TestOutputResultsSARIF(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	appConfig = config.Default()
+
+	t.Run("format from extension", func(t *testing.T) {
+		results := github.CreateTestSearchResults(1,
+			github.CreateTestSearchItem("facebook/react", "src/ReactHooks.ts", "function useState(a, b)"),
+		)
+		outputFile = filepath.Join(t.TempDir(), "findings.sarif")
+		defer func() { outputFile = "" }()
+
+		out := captureOutput(func() error {
+			return outputResults(results, "useState")
+		})
+		require.NoError(t, out.err)
+		written, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+
+		var log map[string]interface{}
+		require.NoError(t, json.Unmarshal(written, &log))
+		assert.Equal(t, "2.1.0", log["version"])
+		assert.NotContains(t, string(written), "Common Patterns")
+	})
+
+	t.Run("no results is an empty run", func(t *testing.T) {
+		outputFormat = output.FormatSARIF
+		defer func() { outputFormat = output.FormatDefault }()
+
+		out := captureOutput(func() error {
+			return outputResults(&github.SearchResults{Total: github.IntPtr(0)}, "nothing")
+		})
+		require.NoError(t, out.err)
+		assert.NotContains(t, out.stdout, "No results found.")
+		assert.Contains(t, out.stdout, `"results": []`)
+	})
+}
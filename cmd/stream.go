@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/silouanwright/gh-scout/internal/filter"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/output"
+	"github.com/silouanwright/gh-scout/internal/rank"
+)
+
+// streamingOutput reports whether results can be written page by page as
+// NDJSON. Client-side sorting and --dedupe need every result first, and
+// --output, --page and --consensus produce a single document anyway.
+func streamingOutput() bool {
+	return searchOutputFormat() == output.FormatNDJSON &&
+		!exportActive() &&
+		outputFile == "" &&
+		searchPage == 0 &&
+		!rank.ClientSide(sort) &&
+		dedupeMode == "" &&
+		!consensusMode
+}
+
+// progressf prints a progress message to stdout, or to stderr while NDJSON
+// is streamed to stdout so consumers only read records
+func progressf(format string, args ...interface{}) {
+	out := io.Writer(os.Stdout)
+	if streamingOutput() {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, format, args...)
+}
+
+// streamSearch fetches pages of query and writes each page's results to w
+// as NDJSON once the page and its enrichment complete, ending with a
+// summary record. Filtered results are replaced from following pages until
+// --limit results have been written or the search runs out of matches.
+func streamSearch(ctx context.Context, query string, w io.Writer) error {
+	writer := output.NewNDJSONWriter(w, searchOutputOptions())
+	f := resultFilter()
+
+	var first *github.SearchResults
+	perPage := min(searchLimit, GitHubMaxResultsPerPage)
+	remaining := searchLimit
+
+	for page := 1; remaining > 0 && (page-1)*perPage < GitHubMaxSearchResults; page++ {
+		if page > 1 {
+			if err := delayNextPage(ctx, page); err != nil {
+				break
+			}
+		}
+
+		results, err := fetchSearchPage(ctx, query, page, perPage)
+		if err != nil {
+			if first == nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("search %s before any results arrived: %w", stopReason(ctx), ctx.Err())
+				}
+				return handleSearchError(err, query)
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			return handleSearchError(err, query)
+		}
+		if first == nil {
+			first = results
+		}
+		exhausted := len(results.Items) < perPage
+
+		if f.Active() {
+			var dropped []filter.Dropped
+			results.Items, dropped = f.Apply(results.Items)
+			if verbose && len(dropped) > 0 {
+				progressf("🧹 Filtered out %d results on page %d\n", len(dropped), page)
+			}
+		}
+		if len(results.Items) > remaining {
+			results.Items = results.Items[:remaining]
+		}
+		if fetchContent {
+			fetchResultContents(ctx, results, query)
+		}
+
+		if err := writer.WriteItems(results.Items); err != nil {
+			return err
+		}
+		remaining -= len(results.Items)
+
+		if exhausted {
+			break
+		}
+	}
+
+	if first == nil {
+		first = &github.SearchResults{}
+	}
+	if err := writer.WriteSummary(first.Total, first.IncompleteResults); err != nil {
+		return err
+	}
+	return stoppedEarly(ctx)
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/config"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/silouanwright/gh-scout/internal/output"
+)
+
+// streamCheckingClient records how many lines had been written to out when
+// each page was requested
+type streamCheckingClient struct {
+	*github.MockClient
+	out          *bytes.Buffer
+	linesAtFetch []int
+}
+
+func (c *streamCheckingClient) SearchCode(ctx context.Context, query string, opts *github.SearchOptions) (*github.SearchResults, error) {
+	c.linesAtFetch = append(c.linesAtFetch, strings.Count(c.out.String(), "\n"))
+	return c.MockClient.SearchCode(ctx, query, opts)
+}
+
+// setupStreamTest installs client and the default config with ndjson output
+func setupStreamTest(t *testing.T, client github.GitHubAPI) {
+	t.Helper()
+	resetSearchFlags()
+	outputFormat = output.FormatNDJSON
+	originalClient, originalConfig := searchClient, appConfig
+	searchClient, appConfig = client, config.Default()
+	t.Cleanup(func() {
+		searchClient, appConfig = originalClient, originalConfig
+		resetSearchFlags()
+	})
+}
+
+// ndjsonLines splits NDJSON output, checking every line is valid JSON
+func ndjsonLines(t *testing.T, out string) []string {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for _, line := range lines {
+		require.True(t, json.Valid([]byte(line)), line)
+	}
+	return lines
+}
+
+func TestStreamSearch_WritesPagesAsTheyArrive(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("config", map[int]*github.SearchResults{1: fullPage(1, 100), 2: fullPage(2, 100)})
+	client := &streamCheckingClient{MockClient: mockClient, out: &buf}
+	setupStreamTest(t, client)
+	searchLimit = 150
+
+	require.NoError(t, streamSearch(context.Background(), "config", &buf))
+	assert.Equal(t, []int{0, 100}, client.linesAtFetch, "page 1 is written before page 2 is requested")
+
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 151, "trimmed to --limit plus the summary")
+	assert.Contains(t, lines[0], `"full_name":"owner/repo-1-0"`)
+	assert.Contains(t, lines[149], `"full_name":"owner/repo-2-49"`)
+
+	var summary output.NDJSONSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[150]), &summary))
+	assert.Equal(t, "summary", summary.Type)
+	assert.Equal(t, 1000, *summary.TotalCount)
+	assert.Equal(t, 150, summary.Pagination.DisplayedResults)
+}
+
+func TestStreamSearch_StopsOnShortPage(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.SetSearchResults("rare", github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("owner/a", "main.go", "package main"),
+		github.CreateTestSearchItem("owner/b", "main.go", "package main"),
+	))
+	setupStreamTest(t, mockClient)
+
+	var buf bytes.Buffer
+	require.NoError(t, streamSearch(context.Background(), "rare", &buf))
+	assert.Equal(t, 1, mockClient.GetCallCount("SearchCode"))
+
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[2], `"type":"summary","total_count":2`)
+}
+
+func TestStreamSearch_NoResults(t *testing.T) {
+	setupStreamTest(t, github.NewMockClient())
+
+	var buf bytes.Buffer
+	require.NoError(t, streamSearch(context.Background(), "nothing", &buf))
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 1, "only the summary")
+	assert.Contains(t, lines[0], `"type":"summary","total_count":0`)
+}
+
+func TestStreamSearch_InterruptKeepsWrittenPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("config", map[int]*github.SearchResults{1: fullPage(1, 100), 2: fullPage(2, 100)})
+	setupStreamTest(t, &interruptingClient{MockClient: mockClient, cancel: cancel, after: 1})
+	searchLimit = 200
+
+	var buf bytes.Buffer
+	err := streamSearch(ctx, "config", &buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "search interrupted before it completed")
+
+	lines := ndjsonLines(t, buf.String())
+	require.Len(t, lines, 101)
+	assert.Contains(t, lines[100], `"type":"summary"`)
+}
+
+func TestStreamingOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func()
+		want  bool
+	}{
+		{"ndjson", func() {}, true},
+		{"other format", func() { outputFormat = "json" }, false},
+		{"output file", func() { outputFile = "results.ndjson" }, false},
+		{"explicit page", func() { searchPage = 2 }, false},
+		{"client-side sort", func() { sort = "stars" }, false},
+		{"dedupe", func() { dedupeMode = "content" }, false},
+		{"consensus", func() { consensusMode = true }, false},
+		{"json fields", func() { jsonFields = []string{"path"} }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSearchFlags()
+			defer resetSearchFlags()
+			outputFormat = output.FormatNDJSON
+			tt.setup()
+			assert.Equal(t, tt.want, streamingOutput())
+		})
+	}
+}
+
+func TestRunSearchQuery_BufferedNDJSON(t *testing.T) {
+	// Sorting client-side needs every result, so NDJSON is written at the end
+	mockClient := github.NewMockClient()
+	low := github.CreateTestSearchItem("owner/low", "config.json", "{}")
+	low.Repository.StargazersCount = github.IntPtr(5)
+	high := github.CreateTestSearchItem("owner/high", "config.json", "{}")
+	high.Repository.StargazersCount = github.IntPtr(500)
+	mockClient.SetSearchResults("config", github.CreateTestSearchResults(2, low, high))
+	setupStreamTest(t, mockClient)
+	sort = "stars"
+
+	out := captureOutput(func() error { return runSearchQuery(context.Background(), "config") })
+	require.NoError(t, out.err)
+
+	lines := ndjsonLines(t, out.stdout)
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"full_name":"owner/high"`)
+	assert.Contains(t, lines[2], `"type":"summary"`)
+}
+
+func TestRunSearchQuery_StreamingProgressOnStderr(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.SetPaginatedSearchResults("tsconfig", map[int]*github.SearchResults{
+		1: github.CreateTestSearchResults(4,
+			github.CreateTestSearchItem("vercel/next.js", "tsconfig.json", "{}"),
+			github.CreateTestSearchItem("vercel/next.js", "test/fixtures/tsconfig.json", "{}"),
+		),
+		2: github.CreateTestSearchResults(4,
+			github.CreateTestSearchItem("vitejs/vite", "tsconfig.json", "{}"),
+		),
+	})
+	setupStreamTest(t, mockClient)
+	searchLimit = 2
+	verbose = true
+
+	out := captureOutput(func() error { return runSearchQuery(context.Background(), "tsconfig") })
+	require.NoError(t, out.err)
+
+	// stdout carries only records, so jq can read it
+	lines := ndjsonLines(t, out.stdout)
+	require.Len(t, lines, 3)
+	assert.Contains(t, out.stderr, "Searching GitHub with query: tsconfig")
+	assert.Contains(t, out.stderr, "🧹 Filtered out 1 results on page 1")
+	assert.Contains(t, out.stderr, "Adding delay between pages")
+}
@@ -0,0 +1,208 @@
+// Package analysis finds configuration and code patterns shared across search results.
+package analysis
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/filter"
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// maxPatternsPerKind caps how many keys, values and lines a report keeps
+const maxPatternsPerKind = 15
+
+// maxPatternLength skips minified code and long strings that never recur
+const maxPatternLength = 160
+
+// Settings controls which results are analyzed and which patterns are reported
+type Settings struct {
+	MinPatternCount  int            // results a pattern must appear in
+	PatternThreshold float64        // fraction of analyzed results a pattern must appear in
+	Filter           *filter.Filter // skips the results it drops, nil analyzes all results
+}
+
+// Pattern is a key, option value or line that recurs across results
+type Pattern struct {
+	Text      string  `json:"text"`
+	Count     int     `json:"count"`     // results containing the pattern
+	Frequency float64 `json:"frequency"` // Count as a fraction of analyzed results
+}
+
+// Report lists the patterns found across a set of search results
+type Report struct {
+	Results   int       `json:"results"`  // results analyzed
+	Excluded  int       `json:"excluded"` // results skipped by Filter
+	MinCount  int       `json:"min_count"`
+	Threshold float64   `json:"threshold"`
+	Keys      []Pattern `json:"keys,omitempty"`
+	Values    []Pattern `json:"values,omitempty"`
+	Lines     []Pattern `json:"lines,omitempty"`
+}
+
+// Empty reports whether no pattern met the thresholds
+func (r *Report) Empty() bool {
+	return r == nil || len(r.Keys)+len(r.Values)+len(r.Lines) == 0
+}
+
+// keyValuePattern matches `key: value`, `"key": value` and `key = value` lines
+var keyValuePattern = regexp.MustCompile(`^(["']?)([A-Za-z_@$][\w@$.\-/]*)(["']?)\s*([:=])(.*)$`)
+
+// Analyze counts the keys, option values and lines that appear in at least
+// settings.MinPatternCount results and settings.PatternThreshold of all
+// analyzed results. Fetched file content is used when present, otherwise
+// the text match fragments returned by search.
+func Analyze(results *github.SearchResults, settings Settings) *Report {
+	report := &Report{
+		MinCount:  max(settings.MinPatternCount, 1),
+		Threshold: settings.PatternThreshold,
+	}
+	if results == nil {
+		return report
+	}
+
+	keys := make(map[string]int)
+	values := make(map[string]int)
+	lines := make(map[string]int)
+
+	for i := range results.Items {
+		item := &results.Items[i]
+		if settings.Filter != nil && settings.Filter.Check(item) != "" {
+			report.Excluded++
+			continue
+		}
+
+		text := itemLines(item)
+		if len(text) == 0 {
+			continue
+		}
+		report.Results++
+
+		// Each pattern counts once per result, however often it repeats
+		seenKeys := make(map[string]bool)
+		seenValues := make(map[string]bool)
+		seenLines := make(map[string]bool)
+		for _, line := range text {
+			if key, value, ok := parseKeyValue(line); ok {
+				seenKeys[key] = true
+				if value != "" {
+					seenValues[key+": "+value] = true
+				}
+				continue
+			}
+			if normalized := normalizeLine(line); normalized != "" {
+				seenLines[normalized] = true
+			}
+		}
+		countSeen(keys, seenKeys)
+		countSeen(values, seenValues)
+		countSeen(lines, seenLines)
+	}
+
+	report.Keys = report.patterns(keys)
+	report.Values = report.patterns(values)
+	report.Lines = report.patterns(lines)
+	return report
+}
+
+// patterns keeps counts meeting the report thresholds, most common first
+func (r *Report) patterns(counts map[string]int) []Pattern {
+	if r.Results == 0 {
+		return nil
+	}
+
+	var patterns []Pattern
+	for text, count := range counts {
+		frequency := float64(count) / float64(r.Results)
+		if count < r.MinCount || frequency < r.Threshold {
+			continue
+		}
+		patterns = append(patterns, Pattern{Text: text, Count: count, Frequency: frequency})
+	}
+
+	slices.SortFunc(patterns, func(a, b Pattern) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Text, b.Text)
+	})
+	if len(patterns) > maxPatternsPerKind {
+		patterns = patterns[:maxPatternsPerKind]
+	}
+	return patterns
+}
+
+// countSeen adds one to counts for every pattern seen in a result
+func countSeen(counts map[string]int, seen map[string]bool) {
+	for text := range seen {
+		counts[text]++
+	}
+}
+
+// itemLines returns the lines of code available for a result
+func itemLines(item *github.SearchItem) []string {
+	var lines []string
+	if item.Content != nil && item.Content.Error == "" && len(item.Content.Snippets) > 0 {
+		for _, snippet := range item.Content.Snippets {
+			for _, line := range snippet.Lines {
+				lines = append(lines, line.Text)
+			}
+		}
+		return lines
+	}
+
+	for _, match := range item.TextMatches {
+		if match.Fragment != nil {
+			lines = append(lines, strings.Split(*match.Fragment, "\n")...)
+		}
+	}
+	return lines
+}
+
+// parseKeyValue extracts the key and value of a configuration-style line.
+// Values that open an object or array are reported as keys only.
+func parseKeyValue(line string) (key, value string, ok bool) {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+	m := keyValuePattern.FindStringSubmatch(line)
+	if m == nil || m[1] != m[3] {
+		return "", "", false
+	}
+
+	rest := m[5]
+	// Unquoted `a:b` is more likely a URL or label than a key, and `==`/`=>` are code
+	if m[4] == ":" && m[1] == "" && rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", "", false
+	}
+	if m[4] == "=" && (strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ">")) {
+		return "", "", false
+	}
+
+	value = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), ",;"))
+	value = strings.Trim(value, `"'`)
+	switch {
+	case value == "", len(value) > maxPatternLength,
+		strings.HasSuffix(value, "{"), strings.HasSuffix(value, "["), strings.HasSuffix(value, "("):
+		value = ""
+	}
+	return m[2], value, true
+}
+
+// normalizeLine trims a line for comparison, or returns "" for lines that
+// carry no pattern such as blanks and lone brackets
+func normalizeLine(line string) string {
+	line = strings.Join(strings.Fields(line), " ")
+	line = strings.TrimRight(line, ",;")
+	if len(line) < 3 || len(line) > maxPatternLength {
+		return ""
+	}
+	if !strings.ContainsFunc(line, isAlphanumeric) {
+		return ""
+	}
+	return line
+}
+
+// isAlphanumeric reports whether r is an ASCII letter or digit
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
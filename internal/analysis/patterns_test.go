@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/filter"
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tsconfigResults builds four tsconfig fragments, one of them in a test directory
+func tsconfigResults() *github.SearchResults {
+	return github.CreateTestSearchResults(4,
+		github.CreateTestSearchItem("vercel/next.js", "tsconfig.json",
+			"{\n  \"compilerOptions\": {\n    \"strict\": true,\n    \"target\": \"ES2020\"\n  }\n}"),
+		github.CreateTestSearchItem("facebook/react", "tsconfig.json",
+			"{\n  \"compilerOptions\": {\n    \"strict\": true,\n    \"target\": \"ES2017\",\n    \"strict\": true\n  }\n}"),
+		github.CreateTestSearchItem("vitejs/vite", "tsconfig.json",
+			"{\n  \"compilerOptions\": {\n    \"strict\": false\n  },\n  \"include\": [\"src\"]\n}"),
+		github.CreateTestSearchItem("vitejs/vite", "test/fixtures/tsconfig.json",
+			"{\n  \"compilerOptions\": {\n    \"strict\": false\n  }\n}"),
+	)
+}
+
+// jsonLanguage detects JSON files for language exclusion
+func jsonLanguage(filePath string) string {
+	if strings.HasSuffix(filePath, ".json") {
+		return "json"
+	}
+	return ""
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze(tsconfigResults(), Settings{MinPatternCount: 2, PatternThreshold: 0.3, Filter: filter.New(filter.Rules{ExcludeTests: true})})
+
+	assert.Equal(t, 3, report.Results)
+	assert.Equal(t, 1, report.Excluded)
+	assert.Equal(t, []Pattern{
+		{Text: "compilerOptions", Count: 3, Frequency: 1},
+		{Text: "strict", Count: 3, Frequency: 1},
+		{Text: "target", Count: 2, Frequency: 2.0 / 3.0},
+	}, report.Keys)
+	assert.Equal(t, []Pattern{{Text: "strict: true", Count: 2, Frequency: 2.0 / 3.0}}, report.Values)
+	assert.Empty(t, report.Lines, "braces alone are not patterns")
+}
+
+func TestAnalyze_Thresholds(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  Settings
+		wantKeys  []string
+		wantEmpty bool
+	}{
+		{
+			name:     "test files included",
+			settings: Settings{MinPatternCount: 4, PatternThreshold: 0},
+			wantKeys: []string{"compilerOptions", "strict"},
+		},
+		{
+			name:     "threshold above target",
+			settings: Settings{MinPatternCount: 1, PatternThreshold: 0.9, Filter: filter.New(filter.Rules{ExcludeTests: true})},
+			wantKeys: []string{"compilerOptions", "strict"},
+		},
+		{
+			name:      "count above results",
+			settings:  Settings{MinPatternCount: 5, Filter: filter.New(filter.Rules{ExcludeTests: true})},
+			wantEmpty: true,
+		},
+		{
+			name:      "language excluded",
+			settings:  Settings{MinPatternCount: 1, Filter: filter.New(filter.Rules{ExcludeLanguages: []string{"JSON"}, DetectLanguage: jsonLanguage})},
+			wantEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Analyze(tsconfigResults(), tt.settings)
+			if tt.wantEmpty {
+				assert.True(t, report.Empty())
+				return
+			}
+
+			var keys []string
+			for _, pattern := range report.Keys {
+				keys = append(keys, pattern.Text)
+			}
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestAnalyze_FetchedContentAndLines(t *testing.T) {
+	results := github.CreateTestSearchResults(2,
+		github.CreateTestSearchItem("a/one", "vite.config.ts", "ignored fragment"),
+		github.CreateTestSearchItem("b/two", "vite.config.ts", "import react from '@vitejs/plugin-react'\nexport default defineConfig({"),
+	)
+	results.Items[0].Content = &github.FileContent{Snippets: []github.ContentSnippet{{Lines: []github.ContentLine{
+		{Number: 1, Text: "import react from '@vitejs/plugin-react'"},
+		{Number: 2, Text: "export default defineConfig({"},
+		{Number: 3, Text: "  plugins: [react()],"},
+	}}}}
+
+	report := Analyze(results, Settings{MinPatternCount: 2})
+	assert.Equal(t, []Pattern{
+		{Text: "export default defineConfig({", Count: 2, Frequency: 1},
+		{Text: "import react from '@vitejs/plugin-react'", Count: 2, Frequency: 1},
+	}, report.Lines)
+	assert.Empty(t, report.Keys, "plugins only appears in one result")
+}
+
+func TestParseKeyValue(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantKey   string
+		wantValue string
+		wantOK    bool
+	}{
+		{line: `    "strict": true,`, wantKey: "strict", wantValue: "true", wantOK: true},
+		{line: `"target": "ES2020"`, wantKey: "target", wantValue: "ES2020", wantOK: true},
+		{line: `  compilerOptions: {`, wantKey: "compilerOptions", wantOK: true},
+		{line: `- name: build`, wantKey: "name", wantValue: "build", wantOK: true},
+		{line: `edition = "2021"`, wantKey: "edition", wantValue: "2021", wantOK: true},
+		{line: `https://github.com`, wantOK: false},
+		{line: `if a == b {`, wantOK: false},
+		{line: `x => x * 2`, wantOK: false},
+		{line: `const x = 1`, wantOK: false},
+		{line: `"unbalanced': 1`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			key, value, ok := parseKeyValue(tt.line)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	report := Analyze(tsconfigResults(), Settings{MinPatternCount: 2, PatternThreshold: 0.3, Filter: filter.New(filter.Rules{ExcludeTests: true})})
+
+	text := FormatText(report)
+	assert.Contains(t, text, "📊 Common patterns")
+	assert.Contains(t, text, "Patterns in at least 2 of 3 results (30% threshold), 1 excluded")
+	assert.Contains(t, text, "Values:\n  strict: true")
+	assert.Contains(t, text, "67%")
+
+	markdown := FormatMarkdown(report)
+	assert.Contains(t, markdown, "| Kind | Pattern | Results | Share |")
+	assert.Contains(t, markdown, "| value | `strict: true` | 2 | 67% |")
+
+	require.True(t, (&Report{}).Empty())
+	assert.Empty(t, FormatText(&Report{}))
+	assert.Empty(t, FormatMarkdown(nil))
+}
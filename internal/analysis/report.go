@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// patternGroup pairs a report section with its label
+type patternGroup struct {
+	label    string
+	patterns []Pattern
+}
+
+// groups returns the non-empty report sections in display order
+func (r *Report) groups() []patternGroup {
+	var groups []patternGroup
+	for _, group := range []patternGroup{
+		{"Keys", r.Keys},
+		{"Values", r.Values},
+		{"Lines", r.Lines},
+	} {
+		if len(group.patterns) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Summary describes what was analyzed and the thresholds patterns had to meet
+func (r *Report) Summary() string {
+	summary := fmt.Sprintf("Patterns in at least %d of %d results (%.0f%% threshold)",
+		r.MinCount, r.Results, r.Threshold*100)
+	if r.Excluded > 0 {
+		summary += fmt.Sprintf(", %d excluded as tests or excluded languages", r.Excluded)
+	}
+	return summary
+}
+
+// FormatText renders the report for terminal output
+func FormatText(r *Report) string {
+	if r.Empty() {
+		return ""
+	}
+
+	width := 0
+	for _, group := range r.groups() {
+		for _, pattern := range group.patterns {
+			width = max(width, len(pattern.Text))
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("📊 Common patterns\n%s\n", r.Summary()))
+	for _, group := range r.groups() {
+		buf.WriteString(fmt.Sprintf("\n%s:\n", group.label))
+		for _, pattern := range group.patterns {
+			buf.WriteString(fmt.Sprintf("  %-*s  %3d  %3.0f%%\n", width, pattern.Text, pattern.Count, pattern.Frequency*100))
+		}
+	}
+	return buf.String()
+}
+
+// FormatMarkdown renders the report as a summary line and a table
+func FormatMarkdown(r *Report) string {
+	if r.Empty() {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("*%s*\n\n", r.Summary()))
+	buf.WriteString("| Kind | Pattern | Results | Share |\n")
+	buf.WriteString("|------|---------|---------|-------|\n")
+	for _, group := range r.groups() {
+		kind := strings.ToLower(strings.TrimSuffix(group.label, "s"))
+		for _, pattern := range group.patterns {
+			text := strings.ReplaceAll(pattern.Text, "|", "\\|")
+			text = strings.ReplaceAll(text, "`", "'")
+			buf.WriteString(fmt.Sprintf("| %s | `%s` | %d | %.0f%% |\n", kind, text, pattern.Count, pattern.Frequency*100))
+		}
+	}
+	return buf.String()
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/silouanwright/gh-scout/internal/filter"
 	"github.com/silouanwright/gh-scout/internal/search"
 	"gopkg.in/yaml.v3"
 )
@@ -16,6 +18,7 @@ type Config struct {
 	SavedSearches map[string]SavedSearch `yaml:"saved_searches" json:"saved_searches"`
 	Analysis      AnalysisSettings       `yaml:"analysis" json:"analysis"`
 	Output        OutputSettings         `yaml:"output" json:"output"`
+	Ranking       RankingSettings        `yaml:"ranking" json:"ranking"`
 	GitHub        GitHubSettings         `yaml:"github" json:"github"`
 }
 
@@ -49,6 +52,8 @@ type AnalysisSettings struct {
 	MinPatternCount  int      `yaml:"min_pattern_count" json:"min_pattern_count"`
 	ExcludeLanguages []string `yaml:"exclude_languages" json:"exclude_languages"`
 	ExcludeTests     bool     `yaml:"exclude_tests" json:"exclude_tests"`
+	TestPaths        []string `yaml:"test_paths" json:"test_paths"`       // globs treated as tests in addition to the built-in conventions
+	ExcludePaths     []string `yaml:"exclude_paths" json:"exclude_paths"` // globs always dropped from results
 	PatternThreshold float64  `yaml:"pattern_threshold" json:"pattern_threshold"`
 }
 
@@ -64,9 +69,20 @@ type OutputSettings struct {
 	ShowLineNumbers bool   `yaml:"show_line_numbers" json:"show_line_numbers"`
 }
 
+// RankingSettings weighs repository signals for --sort quality
+type RankingSettings struct {
+	StarsWeight     float64 `yaml:"stars_weight" json:"stars_weight"`
+	ForksWeight     float64 `yaml:"forks_weight" json:"forks_weight"`
+	RecencyWeight   float64 `yaml:"recency_weight" json:"recency_weight"`
+	RelevanceWeight float64 `yaml:"relevance_weight" json:"relevance_weight"`
+	ArchivedPenalty float64 `yaml:"archived_penalty" json:"archived_penalty"` // fraction of the score removed for archived repositories
+	ForkPenalty     float64 `yaml:"fork_penalty" json:"fork_penalty"`         // fraction of the score removed for forks
+}
+
 // GitHubSettings configures GitHub API behavior
 type GitHubSettings struct {
-	RateLimitBuffer int    `yaml:"rate_limit_buffer" json:"rate_limit_buffer"`
+	Host            string `yaml:"host" json:"host"`                           // GitHub Enterprise Server hostname (default: gh's host)
+	RateLimitBuffer int    `yaml:"rate_limit_buffer" json:"rate_limit_buffer"` // Wait for the reset once this many requests remain
 	Timeout         string `yaml:"timeout" json:"timeout"`
 	RetryCount      int    `yaml:"retry_count" json:"retry_count"`
 	CacheResults    bool   `yaml:"cache_results" json:"cache_results"`
@@ -91,6 +107,11 @@ func Load() (*Config, error) {
 	return defaultConfig(), nil
 }
 
+// Default returns a configuration populated with default values
+func Default() *Config {
+	return defaultConfig()
+}
+
 // LoadFromFile loads configuration from a specific file path
 func LoadFromFile(path string) (*Config, error) {
 	return loadFromFile(path)
@@ -171,6 +192,28 @@ func (c *Config) DeleteSavedSearch(name string) error {
 	return nil
 }
 
+// RenameSavedSearch renames a saved search, keeping its history
+func (c *Config) RenameSavedSearch(oldName, newName string) error {
+	search, exists := c.SavedSearches[oldName]
+	if !exists {
+		return fmt.Errorf("saved search '%s' not found", oldName)
+	}
+
+	if newName == "" {
+		return fmt.Errorf("new name for saved search '%s' is required", oldName)
+	}
+
+	if _, taken := c.SavedSearches[newName]; taken {
+		return fmt.Errorf("saved search '%s' already exists", newName)
+	}
+
+	search.Name = newName
+	delete(c.SavedSearches, oldName)
+	c.SavedSearches[newName] = search
+
+	return nil
+}
+
 // ListSavedSearches returns all saved searches sorted by last used
 func (c *Config) ListSavedSearches() []SavedSearch {
 	searches := make([]SavedSearch, 0, len(c.SavedSearches))
@@ -203,17 +246,18 @@ func (c *Config) Validate() error {
 	}
 
 	validFormats := map[string]bool{
-		"default": true, "json": true, "markdown": true, "compact": true,
+		"default": true, "json": true, "markdown": true, "compact": true, "csv": true, "tsv": true, "ndjson": true, "html": true, "sarif": true,
 	}
 	if !validFormats[c.Defaults.OutputFormat] {
-		return fmt.Errorf("defaults.output_format must be one of: default, json, markdown, compact")
+		return fmt.Errorf("defaults.output_format must be one of: default, json, markdown, compact, csv, tsv, ndjson, html, sarif")
 	}
 
 	validSortBy := map[string]bool{
-		"relevance": true, "stars": true, "updated": true, "created": true,
+		"relevance": true, "indexed": true, "stars": true, "forks": true,
+		"recent": true, "updated": true, "created": true, "quality": true,
 	}
 	if !validSortBy[c.Defaults.SortBy] {
-		return fmt.Errorf("defaults.sort_by must be one of: relevance, stars, updated, created")
+		return fmt.Errorf("defaults.sort_by must be one of: relevance, indexed, stars, forks, recent, updated, created, quality")
 	}
 
 	// Validate output settings
@@ -237,6 +281,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("analysis.pattern_threshold must be between 0 and 1")
 	}
 
+	// Validate ranking settings
+	r := c.Ranking
+	if r.StarsWeight < 0 || r.ForksWeight < 0 || r.RecencyWeight < 0 || r.RelevanceWeight < 0 {
+		return fmt.Errorf("ranking weights must be non-negative")
+	}
+	if r.StarsWeight+r.ForksWeight+r.RecencyWeight+r.RelevanceWeight == 0 {
+		return fmt.Errorf("ranking needs at least one positive weight")
+	}
+	if r.ArchivedPenalty < 0 || r.ArchivedPenalty > 1 || r.ForkPenalty < 0 || r.ForkPenalty > 1 {
+		return fmt.Errorf("ranking.archived_penalty and ranking.fork_penalty must be between 0 and 1")
+	}
+
 	// Validate GitHub settings
 	if c.GitHub.RateLimitBuffer < 0 {
 		return fmt.Errorf("github.rate_limit_buffer must be non-negative")
@@ -246,9 +302,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("github.retry_count must be between 0 and 10")
 	}
 
+	if c.GitHub.CacheTTL != "" {
+		if ttl, err := time.ParseDuration(c.GitHub.CacheTTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("github.cache_ttl must be a positive duration (e.g. 30m, 1h)")
+		}
+	}
+
 	return nil
 }
 
+// CacheDir returns the directory used for cached GitHub API responses
+func CacheDir() string {
+	return filepath.Join(getConfigDir(), "cache")
+}
+
+// CacheTTLDuration returns github.cache_ttl as a duration, falling back to the default
+func (c *Config) CacheTTLDuration() time.Duration {
+	if ttl, err := time.ParseDuration(c.GitHub.CacheTTL); err == nil && ttl > 0 {
+		return ttl
+	}
+	ttl, _ := time.ParseDuration(defaultConfig().GitHub.CacheTTL)
+	return ttl
+}
+
 // Private helper functions
 
 // getConfigPaths returns the ordered list of config file paths to check
@@ -297,7 +373,9 @@ func loadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
+	// Ranking fields left out of the file keep their default weights and penalties,
+	// while explicit zeros are kept
+	config := Config{Ranking: Default().Ranking}
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
@@ -332,6 +410,8 @@ func defaultConfig() *Config {
 			MinPatternCount:  2,
 			ExcludeLanguages: []string{},
 			ExcludeTests:     true,
+			TestPaths:        []string{},
+			ExcludePaths:     slices.Clone(filter.DefaultExcludePaths),
 			PatternThreshold: 0.3,
 		},
 		Output: OutputSettings{
@@ -344,6 +424,14 @@ func defaultConfig() *Config {
 			ShowStars:       true,
 			ShowLineNumbers: false,
 		},
+		Ranking: RankingSettings{
+			StarsWeight:     0.4,
+			ForksWeight:     0.1,
+			RecencyWeight:   0.3,
+			RelevanceWeight: 0.2,
+			ArchivedPenalty: 0.5,
+			ForkPenalty:     0.3,
+		},
 		GitHub: GitHubSettings{
 			RateLimitBuffer: 5,
 			Timeout:         "30s",
@@ -387,6 +475,10 @@ func applyDefaults(config *Config) {
 	if config.Analysis.PatternThreshold == 0 {
 		config.Analysis.PatternThreshold = defaults.Analysis.PatternThreshold
 	}
+	// An explicit empty list keeps vendored paths
+	if config.Analysis.ExcludePaths == nil {
+		config.Analysis.ExcludePaths = defaults.Analysis.ExcludePaths
+	}
 
 	if config.GitHub.Timeout == "" {
 		config.GitHub.Timeout = defaults.GitHub.Timeout
@@ -24,6 +24,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.True(t, config.Analysis.EnablePatterns)
 	assert.Equal(t, 2, config.Analysis.MinPatternCount)
 	assert.Equal(t, 0.3, config.Analysis.PatternThreshold)
+	assert.Equal(t, 0.4, config.Ranking.StarsWeight)
 	assert.Equal(t, 5, config.GitHub.RateLimitBuffer)
 	assert.Equal(t, "30s", config.GitHub.Timeout)
 	assert.Equal(t, 3, config.GitHub.RetryCount)
@@ -73,7 +74,7 @@ func TestConfigValidation(t *testing.T) {
 				c.Defaults.OutputFormat = "invalid"
 			},
 			wantErr:  true,
-			errorMsg: "defaults.output_format must be one of: default, json, markdown, compact",
+			errorMsg: "defaults.output_format must be one of: default, json, markdown, compact, csv, tsv, ndjson, html, sarif",
 		},
 		{
 			name: "invalid sort by",
@@ -81,7 +82,38 @@ func TestConfigValidation(t *testing.T) {
 				c.Defaults.SortBy = "invalid"
 			},
 			wantErr:  true,
-			errorMsg: "defaults.sort_by must be one of: relevance, stars, updated, created",
+			errorMsg: "defaults.sort_by must be one of: relevance, indexed, stars, forks, recent, updated, created, quality",
+		},
+		{
+			name: "quality sort by",
+			setupFunc: func(c *Config) {
+				c.Defaults.SortBy = "quality"
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative ranking weight",
+			setupFunc: func(c *Config) {
+				c.Ranking.ForksWeight = -1
+			},
+			wantErr:  true,
+			errorMsg: "ranking weights must be non-negative",
+		},
+		{
+			name: "all ranking weights zero",
+			setupFunc: func(c *Config) {
+				c.Ranking = RankingSettings{ForkPenalty: 0.3}
+			},
+			wantErr:  true,
+			errorMsg: "ranking needs at least one positive weight",
+		},
+		{
+			name: "ranking penalty above one",
+			setupFunc: func(c *Config) {
+				c.Ranking.ArchivedPenalty = 1.5
+			},
+			wantErr:  true,
+			errorMsg: "ranking.archived_penalty and ranking.fork_penalty must be between 0 and 1",
 		},
 		{
 			name: "invalid color mode",
@@ -115,6 +147,14 @@ func TestConfigValidation(t *testing.T) {
 			wantErr:  true,
 			errorMsg: "github.retry_count must be between 0 and 10",
 		},
+		{
+			name: "invalid cache ttl",
+			setupFunc: func(c *Config) {
+				c.GitHub.CacheTTL = "forever"
+			},
+			wantErr:  true,
+			errorMsg: "github.cache_ttl must be a positive duration",
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +244,38 @@ func TestSavedSearchOperations(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestRenameSavedSearch(t *testing.T) {
+	config := defaultConfig()
+	config.AddSavedSearch(SavedSearch{Name: "ts", Query: "tsconfig.json"})
+	config.AddSavedSearch(SavedSearch{Name: "docker", Query: "dockerfile"})
+	_ = config.UseSavedSearch("ts")
+
+	// Rename preserves the search and its history
+	err := config.RenameSavedSearch("ts", "typescript")
+	require.NoError(t, err)
+
+	_, exists := config.GetSavedSearch("ts")
+	assert.False(t, exists)
+
+	renamed, exists := config.GetSavedSearch("typescript")
+	assert.True(t, exists)
+	assert.Equal(t, "typescript", renamed.Name)
+	assert.Equal(t, "tsconfig.json", renamed.Query)
+	assert.Equal(t, 1, renamed.UseCount)
+
+	// Missing source, empty target and existing target are rejected
+	err = config.RenameSavedSearch("non-existent", "other")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	err = config.RenameSavedSearch("typescript", "")
+	assert.Error(t, err)
+
+	err = config.RenameSavedSearch("typescript", "docker")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
 func TestListSavedSearches(t *testing.T) {
 	config := defaultConfig()
 
@@ -371,6 +443,77 @@ func TestLoadFromFile_InvalidYAML(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to parse config file")
 }
 
+func TestLoadFromFile_ExcludePaths(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Omitted exclude_paths falls back to the vendored defaults
+	omitted := filepath.Join(tempDir, "omitted.yaml")
+	require.NoError(t, os.WriteFile(omitted, []byte("analysis:\n  exclude_tests: false\n"), 0644))
+	config, err := LoadFromFile(omitted)
+	require.NoError(t, err)
+	assert.False(t, config.Analysis.ExcludeTests)
+	assert.Equal(t, []string{"**/vendor/**", "**/node_modules/**"}, config.Analysis.ExcludePaths)
+
+	// An explicit empty list disables path exclusion
+	empty := filepath.Join(tempDir, "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("analysis:\n  exclude_paths: []\n  test_paths: [\"**/e2e/**\"]\n"), 0644))
+	config, err = LoadFromFile(empty)
+	require.NoError(t, err)
+	assert.Empty(t, config.Analysis.ExcludePaths)
+	assert.Equal(t, []string{"**/e2e/**"}, config.Analysis.TestPaths)
+}
+
+func TestLoadFromFile_PartialRanking(t *testing.T) {
+	defaults := Default().Ranking
+	tests := []struct {
+		name string
+		yaml string
+		want RankingSettings
+	}{
+		{
+			name: "missing section",
+			yaml: "defaults:\n  sort_by: quality\n",
+			want: defaults,
+		},
+		{
+			name: "penalty only",
+			yaml: "ranking:\n  archived_penalty: 0.8\n",
+			want: func() RankingSettings { r := defaults; r.ArchivedPenalty = 0.8; return r }(),
+		},
+		{
+			name: "one weight",
+			yaml: "ranking:\n  stars_weight: 1\n",
+			want: func() RankingSettings { r := defaults; r.StarsWeight = 1; return r }(),
+		},
+		{
+			name: "explicit zero",
+			yaml: "ranking:\n  fork_penalty: 0\n",
+			want: func() RankingSettings { r := defaults; r.ForkPenalty = 0; return r }(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.yaml), 0644))
+
+			config, err := LoadFromFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config.Ranking)
+		})
+	}
+}
+
+func TestLoadFromFile_GitHubHost(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("github:\n  host: ghe.example.com\n"), 0644))
+
+	config, err := LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ghe.example.com", config.GitHub.Host)
+	assert.Empty(t, Default().GitHub.Host, "the default host comes from gh")
+}
+
 func TestConfigSave_Default(t *testing.T) {
 	// This test is tricky because Save() writes to user's config directory
 	// We'll just verify that the method exists and basic error handling works
@@ -0,0 +1,192 @@
+// Package consensus parses matched config files and reports which settings
+// most projects agree on.
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Default report limits
+const (
+	DefaultMaxKeys   = 50
+	DefaultMaxValues = 3
+)
+
+// Document is one parsed config file
+type Document struct {
+	Repository string
+	Path       string
+	Values     map[string]interface{}
+}
+
+// Failure records a file that could not be fetched or parsed
+type Failure struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Error      string `json:"error"`
+}
+
+// ValueCount is how many files set a key to one value
+type ValueCount struct {
+	Value string  `json:"value"` // JSON encoding of the value
+	Count int     `json:"count"`
+	Share float64 `json:"share"` // Count as a fraction of all parsed files
+}
+
+// KeyConsensus summarizes one key path across files
+type KeyConsensus struct {
+	Path     string       `json:"path"`
+	Count    int          `json:"count"`    // files that set the key
+	Adoption float64      `json:"adoption"` // Count as a fraction of all parsed files
+	Values   []ValueCount `json:"values"`   // most common values first
+}
+
+// Report is the consensus across a set of config files
+type Report struct {
+	Filename string         `json:"filename,omitempty"`
+	Files    int            `json:"files"`             // files parsed
+	Skipped  int            `json:"skipped,omitempty"` // results with a different filename
+	Failures []Failure      `json:"failures,omitempty"`
+	Keys     []KeyConsensus `json:"keys"`
+}
+
+// Options limits the size of a report
+type Options struct {
+	MaxKeys   int // most adopted keys kept (DefaultMaxKeys if zero)
+	MaxValues int // most common values kept per key (DefaultMaxValues if zero)
+}
+
+// Build counts key paths and their values across documents. Nested objects
+// are flattened to dotted paths such as compilerOptions.strict; arrays are
+// compared as whole values.
+func Build(documents []Document, opts Options) *Report {
+	if opts.MaxKeys <= 0 {
+		opts.MaxKeys = DefaultMaxKeys
+	}
+	if opts.MaxValues <= 0 {
+		opts.MaxValues = DefaultMaxValues
+	}
+
+	report := &Report{Files: len(documents), Keys: []KeyConsensus{}}
+	if len(documents) == 0 {
+		return report
+	}
+
+	keyCounts := make(map[string]int)
+	valueCounts := make(map[string]map[string]int)
+	for _, document := range documents {
+		for keyPath, value := range Flatten(document.Values) {
+			keyCounts[keyPath]++
+			if valueCounts[keyPath] == nil {
+				valueCounts[keyPath] = make(map[string]int)
+			}
+			valueCounts[keyPath][value]++
+		}
+	}
+
+	total := float64(len(documents))
+	for keyPath, count := range keyCounts {
+		key := KeyConsensus{Path: keyPath, Count: count, Adoption: float64(count) / total}
+		for value, valueCount := range valueCounts[keyPath] {
+			key.Values = append(key.Values, ValueCount{Value: value, Count: valueCount, Share: float64(valueCount) / total})
+		}
+		slices.SortFunc(key.Values, func(a, b ValueCount) int {
+			if a.Count != b.Count {
+				return b.Count - a.Count
+			}
+			return strings.Compare(a.Value, b.Value)
+		})
+		if len(key.Values) > opts.MaxValues {
+			key.Values = key.Values[:opts.MaxValues]
+		}
+		report.Keys = append(report.Keys, key)
+	}
+
+	slices.SortFunc(report.Keys, func(a, b KeyConsensus) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	if len(report.Keys) > opts.MaxKeys {
+		report.Keys = report.Keys[:opts.MaxKeys]
+	}
+	return report
+}
+
+// Flatten maps every leaf key path of a parsed config to the JSON encoding
+// of its value. Empty objects are kept as leaves so their presence counts.
+func Flatten(values map[string]interface{}) map[string]string {
+	flat := make(map[string]string)
+	flattenInto(flat, "", values)
+	return flat
+}
+
+// flattenInto adds the leaves under prefix to flat
+func flattenInto(flat map[string]string, prefix string, value interface{}) {
+	table, isTable := asTable(value)
+	if !isTable || (len(table) == 0 && prefix != "") {
+		flat[prefix] = encodeValue(value)
+		return
+	}
+
+	for key, child := range table {
+		keyPath := key
+		if prefix != "" {
+			keyPath = prefix + "." + key
+		}
+		flattenInto(flat, keyPath, child)
+	}
+}
+
+// asTable converts JSON, TOML and YAML mappings to a string-keyed map
+func asTable(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			table[fmt.Sprint(key)] = child
+		}
+		return table, true
+	default:
+		return nil, false
+	}
+}
+
+// encodeValue renders a value as compact JSON so equal values compare equal
+// regardless of the source format
+func encodeValue(value interface{}) string {
+	data, err := json.Marshal(normalizeValue(value))
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// normalizeValue converts YAML's interface-keyed maps so they can be marshaled
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		table, _ := asTable(v)
+		return normalizeValue(table)
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			normalized[key] = normalizeValue(child)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, child := range v {
+			normalized[i] = normalizeValue(child)
+		}
+		return normalized
+	default:
+		return v
+	}
+}
@@ -0,0 +1,110 @@
+package consensus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tsconfigDocuments parses three tsconfig variants in different formats
+func tsconfigDocuments(t *testing.T) []Document {
+	t.Helper()
+	sources := []struct {
+		repo, path, content string
+	}{
+		{"vercel/next.js", "tsconfig.json", `{
+  // Next.js defaults
+  "compilerOptions": {
+    "strict": true,
+    "target": "ES2020",
+    "lib": ["dom", "esnext"],
+  },
+}`},
+		{"facebook/react", "tsconfig.json", `{"compilerOptions": {"strict": true, "target": "ES2017", "lib": ["dom", "esnext"]}}`},
+		{"vitejs/vite", "tsconfig.yaml", "compilerOptions:\n  strict: false\n  paths: {}\n"},
+	}
+
+	var documents []Document
+	for _, source := range sources {
+		values, err := Parse(source.path, []byte(source.content))
+		require.NoError(t, err, source.repo)
+		documents = append(documents, Document{Repository: source.repo, Path: source.path, Values: values})
+	}
+	return documents
+}
+
+func TestBuild(t *testing.T) {
+	report := Build(tsconfigDocuments(t), Options{})
+
+	assert.Equal(t, 3, report.Files)
+	require.Len(t, report.Keys, 4)
+
+	strict := report.Keys[0]
+	assert.Equal(t, "compilerOptions.strict", strict.Path)
+	assert.Equal(t, 3, strict.Count)
+	assert.InDelta(t, 1.0, strict.Adoption, 0.001)
+	assert.Equal(t, []ValueCount{
+		{Value: "true", Count: 2, Share: 2.0 / 3.0},
+		{Value: "false", Count: 1, Share: 1.0 / 3.0},
+	}, strict.Values)
+
+	lib := report.Keys[1]
+	assert.Equal(t, "compilerOptions.lib", lib.Path)
+	assert.Equal(t, []ValueCount{{Value: `["dom","esnext"]`, Count: 2, Share: 2.0 / 3.0}}, lib.Values)
+
+	assert.Equal(t, "compilerOptions.target", report.Keys[2].Path)
+	assert.Equal(t, "compilerOptions.paths", report.Keys[3].Path, "empty objects count as set")
+	assert.Equal(t, "{}", report.Keys[3].Values[0].Value)
+}
+
+func TestBuild_Limits(t *testing.T) {
+	report := Build(tsconfigDocuments(t), Options{MaxKeys: 1, MaxValues: 1})
+	require.Len(t, report.Keys, 1)
+	assert.Len(t, report.Keys[0].Values, 1)
+
+	empty := Build(nil, Options{})
+	assert.Equal(t, 0, empty.Files)
+	assert.NotNil(t, empty.Keys, "keys encode as [] rather than null")
+}
+
+func TestFlatten(t *testing.T) {
+	values := map[string]interface{}{
+		"name": "app",
+		"scripts": map[string]interface{}{
+			"build": "vite build",
+		},
+		"nested": map[interface{}]interface{}{"count": 2},
+		"tags":   []interface{}{map[interface{}]interface{}{"a": 1}},
+	}
+
+	assert.Equal(t, map[string]string{
+		"name":          `"app"`,
+		"scripts.build": `"vite build"`,
+		"nested.count":  "2",
+		"tags":          `[{"a":1}]`,
+	}, Flatten(values))
+}
+
+func TestFormatReport(t *testing.T) {
+	report := Build(tsconfigDocuments(t), Options{})
+	report.Filename = "tsconfig.json"
+	report.Skipped = 1
+	report.Failures = []Failure{{Repository: "broken/repo", Path: "tsconfig.json", Error: "invalid JSON"}}
+
+	text := FormatText(report)
+	assert.Contains(t, text, "📋 Consensus across 3 tsconfig.json files (1 could not be fetched or parsed, 1 other files skipped)")
+	assert.Contains(t, text, "compilerOptions.strict    100%  true 67%, false 33%")
+	assert.Contains(t, text, "broken/repo/tsconfig.json: invalid JSON")
+
+	markdown := FormatMarkdown(report)
+	assert.Contains(t, markdown, "| `compilerOptions.strict` | 100% | `true` 67%, `false` 33% |")
+	assert.Contains(t, markdown, "## ⚠️ Not Included")
+
+	encoded, err := FormatJSON(report)
+	require.NoError(t, err)
+	var decoded Report
+	require.NoError(t, json.Unmarshal([]byte(encoded), &decoded))
+	assert.Equal(t, report.Keys, decoded.Keys)
+}
@@ -0,0 +1,150 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported config file types
+const (
+	TypeJSON  = "json"
+	TypeJSONC = "jsonc"
+	TypeYAML  = "yaml"
+	TypeTOML  = "toml"
+)
+
+// DetectType returns the config file type for a file path, or "" when the
+// extension is not a supported config format
+func DetectType(filePath string) string {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".json":
+		return TypeJSON
+	case ".jsonc", ".json5":
+		return TypeJSONC
+	case ".yaml", ".yml":
+		return TypeYAML
+	case ".toml":
+		return TypeTOML
+	default:
+		return ""
+	}
+}
+
+// Parse decodes a config file into nested maps. JSON files are read as
+// JSONC because tsconfig.json and friends routinely contain comments and
+// trailing commas. Files with unknown extensions are tried as JSON and
+// then YAML.
+func Parse(filePath string, data []byte) (map[string]interface{}, error) {
+	switch DetectType(filePath) {
+	case TypeJSON, TypeJSONC:
+		return parseJSONC(data)
+	case TypeYAML:
+		return parseYAML(data)
+	case TypeTOML:
+		return parseTOML(data)
+	default:
+		if values, err := parseJSONC(data); err == nil {
+			return values, nil
+		}
+		return parseYAML(data)
+	}
+}
+
+// parseJSONC decodes JSON after removing comments and trailing commas
+func parseJSONC(data []byte) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	if err := json.Unmarshal(StripJSONC(data), &values); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if values == nil {
+		return nil, fmt.Errorf("invalid JSON: top level is not an object")
+	}
+	return values, nil
+}
+
+// parseYAML decodes the first YAML document of a file
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if values == nil {
+		return nil, fmt.Errorf("invalid YAML: top level is not a mapping")
+	}
+	return values, nil
+}
+
+// parseTOML decodes a TOML file. Tables decode to maps and datetimes to
+// times, which encode back to their TOML text.
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if err := toml.Unmarshal(data, &values); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, _ := decodeErr.Position()
+			return nil, fmt.Errorf("invalid TOML on line %d: %w", line, err)
+		}
+		return nil, fmt.Errorf("invalid TOML: %w", err)
+	}
+	return values, nil
+}
+
+// StripJSONC removes // and /* */ comments and trailing commas so JSONC
+// can be decoded with encoding/json. String contents are left untouched.
+func StripJSONC(data []byte) []byte {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	out := make([]byte, 0, len(data))
+
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(data)
+			} else {
+				i += end + 3
+			}
+			out = append(out, ' ')
+		case c == '}' || c == ']':
+			// Drop a comma that only has whitespace between it and the closing bracket
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectType(t *testing.T) {
+	tests := map[string]string{
+		"tsconfig.json":               TypeJSON,
+		".vscode/settings.jsonc":      TypeJSONC,
+		".github/workflows/ci.yml":    TypeYAML,
+		"docker-compose.yaml":         TypeYAML,
+		"Cargo.toml":                  TypeTOML,
+		"vite.config.ts":              "",
+		"packages/app/.eslintrc":      "",
+		"packages/app/pyproject.TOML": TypeTOML,
+	}
+
+	for filePath, want := range tests {
+		t.Run(filePath, func(t *testing.T) {
+			assert.Equal(t, want, DetectType(filePath))
+		})
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	input := `{
+  // line comment
+  "url": "https://example.com/*not a comment*/", /* block
+  comment */
+  "escaped": "quote \" // still string",
+  "list": [1, 2,],
+}`
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(StripJSONC([]byte(input)), &decoded))
+	assert.Equal(t, map[string]interface{}{
+		"url":     "https://example.com/*not a comment*/",
+		"escaped": `quote " // still string`,
+		"list":    []interface{}{float64(1), float64(2)},
+	}, decoded)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "jsonc",
+			path:    "tsconfig.json",
+			content: "{\n// comment\n\"strict\": true,\n}",
+			want:    map[string]interface{}{"strict": true},
+		},
+		{
+			name:    "yaml",
+			path:    "ci.yml",
+			content: "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			want: map[string]interface{}{
+				"on":   "push",
+				"jobs": map[string]interface{}{"build": map[string]interface{}{"runs-on": "ubuntu-latest"}},
+			},
+		},
+		{
+			name:    "toml",
+			path:    "Cargo.toml",
+			content: "[package]\nname = \"demo\"\nedition = \"2021\"\n",
+			want:    map[string]interface{}{"package": map[string]interface{}{"name": "demo", "edition": "2021"}},
+		},
+		{
+			name:    "toml line ending backslash",
+			path:    "pyproject.toml",
+			content: "[tool.\"my#tool\"]\ndescription = \"\"\"\nfast \\\n    linter\"\"\"\n",
+			want:    map[string]interface{}{"tool": map[string]interface{}{"my#tool": map[string]interface{}{"description": "fast linter"}}},
+		},
+		{
+			name:    "invalid toml",
+			path:    "Cargo.toml",
+			content: "[package]\nname \"demo\"\n",
+			wantErr: "invalid TOML on line 2",
+		},
+		{
+			name:    "unknown extension falls back to yaml",
+			path:    ".prettierrc",
+			content: "semi: false\n",
+			want:    map[string]interface{}{"semi": false},
+		},
+		{
+			name:    "invalid json",
+			path:    "package.json",
+			content: "{not json",
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "json array",
+			path:    "list.json",
+			content: "[1, 2]",
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "empty yaml",
+			path:    "empty.yml",
+			content: "",
+			wantErr: "top level is not a mapping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := Parse(tt.path, []byte(tt.content))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
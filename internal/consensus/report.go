@@ -0,0 +1,120 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxValueWidth truncates long values such as arrays in text and markdown tables
+const maxValueWidth = 40
+
+// Summary describes how many files the report covers
+func (r *Report) Summary() string {
+	subject := "config files"
+	if r.Filename != "" {
+		subject = r.Filename + " files"
+	}
+	summary := fmt.Sprintf("Consensus across %d %s", r.Files, subject)
+
+	var notes []string
+	if len(r.Failures) > 0 {
+		notes = append(notes, fmt.Sprintf("%d could not be fetched or parsed", len(r.Failures)))
+	}
+	if r.Skipped > 0 {
+		notes = append(notes, fmt.Sprintf("%d other files skipped", r.Skipped))
+	}
+	if len(notes) > 0 {
+		summary += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return summary
+}
+
+// valueList renders a key's most common values with their share of files
+func valueList(key KeyConsensus) string {
+	parts := make([]string, len(key.Values))
+	for i, value := range key.Values {
+		parts[i] = fmt.Sprintf("%s %.0f%%", truncateValue(value.Value), value.Share*100)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// truncateValue shortens values that would break table layout
+func truncateValue(value string) string {
+	if len(value) <= maxValueWidth {
+		return value
+	}
+	return value[:maxValueWidth-3] + "..."
+}
+
+// FormatText renders the report as an aligned terminal table
+func FormatText(r *Report) string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("📋 %s\n\n", r.Summary()))
+
+	if len(r.Keys) == 0 {
+		buf.WriteString("No settings found.\n")
+	} else {
+		width := len("KEY")
+		for _, key := range r.Keys {
+			width = max(width, len(key.Path))
+		}
+
+		buf.WriteString(fmt.Sprintf("%-*s  %6s  %s\n", width, "KEY", "SET IN", "MOST COMMON VALUES"))
+		for _, key := range r.Keys {
+			buf.WriteString(fmt.Sprintf("%-*s  %5.0f%%  %s\n", width, key.Path, key.Adoption*100, valueList(key)))
+		}
+	}
+
+	writeFailuresText(&buf, r.Failures)
+	return buf.String()
+}
+
+// writeFailuresText lists files that were left out of the report
+func writeFailuresText(buf *strings.Builder, failures []Failure) {
+	if len(failures) == 0 {
+		return
+	}
+	buf.WriteString("\n⚠️  Not included:\n")
+	for _, failure := range failures {
+		buf.WriteString(fmt.Sprintf("  %s/%s: %s\n", failure.Repository, failure.Path, failure.Error))
+	}
+}
+
+// FormatMarkdown renders the report as a markdown table
+func FormatMarkdown(r *Report) string {
+	var buf strings.Builder
+	buf.WriteString("# 📋 Config Consensus\n\n")
+	buf.WriteString(fmt.Sprintf("%s\n\n", r.Summary()))
+
+	if len(r.Keys) == 0 {
+		buf.WriteString("No settings found.\n")
+	} else {
+		buf.WriteString("| Key | Set in | Most common values |\n")
+		buf.WriteString("|-----|--------|--------------------|\n")
+		for _, key := range r.Keys {
+			values := make([]string, len(key.Values))
+			for i, value := range key.Values {
+				values[i] = fmt.Sprintf("`%s` %.0f%%", strings.ReplaceAll(truncateValue(value.Value), "|", "\\|"), value.Share*100)
+			}
+			buf.WriteString(fmt.Sprintf("| `%s` | %.0f%% | %s |\n", key.Path, key.Adoption*100, strings.Join(values, ", ")))
+		}
+	}
+
+	if len(r.Failures) > 0 {
+		buf.WriteString("\n## ⚠️ Not Included\n\n")
+		for _, failure := range r.Failures {
+			buf.WriteString(fmt.Sprintf("- `%s/%s`: %s\n", failure.Repository, failure.Path, failure.Error))
+		}
+	}
+	return buf.String()
+}
+
+// FormatJSON renders the report as indented JSON
+func FormatJSON(r *Report) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
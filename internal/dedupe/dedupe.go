@@ -0,0 +1,186 @@
+// Package dedupe collapses search results that are copies of the same file,
+// such as vendored libraries and forks, into one canonical result.
+package dedupe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// Supported dedupe modes
+const (
+	ModeSHA     = "sha"     // identical blob SHA
+	ModeContent = "content" // identical matched fragments, ignoring whitespace
+	ModeRepo    = "repo"    // same path in repositories with the same name, i.e. forks
+)
+
+// Modes lists the supported dedupe modes
+func Modes() []string {
+	return []string{ModeSHA, ModeContent, ModeRepo}
+}
+
+// ValidateMode rejects unknown modes. An empty mode disables deduplication.
+func ValidateMode(mode string) error {
+	if mode == "" || slices.Contains(Modes(), mode) {
+		return nil
+	}
+	return fmt.Errorf("unsupported dedupe mode: %s (supported: %s)", mode, strings.Join(Modes(), ", "))
+}
+
+// Apply collapses items with the same key under mode. Each group is kept at
+// the position of its first copy, represented by its most starred copy, and
+// lists the other copies in Duplicates. Items without a key are kept as is.
+// It returns the remaining items and how many were collapsed.
+func Apply(items []github.SearchItem, mode string) ([]github.SearchItem, int) {
+	if mode == "" {
+		return items, 0
+	}
+
+	deduped := make([]github.SearchItem, 0, len(items))
+	groups := make(map[string]int)
+	for _, item := range items {
+		key := Key(&item, mode)
+		if key == "" {
+			deduped = append(deduped, item)
+			continue
+		}
+
+		index, seen := groups[key]
+		if !seen {
+			groups[key] = len(deduped)
+			deduped = append(deduped, item)
+			continue
+		}
+		deduped[index] = merge(deduped[index], item)
+	}
+
+	for i := range deduped {
+		sortDuplicates(deduped[i].Duplicates)
+	}
+	return deduped, len(items) - len(deduped)
+}
+
+// Key returns the value items are grouped by under mode, or "" when the
+// item lacks the data to compare it
+func Key(item *github.SearchItem, mode string) string {
+	switch mode {
+	case ModeSHA:
+		if item.SHA != nil && *item.SHA != "" {
+			return *item.SHA
+		}
+		return contentHash(item)
+	case ModeContent:
+		return contentHash(item)
+	case ModeRepo:
+		name := repositoryName(&item.Repository)
+		if name == "" || item.Path == nil {
+			return ""
+		}
+		return strings.ToLower(name) + ":" + *item.Path
+	default:
+		return ""
+	}
+}
+
+// merge folds item into the group represented by canonical, promoting item
+// when it is the better copy
+func merge(canonical, item github.SearchItem) github.SearchItem {
+	duplicates := make([]github.Duplicate, 0, len(canonical.Duplicates)+len(item.Duplicates)+1)
+	duplicates = append(duplicates, canonical.Duplicates...)
+	duplicates = append(duplicates, item.Duplicates...)
+
+	if preferred(&item, &canonical) {
+		canonical, item = item, canonical
+	}
+	canonical.Duplicates = append(duplicates, duplicateOf(&item))
+	return canonical
+}
+
+// preferred reports whether a is a better canonical copy than b: more
+// stars first, then an original repository over a fork
+func preferred(a, b *github.SearchItem) bool {
+	starsA, starsB := stars(a), stars(b)
+	if starsA != starsB {
+		return starsA > starsB
+	}
+	return !isFork(a) && isFork(b)
+}
+
+// duplicateOf records where a collapsed copy was found
+func duplicateOf(item *github.SearchItem) github.Duplicate {
+	return github.Duplicate{
+		Repository: stringValue(item.Repository.FullName),
+		Path:       stringValue(item.Path),
+		Stars:      stars(item),
+		HTMLURL:    stringValue(item.HTMLURL),
+	}
+}
+
+// sortDuplicates orders copies by stars, then repository and path
+func sortDuplicates(duplicates []github.Duplicate) {
+	slices.SortStableFunc(duplicates, func(a, b github.Duplicate) int {
+		if a.Stars != b.Stars {
+			return b.Stars - a.Stars
+		}
+		if c := strings.Compare(a.Repository, b.Repository); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+}
+
+// contentHash hashes the matched fragments with whitespace normalized
+func contentHash(item *github.SearchItem) string {
+	var lines []string
+	for _, match := range item.TextMatches {
+		if match.Fragment == nil {
+			continue
+		}
+		for _, line := range strings.Split(*match.Fragment, "\n") {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				lines = append(lines, strings.Join(fields, " "))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// repositoryName returns the repository name without its owner
+func repositoryName(repository *github.Repository) string {
+	if name := repository.GetName(); name != "" {
+		return name
+	}
+	fullName := stringValue(repository.FullName)
+	return fullName[strings.LastIndex(fullName, "/")+1:]
+}
+
+// stars returns the repository star count, 0 when not enriched
+func stars(item *github.SearchItem) int {
+	if item.Repository.StargazersCount == nil {
+		return 0
+	}
+	return *item.Repository.StargazersCount
+}
+
+// isFork reports whether the item's repository is a fork
+func isFork(item *github.SearchItem) bool {
+	return item.Repository.Fork != nil && *item.Repository.Fork
+}
+
+// stringValue dereferences an optional string
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
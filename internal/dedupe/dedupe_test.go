@@ -0,0 +1,123 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// testItem builds a result with a blob SHA, star count and fork flag
+func testItem(repository, path, sha string, stars int, fork bool, fragment string) github.SearchItem {
+	item := github.CreateTestSearchItem(repository, path, fragment)
+	item.Repository = github.CreateTestRepository(repository, stars)
+	item.Repository.Fork = github.BoolPtr(fork)
+	if sha != "" {
+		item.SHA = github.StringPtr(sha)
+	}
+	return item
+}
+
+func TestValidateMode(t *testing.T) {
+	for _, mode := range []string{"", ModeSHA, ModeContent, ModeRepo} {
+		assert.NoError(t, ValidateMode(mode), mode)
+	}
+	assert.EqualError(t, ValidateMode("path"), "unsupported dedupe mode: path (supported: sha, content, repo)")
+}
+
+func TestApply(t *testing.T) {
+	items := []github.SearchItem{
+		testItem("someone/lodash", "vendor/lodash.js", "abc", 3, true, "module.exports = _;"),
+		testItem("acme/app", "src/index.js", "def", 50, false, "import _ from 'lodash'"),
+		testItem("lodash/lodash", "lodash.js", "abc", 58000, false, "module.exports  =  _;\n"),
+		testItem("other/lodash", "lodash.js", "abc", 3, false, "module.exports = _;"),
+		testItem("fork/app", "src/index.js", "123", 0, true, "import _ from 'lodash'"),
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		wantRepos     []string
+		wantCollapsed int
+	}{
+		{
+			name:      "disabled",
+			mode:      "",
+			wantRepos: []string{"someone/lodash", "acme/app", "lodash/lodash", "other/lodash", "fork/app"},
+		},
+		{
+			name:          "sha keeps the most starred copy in the first copy's position",
+			mode:          ModeSHA,
+			wantRepos:     []string{"lodash/lodash", "acme/app", "fork/app"},
+			wantCollapsed: 2,
+		},
+		{
+			name:          "content ignores whitespace",
+			mode:          ModeContent,
+			wantRepos:     []string{"lodash/lodash", "acme/app"},
+			wantCollapsed: 3,
+		},
+		{
+			name:          "repo collapses forks with the same path",
+			mode:          ModeRepo,
+			wantRepos:     []string{"someone/lodash", "acme/app", "lodash/lodash"},
+			wantCollapsed: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deduped, collapsed := Apply(items, tt.mode)
+
+			var repos []string
+			for _, item := range deduped {
+				repos = append(repos, *item.Repository.FullName)
+			}
+			assert.Equal(t, tt.wantRepos, repos)
+			assert.Equal(t, tt.wantCollapsed, collapsed)
+		})
+	}
+}
+
+func TestApply_Duplicates(t *testing.T) {
+	items := []github.SearchItem{
+		testItem("someone/lodash", "vendor/lodash.js", "abc", 3, true, ""),
+		testItem("lodash/lodash", "lodash.js", "abc", 58000, false, ""),
+		testItem("lodash/lodash", "dist/lodash.js", "abc", 58000, false, ""),
+		testItem("other/lodash", "lodash.js", "abc", 3, false, ""),
+	}
+
+	deduped, _ := Apply(items, ModeSHA)
+	require.Len(t, deduped, 1)
+
+	canonical := deduped[0]
+	assert.Equal(t, "lodash/lodash", *canonical.Repository.FullName)
+	assert.Equal(t, "lodash.js", *canonical.Path, "first of equally starred copies wins")
+	assert.Equal(t, []github.Duplicate{
+		{Repository: "lodash/lodash", Path: "dist/lodash.js", Stars: 58000, HTMLURL: "https://github.com/lodash/lodash/blob/main/dist/lodash.js"},
+		{Repository: "other/lodash", Path: "lodash.js", Stars: 3, HTMLURL: "https://github.com/other/lodash/blob/main/lodash.js"},
+		{Repository: "someone/lodash", Path: "vendor/lodash.js", Stars: 3, HTMLURL: "https://github.com/someone/lodash/blob/main/vendor/lodash.js"},
+	}, canonical.Duplicates)
+	assert.Equal(t, []string{"other/lodash", "someone/lodash"}, canonical.DuplicateRepositories())
+
+	// Applying again to an already deduplicated list keeps existing duplicates
+	more := append(deduped, testItem("mirror/lodash", "lodash.js", "abc", 10, false, ""))
+	again, collapsed := Apply(more, ModeSHA)
+	require.Len(t, again, 1)
+	assert.Equal(t, 1, collapsed)
+	assert.Len(t, again[0].Duplicates, 4)
+}
+
+func TestKey(t *testing.T) {
+	noSHA := testItem("acme/app", "config.yml", "", 1, false, "a:   1\n\n  b: 2")
+	assert.Equal(t, Key(&noSHA, ModeContent), Key(&noSHA, ModeSHA), "sha falls back to content")
+
+	empty := testItem("acme/app", "config.yml", "", 1, false, "")
+	assert.Empty(t, Key(&empty, ModeContent))
+
+	noName := testItem("acme/App", "config.yml", "", 1, false, "")
+	noName.Repository.Name = nil
+	assert.Equal(t, "app:config.yml", Key(&noName, ModeRepo))
+}
@@ -0,0 +1,153 @@
+// Package filter drops search results that make poor examples: tests,
+// fixtures, vendored dependencies and files in excluded languages.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// Reasons a result is dropped
+const (
+	ReasonTest     = "test or fixture path"
+	ReasonPath     = "excluded path"
+	ReasonLanguage = "excluded language"
+)
+
+// DefaultTestPaths are test and fixture conventions shared across languages
+var DefaultTestPaths = []string{
+	"**/test/**",
+	"**/tests/**",
+	"**/spec/**",
+	"**/testdata/**",
+	"**/fixtures/**",
+	"**/__fixtures__/**",
+	"**/__tests__/**",
+	"**/__mocks__/**",
+	"*.test.*",
+	"*.spec.*",
+}
+
+// LanguageTestPaths are test conventions that only apply to files in one language
+var LanguageTestPaths = map[string][]string{
+	"go":         {"*_test.go"},
+	"javascript": {"**/cypress/**", "*.cy.*"},
+	"typescript": {"**/cypress/**", "*.cy.*"},
+	"python":     {"test_*.py", "*_test.py", "conftest.py"},
+	"ruby":       {"*_spec.rb", "*_test.rb"},
+	"java":       {"*Test.java", "*Tests.java", "*IT.java"},
+	"rust":       {"**/benches/**"},
+}
+
+// DefaultExcludePaths are vendored dependencies, dropped regardless of ExcludeTests
+var DefaultExcludePaths = []string{
+	"**/vendor/**",
+	"**/node_modules/**",
+}
+
+// Rules configures which results a Filter drops
+type Rules struct {
+	ExcludeTests     bool                         // drop test and fixture paths
+	TestPaths        []string                     // globs added to the built-in test conventions
+	ExcludePaths     []string                     // globs always dropped
+	ExcludeLanguages []string                     // languages dropped by file type
+	DetectLanguage   func(filePath string) string // names a file's language, language rules are skipped when nil
+}
+
+// Dropped is a result removed by a Filter and why
+type Dropped struct {
+	Item   github.SearchItem
+	Reason string
+}
+
+// Filter decides which search results to keep
+type Filter struct {
+	excludeTests   bool
+	testPaths      []*regexp.Regexp
+	excludePaths   []*regexp.Regexp
+	languages      []string
+	detectLanguage func(filePath string) string
+}
+
+// Built-in conventions compiled once
+var (
+	defaultTestGlobs  = compileGlobs(DefaultTestPaths)
+	languageTestGlobs = compileLanguageGlobs()
+)
+
+// New compiles rules into a Filter
+func New(rules Rules) *Filter {
+	f := &Filter{
+		excludeTests:   rules.ExcludeTests,
+		excludePaths:   compileGlobs(rules.ExcludePaths),
+		detectLanguage: rules.DetectLanguage,
+	}
+	if rules.ExcludeTests {
+		f.testPaths = compileGlobs(append(slices.Clone(DefaultTestPaths), rules.TestPaths...))
+	}
+	for _, language := range rules.ExcludeLanguages {
+		f.languages = append(f.languages, strings.ToLower(language))
+	}
+	return f
+}
+
+// Active reports whether the filter can drop anything
+func (f *Filter) Active() bool {
+	return f.excludeTests || len(f.excludePaths) > 0 || len(f.languages) > 0
+}
+
+// Check returns why an item should be dropped, or "" to keep it
+func (f *Filter) Check(item *github.SearchItem) string {
+	filePath := ""
+	if item.Path != nil {
+		filePath = strings.TrimPrefix(*item.Path, "/")
+	}
+
+	if matchAny(f.excludePaths, filePath) {
+		return ReasonPath
+	}
+
+	language := f.language(filePath)
+	if f.excludeTests && (matchAny(f.testPaths, filePath) || matchAny(languageTestGlobs[language], filePath)) {
+		return ReasonTest
+	}
+	if language != "" && slices.Contains(f.languages, language) {
+		return fmt.Sprintf("%s (%s)", ReasonLanguage, language)
+	}
+	return ""
+}
+
+// Apply splits items into those kept and those dropped, preserving order
+func (f *Filter) Apply(items []github.SearchItem) ([]github.SearchItem, []Dropped) {
+	kept := make([]github.SearchItem, 0, len(items))
+	var dropped []Dropped
+	for _, item := range items {
+		if reason := f.Check(&item); reason != "" {
+			dropped = append(dropped, Dropped{Item: item, Reason: reason})
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept, dropped
+}
+
+// language returns the lowercase language of a file path, or "" when unknown
+func (f *Filter) language(filePath string) string {
+	if f.detectLanguage == nil {
+		return ""
+	}
+	return strings.ToLower(f.detectLanguage(filePath))
+}
+
+// compileLanguageGlobs compiles the per-language test conventions
+func compileLanguageGlobs() map[string][]*regexp.Regexp {
+	compiled := make(map[string][]*regexp.Regexp, len(LanguageTestPaths))
+	for language, globs := range LanguageTestPaths {
+		compiled[language] = compileGlobs(globs)
+	}
+	return compiled
+}
@@ -0,0 +1,186 @@
+package filter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob     string
+		filePath string
+		want     bool
+	}{
+		{"**/vendor/**", "vendor/github.com/pkg/errors/errors.go", true},
+		{"**/vendor/**", "third/vendor/lib.js", true},
+		{"**/vendor/**", "vendored/lib.js", false},
+		{"*.test.*", "src/deep/Button.test.tsx", true},
+		{"*.test.*", "src/Button.tsx", false},
+		{"docs/*.md", "docs/guide.md", true},
+		{"docs/*.md", "docs/api/guide.md", false},
+		{"/docs/**", "docs/api/guide.md", true},
+		{"config?.yml", "ci/config1.yml", true},
+		{"config?.yml", "ci/config10.yml", false},
+		{"a+b.json", "a+b.json", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.filePath, func(t *testing.T) {
+			assert.Equal(t, tt.want, compileGlob(tt.glob).MatchString(tt.filePath))
+		})
+	}
+}
+
+// testLanguage names the languages the test conventions cover by extension
+func testLanguage(filePath string) string {
+	return map[string]string{
+		".go":   "go",
+		".ts":   "typescript",
+		".tsx":  "typescript",
+		".py":   "python",
+		".java": "java",
+		".rs":   "rust",
+	}[path.Ext(filePath)]
+}
+
+func TestFilterCheck_TestPaths(t *testing.T) {
+	tests := map[string]bool{
+		"cmd/search_test.go":                 true,
+		"src/Button.test.tsx":                true,
+		"src/api.spec.ts":                    true,
+		"tests/conftest.py":                  true,
+		"test_utils.py":                      true,
+		"packages/a/__tests__/x.js":          true,
+		"internal/testdata/cfg.json":         true,
+		"spec/fixtures/config.yml":           true,
+		"src/main/java/AppTest.java":         true,
+		"src/components/Button.tsx":          false,
+		"contest/tsconfig.json":              false,
+		"docs/testing-guide/index.md":        false,
+		"scripts/test_release.sh":            false,
+		"src/main/java/ContestService.java":  false,
+		"packages/a/__tests__.config.json":   false,
+		"crates/core/benches/throughput.rs":  true,
+		"crates/core/src/benches/README.md":  false,
+		"pkg/server_test_helpers/handler.go": false,
+	}
+
+	f := New(Rules{ExcludeTests: true, DetectLanguage: testLanguage})
+	for filePath, want := range tests {
+		t.Run(filePath, func(t *testing.T) {
+			item := testItems(filePath)[0]
+			assert.Equal(t, want, f.Check(&item) == ReasonTest)
+		})
+	}
+
+	// Language conventions need a detector
+	item := testItems("cmd/search_test.go")[0]
+	assert.Empty(t, New(Rules{ExcludeTests: true}).Check(&item))
+}
+
+// testItems builds one search result per path
+func testItems(paths ...string) []github.SearchItem {
+	items := make([]github.SearchItem, len(paths))
+	for i, filePath := range paths {
+		items[i] = github.SearchItem{
+			Path:       github.StringPtr(filePath),
+			Repository: github.Repository{FullName: github.StringPtr("owner/repo")},
+		}
+	}
+	return items
+}
+
+func TestFilterApply(t *testing.T) {
+	items := testItems(
+		"tsconfig.json",
+		"src/__tests__/tsconfig.json",
+		"node_modules/pkg/tsconfig.json",
+		"examples/demo/tsconfig.json",
+		"main.py",
+		"e2e/tsconfig.json",
+	)
+
+	tests := []struct {
+		name        string
+		rules       Rules
+		wantKept    []string
+		wantReasons map[string]string
+	}{
+		{
+			name:     "no rules",
+			rules:    Rules{},
+			wantKept: []string{"tsconfig.json", "src/__tests__/tsconfig.json", "node_modules/pkg/tsconfig.json", "examples/demo/tsconfig.json", "main.py", "e2e/tsconfig.json"},
+		},
+		{
+			name:     "tests and vendored paths",
+			rules:    Rules{ExcludeTests: true, ExcludePaths: DefaultExcludePaths},
+			wantKept: []string{"tsconfig.json", "examples/demo/tsconfig.json", "main.py", "e2e/tsconfig.json"},
+			wantReasons: map[string]string{
+				"src/__tests__/tsconfig.json":    ReasonTest,
+				"node_modules/pkg/tsconfig.json": ReasonPath,
+			},
+		},
+		{
+			name:     "custom test globs",
+			rules:    Rules{ExcludeTests: true, TestPaths: []string{"**/e2e/**", "examples/**"}},
+			wantKept: []string{"tsconfig.json", "node_modules/pkg/tsconfig.json", "main.py"},
+		},
+		{
+			name:     "custom test globs ignored without exclude tests",
+			rules:    Rules{TestPaths: []string{"**/e2e/**"}, ExcludePaths: []string{"examples/**"}},
+			wantKept: []string{"tsconfig.json", "src/__tests__/tsconfig.json", "node_modules/pkg/tsconfig.json", "main.py", "e2e/tsconfig.json"},
+		},
+		{
+			name:     "excluded languages",
+			rules:    Rules{ExcludeLanguages: []string{"Python"}, DetectLanguage: testLanguage},
+			wantKept: []string{"tsconfig.json", "src/__tests__/tsconfig.json", "node_modules/pkg/tsconfig.json", "examples/demo/tsconfig.json", "e2e/tsconfig.json"},
+			wantReasons: map[string]string{
+				"main.py": "excluded language (python)",
+			},
+		},
+		{
+			name: "detected languages",
+			rules: Rules{
+				ExcludeLanguages: []string{"json"},
+				DetectLanguage: func(filePath string) string {
+					if filePath == "tsconfig.json" {
+						return "JSON"
+					}
+					return ""
+				},
+			},
+			wantKept: []string{"src/__tests__/tsconfig.json", "node_modules/pkg/tsconfig.json", "examples/demo/tsconfig.json", "main.py", "e2e/tsconfig.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.rules)
+			kept, dropped := f.Apply(items)
+
+			var keptPaths []string
+			for _, item := range kept {
+				keptPaths = append(keptPaths, *item.Path)
+			}
+			assert.Equal(t, tt.wantKept, keptPaths)
+			assert.Len(t, dropped, len(items)-len(kept))
+
+			for _, d := range dropped {
+				if want, ok := tt.wantReasons[*d.Item.Path]; ok {
+					assert.Equal(t, want, d.Reason, *d.Item.Path)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterActive(t *testing.T) {
+	assert.False(t, New(Rules{}).Active())
+	assert.False(t, New(Rules{TestPaths: []string{"**/e2e/**"}}).Active())
+	assert.True(t, New(Rules{ExcludeTests: true}).Active())
+	assert.True(t, New(Rules{ExcludePaths: []string{"dist/**"}}).Active())
+	assert.True(t, New(Rules{ExcludeLanguages: []string{"go"}}).Active())
+}
@@ -0,0 +1,58 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compileGlobs compiles path globs, skipping empty patterns
+func compileGlobs(globs []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			compiled = append(compiled, compileGlob(glob))
+		}
+	}
+	return compiled
+}
+
+// compileGlob converts a path glob to a regular expression. * and ? match
+// within one path segment and ** matches any number of segments. Globs
+// without a slash match the file name at any depth, like .gitignore.
+func compileGlob(glob string) *regexp.Regexp {
+	glob = strings.TrimPrefix(glob, "/")
+	if !strings.Contains(glob, "/") {
+		glob = "**/" + glob
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// matchAny reports whether filePath matches one of the compiled globs
+func matchAny(globs []*regexp.Regexp, filePath string) bool {
+	for _, glob := range globs {
+		if glob.MatchString(filePath) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,261 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache namespaces for stored entries
+const (
+	CacheNamespaceSearch = "search"
+	CacheNamespaceRepos  = "repos"
+	CacheNamespaceOrgs   = "orgs"
+)
+
+// ResultCache stores API responses on disk with TTL-based expiry.
+// Each entry is a JSON file under <dir>/<namespace>/<hash>.json.
+type ResultCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// CacheStats summarizes the contents of the on-disk cache
+type CacheStats struct {
+	Directory      string         `json:"directory"`
+	TotalEntries   int            `json:"total_entries"`
+	ExpiredEntries int            `json:"expired_entries"`
+	TotalBytes     int64          `json:"total_bytes"`
+	ByNamespace    map[string]int `json:"by_namespace"`
+	Oldest         time.Time      `json:"oldest,omitempty"`
+	Newest         time.Time      `json:"newest,omitempty"`
+}
+
+// cacheEntry is the on-disk representation of a cached value
+type cacheEntry struct {
+	Key       string          `json:"key"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewResultCache creates a cache rooted at dir with the given entry lifetime
+func NewResultCache(dir string, ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Dir returns the cache's root directory
+func (c *ResultCache) Dir() string {
+	return c.dir
+}
+
+// Get loads a cached value into v. It returns false if the entry is missing or expired.
+func (c *ResultCache) Get(namespace, key string, v interface{}) (bool, error) {
+	entry, err := c.readEntry(c.entryPath(namespace, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if entry.Key != key || !c.now().Before(entry.ExpiresAt) {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(entry.Data, v); err != nil {
+		return false, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return true, nil
+}
+
+// Put stores v under the given key, replacing any existing entry
+func (c *ResultCache) Put(namespace, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	now := c.now()
+	entry := cacheEntry{
+		Key:       key,
+		CreatedAt: now,
+		ExpiresAt: now.Add(c.ttl),
+		Data:      data,
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	path := c.entryPath(namespace, key)
+	// Use restrictive permissions, cached results may come from private repositories
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file and rename so readers never see partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(encoded); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Stats reports entry counts and disk usage for the cache
+func (c *ResultCache) Stats() (*CacheStats, error) {
+	stats := &CacheStats{
+		Directory:   c.dir,
+		ByNamespace: make(map[string]int),
+	}
+
+	now := c.now()
+	err := c.walkEntries(func(namespace, path string, info os.FileInfo) error {
+		entry, err := c.readEntry(path)
+		if err != nil {
+			// Unreadable entries count as expired so prune removes them
+			stats.ExpiredEntries++
+			stats.TotalEntries++
+			stats.TotalBytes += info.Size()
+			return nil
+		}
+
+		stats.TotalEntries++
+		stats.TotalBytes += info.Size()
+		stats.ByNamespace[namespace]++
+
+		if !now.Before(entry.ExpiresAt) {
+			stats.ExpiredEntries++
+		}
+		if stats.Oldest.IsZero() || entry.CreatedAt.Before(stats.Oldest) {
+			stats.Oldest = entry.CreatedAt
+		}
+		if entry.CreatedAt.After(stats.Newest) {
+			stats.Newest = entry.CreatedAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// Prune removes expired and unreadable entries, returning how many were removed
+func (c *ResultCache) Prune() (int, error) {
+	removed := 0
+	now := c.now()
+
+	err := c.walkEntries(func(namespace, path string, info os.FileInfo) error {
+		entry, err := c.readEntry(path)
+		if err == nil && now.Before(entry.ExpiresAt) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove cache entry %s: %w", path, err)
+		}
+		removed++
+		return nil
+	})
+
+	return removed, err
+}
+
+// Clear removes every cache entry, returning how many were removed
+func (c *ResultCache) Clear() (int, error) {
+	removed := 0
+
+	err := c.walkEntries(func(namespace, path string, info os.FileInfo) error {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove cache entry %s: %w", path, err)
+		}
+		removed++
+		return nil
+	})
+
+	return removed, err
+}
+
+// walkEntries calls fn for every entry file in the cache
+func (c *ResultCache) walkEntries(fn func(namespace, path string, info os.FileInfo) error) error {
+	namespaces, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	for _, ns := range namespaces {
+		if !ns.IsDir() {
+			continue
+		}
+
+		nsDir := filepath.Join(c.dir, ns.Name())
+		files, err := os.ReadDir(nsDir)
+		if err != nil {
+			return fmt.Errorf("failed to read cache directory: %w", err)
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			info, err := file.Info()
+			if err != nil {
+				continue
+			}
+			if err := fn(ns.Name(), filepath.Join(nsDir, file.Name()), info); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readEntry reads and decodes a single entry file
+func (c *ResultCache) readEntry(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// entryPath maps a key to a file path; keys are hashed so any string is safe
+func (c *ResultCache) entryPath(namespace, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, namespace, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache_GetPut(t *testing.T) {
+	cache := NewResultCache(t.TempDir(), time.Hour)
+
+	var missing SearchResults
+	found, err := cache.Get(CacheNamespaceSearch, "missing", &missing)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	stored := CreateTestSearchResults(1, CreateTestSearchItem("owner/repo", "config.json", "{}"))
+	require.NoError(t, cache.Put(CacheNamespaceSearch, "key", stored))
+
+	var loaded SearchResults
+	found, err = cache.Get(CacheNamespaceSearch, "key", &loaded)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 1, *loaded.Total)
+	assert.Equal(t, "owner/repo", *loaded.Items[0].Repository.FullName)
+}
+
+func TestResultCache_Expiry(t *testing.T) {
+	cache := NewResultCache(t.TempDir(), time.Hour)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Put(CacheNamespaceSearch, "old", "value"))
+
+	// Advance past the TTL
+	cache.now = func() time.Time { return now.Add(2 * time.Hour) }
+	require.NoError(t, cache.Put(CacheNamespaceSearch, "new", "value"))
+
+	var value string
+	found, err := cache.Get(CacheNamespaceSearch, "old", &value)
+	require.NoError(t, err)
+	assert.False(t, found, "expired entries should not be returned")
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalEntries)
+	assert.Equal(t, 1, stats.ExpiredEntries)
+	assert.Equal(t, 2, stats.ByNamespace[CacheNamespaceSearch])
+	assert.Greater(t, stats.TotalBytes, int64(0))
+
+	removed, err := cache.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	found, err = cache.Get(CacheNamespaceSearch, "new", &value)
+	require.NoError(t, err)
+	assert.True(t, found, "fresh entries should survive prune")
+
+	removed, err = cache.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestResultCache_CorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewResultCache(dir, time.Hour)
+	require.NoError(t, cache.Put(CacheNamespaceSearch, "key", "value"))
+
+	// Corrupt the stored entry
+	path := cache.entryPath(CacheNamespaceSearch, "key")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+	var value string
+	found, err := cache.Get(CacheNamespaceSearch, "key", &value)
+	assert.Error(t, err)
+	assert.False(t, found)
+
+	removed, err := cache.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestResultCache_MissingDirectory(t *testing.T) {
+	cache := NewResultCache(filepath.Join(t.TempDir(), "does-not-exist"), time.Hour)
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.TotalEntries)
+
+	removed, err := cache.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
+
+func TestCachingClient_SearchCode(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetSearchResults("config", CreateTestSearchResults(1,
+		CreateTestSearchItem("owner/repo", "config.json", "{}"),
+	))
+	opts := &SearchOptions{ListOptions: ListOptions{Page: 1, PerPage: 10}}
+	cache := NewResultCache(t.TempDir(), time.Hour)
+
+	client := NewCachingClient(mock, cache, "github.com", false)
+
+	first, err := client.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+	second, err := client.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, mock.GetCallCount("SearchCode"), "second search should be served from cache")
+	assert.Equal(t, *first.Items[0].Path, *second.Items[0].Path)
+	assert.Equal(t, CacheUsage{Hits: 1, Misses: 1}, client.Usage())
+
+	// Different options are a different cache key
+	_, err = client.SearchCode(context.Background(), "config", &SearchOptions{ListOptions: ListOptions{Page: 2, PerPage: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, mock.GetCallCount("SearchCode"))
+
+	// Different hosts never share entries
+	enterprise := NewCachingClient(mock, cache, "github.example.com", false)
+	_, err = enterprise.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, mock.GetCallCount("SearchCode"))
+}
+
+func TestCachingClient_Refresh(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetSearchResults("config", CreateTestSearchResults(1,
+		CreateTestSearchItem("owner/repo", "config.json", "{}"),
+	))
+	opts := &SearchOptions{ListOptions: ListOptions{Page: 1, PerPage: 10}}
+	cache := NewResultCache(t.TempDir(), time.Hour)
+
+	refreshing := NewCachingClient(mock, cache, "github.com", true)
+	_, err := refreshing.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+	_, err = refreshing.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mock.GetCallCount("SearchCode"), "refresh should always hit the API")
+
+	// Fresh results written during refresh are served to normal clients
+	normal := NewCachingClient(mock, cache, "github.com", false)
+	_, err = normal.SearchCode(context.Background(), "config", opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mock.GetCallCount("SearchCode"))
+}
+
+func TestCachingClient_ErrorsNotCached(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetError("SearchCode", &RateLimitError{Message: "rate limit exceeded"})
+	client := NewCachingClient(mock, NewResultCache(t.TempDir(), time.Hour), "github.com", false)
+
+	_, err := client.SearchCode(context.Background(), "config", &SearchOptions{})
+	assert.Error(t, err)
+	_, err = client.SearchCode(context.Background(), "config", &SearchOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, 2, mock.GetCallCount("SearchCode"))
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// CachingClient decorates a GitHubAPI with an on-disk result cache.
+// Search results are keyed on host, query and search options. Repository
+// metadata is persisted by the enricher's shared RepoMetadataCache.
+type CachingClient struct {
+	client  GitHubAPI
+	cache   *ResultCache
+	host    string
+	refresh bool
+
+	mu     sync.Mutex
+	hits   int
+	misses int
+}
+
+// CacheUsage reports cache hits and misses for the lifetime of a client
+type CacheUsage struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+// NewCachingClient wraps client with the given cache. When refresh is true,
+// cached entries are ignored on read but fresh responses are still stored.
+func NewCachingClient(client GitHubAPI, cache *ResultCache, host string, refresh bool) *CachingClient {
+	return &CachingClient{
+		client:  client,
+		cache:   cache,
+		host:    host,
+		refresh: refresh,
+	}
+}
+
+// SearchCode implements GitHubAPI.SearchCode, serving fresh cache entries when available
+func (c *CachingClient) SearchCode(ctx context.Context, query string, opts *SearchOptions) (*SearchResults, error) {
+	key := c.searchKey(query, opts)
+
+	if !c.refresh {
+		var cached SearchResults
+		if found, err := c.cache.Get(CacheNamespaceSearch, key, &cached); err == nil && found {
+			c.recordHit()
+			return &cached, nil
+		}
+	}
+	c.recordMiss()
+
+	results, err := c.client.SearchCode(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache failures are never fatal, the search itself succeeded
+	_ = c.cache.Put(CacheNamespaceSearch, key, results)
+
+	return results, nil
+}
+
+// GetFileContent implements GitHubAPI.GetFileContent (not cached)
+func (c *CachingClient) GetFileContent(ctx context.Context, owner, repo, path, ref string) ([]byte, error) {
+	return c.client.GetFileContent(ctx, owner, repo, path, ref)
+}
+
+// GetRateLimit implements GitHubAPI.GetRateLimit (never cached)
+func (c *CachingClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	return c.client.GetRateLimit(ctx)
+}
+
+// ListOrgRepositories implements GitHubAPI.ListOrgRepositories, caching organization listings
+func (c *CachingClient) ListOrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	key := repoCacheKey(c.host, org)
+
+	if !c.refresh {
+		var cached []Repository
+		if found, err := c.cache.Get(CacheNamespaceOrgs, key, &cached); err == nil && found {
+			c.recordHit()
+			return cached, nil
+		}
+	}
+	c.recordMiss()
+
+	repos, err := c.client.ListOrgRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.cache.Put(CacheNamespaceOrgs, key, repos)
+	return repos, nil
+}
+
+// EnrichmentStats implements EnrichmentReporter by forwarding to the wrapped client
+func (c *CachingClient) EnrichmentStats() EnrichmentStats {
+	if reporter, ok := c.client.(EnrichmentReporter); ok {
+		return reporter.EnrichmentStats()
+	}
+	return EnrichmentStats{}
+}
+
+// Usage returns the cache hit and miss counts
+func (c *CachingClient) Usage() CacheUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheUsage{Hits: c.hits, Misses: c.misses}
+}
+
+// searchKey builds a stable cache key from the host, query and options
+func (c *CachingClient) searchKey(query string, opts *SearchOptions) string {
+	keyData := struct {
+		Host           string `json:"host"`
+		Query          string `json:"query"`
+		Sort           string `json:"sort,omitempty"`
+		Order          string `json:"order,omitempty"`
+		Page           int    `json:"page"`
+		PerPage        int    `json:"per_page"`
+		SkipEnrichment bool   `json:"skip_enrichment,omitempty"`
+	}{
+		Host:  c.host,
+		Query: query,
+	}
+	if opts != nil {
+		keyData.Sort = opts.Sort
+		keyData.Order = opts.Order
+		keyData.Page = opts.ListOptions.Page
+		keyData.PerPage = opts.ListOptions.PerPage
+		keyData.SkipEnrichment = opts.SkipEnrichment
+	}
+
+	data, _ := json.Marshal(keyData)
+	return string(data)
+}
+
+func (c *CachingClient) recordHit() {
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+}
+
+func (c *CachingClient) recordMiss() {
+	c.mu.Lock()
+	c.misses++
+	c.mu.Unlock()
+}
+
+// repositoryFullName returns owner/name, falling back to the individual fields
+func repositoryFullName(repo *Repository) string {
+	if repo.FullName != nil && *repo.FullName != "" {
+		return *repo.FullName
+	}
+	if owner, name := repo.GetOwnerLogin(), repo.GetName(); owner != "" && name != "" {
+		return owner + "/" + name
+	}
+	return ""
+}
@@ -10,6 +10,7 @@ type GitHubAPI interface {
 	SearchCode(ctx context.Context, query string, opts *SearchOptions) (*SearchResults, error)
 	GetFileContent(ctx context.Context, owner, repo, path, ref string) ([]byte, error)
 	GetRateLimit(ctx context.Context) (*RateLimit, error)
+	ListOrgRepositories(ctx context.Context, org string) ([]Repository, error)
 }
 
 // SearchOptions configures search requests
@@ -44,6 +45,20 @@ type SearchItem struct {
 	Repository  Repository  `json:"repository,omitempty"`
 	Score       *float64    `json:"score,omitempty"`
 	TextMatches []TextMatch `json:"text_matches,omitempty"`
+
+	// Content is populated by FetchFileContents (--fetch-content)
+	Content *FileContent `json:"content,omitempty"`
+
+	// Duplicates lists copies of this file collapsed into it by --dedupe
+	Duplicates []Duplicate `json:"duplicates,omitempty"`
+}
+
+// Duplicate is a copy of a result's file found in another repository or path
+type Duplicate struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Stars      int    `json:"stars"`
+	HTMLURL    string `json:"html_url,omitempty"`
 }
 
 // Repository represents a GitHub repository
@@ -57,6 +72,8 @@ type Repository struct {
 	HTMLURL         *string    `json:"html_url,omitempty"`
 	Description     *string    `json:"description,omitempty"`
 	Fork            *bool      `json:"fork,omitempty"`
+	Archived        *bool      `json:"archived,omitempty"`
+	Topics          []string   `json:"topics,omitempty"`
 	CreatedAt       *time.Time `json:"created_at,omitempty"`
 	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
 	PushedAt        *time.Time `json:"pushed_at,omitempty"`
@@ -65,6 +82,14 @@ type Repository struct {
 	ForksCount      *int       `json:"forks_count,omitempty"`
 	Language        *string    `json:"language,omitempty"`
 	DefaultBranch   *string    `json:"default_branch,omitempty"`
+	License         *License   `json:"license,omitempty"`
+}
+
+// License represents a repository's detected license
+type License struct {
+	Key    *string `json:"key,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	SPDXID *string `json:"spdx_id,omitempty"`
 }
 
 // User represents a GitHub user or organization
@@ -92,13 +117,37 @@ type Match struct {
 	Indices []int   `json:"indices,omitempty"`
 }
 
-// RateLimit represents GitHub API rate limiting information
+// RateLimit represents GitHub API rate limiting information. Limit,
+// Remaining and Reset describe the search quota; Resources holds every
+// quota GitHub reports, keyed by resource (core, search, code_search, ...).
 type RateLimit struct {
+	Limit     int                          `json:"limit"`
+	Remaining int                          `json:"remaining"`
+	Reset     time.Time                    `json:"reset"`
+	Disabled  bool                         `json:"disabled,omitempty"` // rate limiting is turned off, as GitHub Enterprise Server allows
+	Resources map[string]ResourceRateLimit `json:"resources,omitempty"`
+}
+
+// ResourceRateLimit is the quota of one rate limit resource
+type ResourceRateLimit struct {
 	Limit     int       `json:"limit"`
 	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
 	Reset     time.Time `json:"reset"`
 }
 
+// Resource returns the quota of resource. The search quota falls back to
+// the top-level fields for rate limits built without Resources.
+func (r *RateLimit) Resource(resource string) (ResourceRateLimit, bool) {
+	if limit, ok := r.Resources[resource]; ok {
+		return limit, true
+	}
+	if resource == ResourceSearch && r.Limit > 0 {
+		return ResourceRateLimit{Limit: r.Limit, Remaining: r.Remaining, Used: r.Limit - r.Remaining, Reset: r.Reset}, true
+	}
+	return ResourceRateLimit{}, false
+}
+
 // Helper methods for Repository
 func (r *Repository) GetOwnerLogin() string {
 	if r.Owner != nil && r.Owner.Login != nil {
@@ -114,6 +163,23 @@ func (r *Repository) GetName() string {
 	return ""
 }
 
+// DuplicateRepositories returns the distinct repositories holding collapsed
+// copies of the item's file, excluding the item's own repository
+func (i *SearchItem) DuplicateRepositories() []string {
+	var repositories []string
+	seen := map[string]bool{}
+	if i.Repository.FullName != nil {
+		seen[*i.Repository.FullName] = true
+	}
+	for _, duplicate := range i.Duplicates {
+		if !seen[duplicate.Repository] {
+			seen[duplicate.Repository] = true
+			repositories = append(repositories, duplicate.Repository)
+		}
+	}
+	return repositories
+}
+
 // Helper functions for pointer conversion
 func IntPtr(i int) *int {
 	return &i
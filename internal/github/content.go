@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultContentWorkers bounds concurrent file content requests
+const DefaultContentWorkers = 4
+
+// maxSnippetsPerFile caps how many context windows are kept for a single file
+const maxSnippetsPerFile = 5
+
+// FileContent holds context snippets extracted from a fetched file
+type FileContent struct {
+	Ref        string           `json:"ref,omitempty"`
+	TotalLines int              `json:"total_lines"`
+	Snippets   []ContentSnippet `json:"snippets,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// ContentSnippet is a window of lines around one or more matches
+type ContentSnippet struct {
+	StartLine int           `json:"start_line"`
+	EndLine   int           `json:"end_line"`
+	Lines     []ContentLine `json:"lines"`
+}
+
+// ContentLine is a single numbered line of a fetched file
+type ContentLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+	Match  bool   `json:"match,omitempty"`
+}
+
+// ContentFetchOptions configures FetchFileContents
+type ContentFetchOptions struct {
+	ContextLines int          // lines of context before and after each match
+	Terms        []string     // search terms used when results carry no match text
+	Workers      int          // maximum concurrent requests (DefaultContentWorkers if zero)
+	RateLimiter  *RateLimiter // retries rate-limited requests (NewRateLimiter if nil)
+}
+
+// ContentFetchStats reports how many files were fetched
+type ContentFetchStats struct {
+	Fetched int
+	Failed  int
+}
+
+// FetchFileContents downloads each result's file at the result's ref and
+// attaches context snippets around the matches. Failures are recorded on
+// the individual item so the remaining results are still usable.
+func FetchFileContents(ctx context.Context, client GitHubAPI, results *SearchResults, opts ContentFetchOptions) ContentFetchStats {
+	var stats ContentFetchStats
+	if results == nil || len(results.Items) == 0 {
+		return stats
+	}
+
+	rateLimiter := opts.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewRateLimiter()
+	}
+
+	var mu sync.Mutex
+	forEachItem(ctx, len(results.Items), opts.Workers, func(i int) {
+		item := &results.Items[i]
+		content := fetchItemContent(ctx, client, rateLimiter, item, opts)
+		item.Content = content
+
+		mu.Lock()
+		if content.Error != "" {
+			stats.Failed++
+		} else {
+			stats.Fetched++
+		}
+		mu.Unlock()
+	})
+
+	return stats
+}
+
+// FileData is the complete content of one search result's file
+type FileData struct {
+	Item *SearchItem
+	Data []byte
+	Err  error
+}
+
+// FetchFileData downloads each result's complete file at the result's ref,
+// for callers that parse whole files rather than show snippets. Entries
+// follow the order of results.Items; items never fetched because ctx was
+// cancelled carry ctx's error.
+func FetchFileData(ctx context.Context, client GitHubAPI, results *SearchResults, opts ContentFetchOptions) []FileData {
+	if results == nil || len(results.Items) == 0 {
+		return nil
+	}
+
+	rateLimiter := opts.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewRateLimiter()
+	}
+
+	files := make([]FileData, len(results.Items))
+	for i := range files {
+		files[i] = FileData{Item: &results.Items[i]}
+	}
+	forEachItem(ctx, len(results.Items), opts.Workers, func(i int) {
+		_, files[i].Data, files[i].Err = fetchItemData(ctx, client, rateLimiter, files[i].Item)
+	})
+
+	for i := range files {
+		if files[i].Data == nil && files[i].Err == nil {
+			files[i].Err = ctx.Err()
+		}
+	}
+	return files
+}
+
+// forEachItem calls fn for indexes 0..n-1 from at most workers goroutines
+// (DefaultContentWorkers if zero), stopping early when ctx is cancelled
+func forEachItem(ctx context.Context, n, workers int, fn func(i int)) {
+	if workers <= 0 {
+		workers = DefaultContentWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() == nil {
+					fn(i)
+				}
+			}
+		}()
+	}
+
+sendLoop:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break sendLoop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// fetchItemContent fetches a single result's file and extracts snippets
+func fetchItemContent(ctx context.Context, client GitHubAPI, rateLimiter *RateLimiter, item *SearchItem, opts ContentFetchOptions) *FileContent {
+	ref, data, err := fetchItemData(ctx, client, rateLimiter, item)
+	content := &FileContent{Ref: ref}
+	if err != nil {
+		content.Error = err.Error()
+		return content
+	}
+
+	lines := splitLines(string(data))
+	content.TotalLines = len(lines)
+	content.Snippets = ExtractSnippets(lines, matchTerms(item, opts.Terms), opts.ContextLines)
+	return content
+}
+
+// fetchItemData downloads a single result's file at the result's ref
+func fetchItemData(ctx context.Context, client GitHubAPI, rateLimiter *RateLimiter, item *SearchItem) (string, []byte, error) {
+	ref := ItemRef(item)
+
+	fullName := repositoryFullName(&item.Repository)
+	owner, repo, ok := strings.Cut(fullName, "/")
+	if !ok || item.Path == nil {
+		return ref, nil, fmt.Errorf("missing repository or path")
+	}
+
+	var data []byte
+	err := rateLimiter.WithRetry(ctx, fmt.Sprintf("fetch %s/%s", fullName, *item.Path), func() error {
+		var fetchErr error
+		data, fetchErr = client.GetFileContent(ctx, owner, repo, *item.Path, ref)
+		return fetchErr
+	})
+	if err != nil {
+		return ref, nil, err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return ref, data, nil
+}
+
+// ExtractSnippets returns windows of contextLines lines around every line
+// containing one of terms (case-insensitive). Overlapping windows are merged.
+func ExtractSnippets(lines []string, terms []string, contextLines int) []ContentSnippet {
+	if contextLines < 0 {
+		contextLines = 0
+	}
+
+	var needles []string
+	for _, term := range terms {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" {
+			needles = append(needles, term)
+		}
+	}
+
+	matches := make([]bool, len(lines))
+	var matchIdx []int
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		for _, needle := range needles {
+			if strings.Contains(lower, needle) {
+				matches[i] = true
+				matchIdx = append(matchIdx, i)
+				break
+			}
+		}
+	}
+
+	var snippets []ContentSnippet
+	for _, idx := range matchIdx {
+		start := max(idx-contextLines, 0)
+		end := min(idx+contextLines, len(lines)-1)
+
+		// Merge with the previous window when they overlap or touch
+		if n := len(snippets); n > 0 && start <= snippets[n-1].EndLine {
+			last := &snippets[n-1]
+			for i := last.EndLine; i <= end; i++ {
+				last.Lines = append(last.Lines, ContentLine{Number: i + 1, Text: lines[i], Match: matches[i]})
+			}
+			last.EndLine = max(last.EndLine, end+1)
+			continue
+		}
+
+		if len(snippets) == maxSnippetsPerFile {
+			break
+		}
+
+		snippet := ContentSnippet{StartLine: start + 1, EndLine: end + 1}
+		for i := start; i <= end; i++ {
+			snippet.Lines = append(snippet.Lines, ContentLine{Number: i + 1, Text: lines[i], Match: matches[i]})
+		}
+		snippets = append(snippets, snippet)
+	}
+
+	return snippets
+}
+
+// ItemRef returns the git ref a search result was indexed at. The API URL
+// carries the commit as ?ref=, the HTML URL as /blob/<ref>/.
+func ItemRef(item *SearchItem) string {
+	if item.URL != nil {
+		if parsed, err := url.Parse(*item.URL); err == nil {
+			if ref := parsed.Query().Get("ref"); ref != "" {
+				return ref
+			}
+		}
+	}
+	if item.HTMLURL != nil {
+		if _, rest, ok := strings.Cut(*item.HTMLURL, "/blob/"); ok {
+			if ref, _, ok := strings.Cut(rest, "/"); ok {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+// matchTerms returns the strings to locate in a fetched file, preferring
+// GitHub's own match text over the original search terms
+func matchTerms(item *SearchItem, fallback []string) []string {
+	var terms []string
+	for _, textMatch := range item.TextMatches {
+		for _, match := range textMatch.Matches {
+			if match.Text != nil && *match.Text != "" {
+				terms = append(terms, *match.Text)
+			}
+		}
+	}
+	if len(terms) > 0 {
+		return terms
+	}
+	if len(fallback) > 0 {
+		return fallback
+	}
+
+	// Last resort: anchor on the first non-blank line of each fragment
+	for _, textMatch := range item.TextMatches {
+		if textMatch.Fragment == nil {
+			continue
+		}
+		for _, line := range strings.Split(*textMatch.Fragment, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				terms = append(terms, line)
+				break
+			}
+		}
+	}
+	return terms
+}
+
+// splitLines splits file content into lines, dropping the final newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	content = strings.TrimSuffix(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	return strings.Split(content, "\n")
+}
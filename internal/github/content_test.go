@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func numberedLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return lines
+}
+
+func TestExtractSnippets(t *testing.T) {
+	tests := []struct {
+		name         string
+		lines        []string
+		terms        []string
+		contextLines int
+		expected     [][2]int // start/end line pairs
+	}{
+		{
+			name:         "single match with context",
+			lines:        numberedLines(20),
+			terms:        []string{"line 10"},
+			contextLines: 2,
+			expected:     [][2]int{{8, 12}},
+		},
+		{
+			name:         "context clamped to file bounds",
+			lines:        numberedLines(5),
+			terms:        []string{"line 1"},
+			contextLines: 3,
+			expected:     [][2]int{{1, 4}},
+		},
+		{
+			name:         "overlapping windows are merged",
+			lines:        numberedLines(30),
+			terms:        []string{"line 10", "line 13"},
+			contextLines: 2,
+			expected:     [][2]int{{8, 15}},
+		},
+		{
+			name:         "separate windows stay separate",
+			lines:        numberedLines(30),
+			terms:        []string{"line 5", "line 25"},
+			contextLines: 1,
+			expected:     [][2]int{{4, 6}, {24, 26}},
+		},
+		{
+			name:         "case-insensitive matching",
+			lines:        []string{"package main", "func Main() {}", "}"},
+			terms:        []string{"MAIN()"},
+			contextLines: 0,
+			expected:     [][2]int{{2, 2}},
+		},
+		{
+			name:         "no matches",
+			lines:        numberedLines(5),
+			terms:        []string{"missing"},
+			contextLines: 2,
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippets := ExtractSnippets(tt.lines, tt.terms, tt.contextLines)
+
+			var ranges [][2]int
+			for _, snippet := range snippets {
+				ranges = append(ranges, [2]int{snippet.StartLine, snippet.EndLine})
+				assert.Len(t, snippet.Lines, snippet.EndLine-snippet.StartLine+1)
+				for i, line := range snippet.Lines {
+					assert.Equal(t, snippet.StartLine+i, line.Number)
+					assert.Equal(t, tt.lines[line.Number-1], line.Text)
+				}
+			}
+			assert.Equal(t, tt.expected, ranges)
+		})
+	}
+}
+
+func TestExtractSnippets_MarksMatchesAndCapsSnippets(t *testing.T) {
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("L%03d", i+1)
+	}
+	var terms []string
+	for i := 1; i <= 100; i += 10 {
+		terms = append(terms, fmt.Sprintf("L%03d", i))
+	}
+
+	snippets := ExtractSnippets(lines, terms, 1)
+	assert.Len(t, snippets, maxSnippetsPerFile)
+
+	first := snippets[0]
+	require.Len(t, first.Lines, 2)
+	assert.True(t, first.Lines[0].Match)
+	assert.False(t, first.Lines[1].Match)
+}
+
+func TestItemRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     SearchItem
+		expected string
+	}{
+		{
+			name: "ref from API URL",
+			item: SearchItem{
+				URL:     StringPtr("https://api.github.com/repositories/1/contents/src/app.ts?ref=abc123"),
+				HTMLURL: StringPtr("https://github.com/owner/repo/blob/def456/src/app.ts"),
+			},
+			expected: "abc123",
+		},
+		{
+			name:     "ref from HTML URL",
+			item:     SearchItem{HTMLURL: StringPtr("https://github.com/owner/repo/blob/def456/src/app.ts")},
+			expected: "def456",
+		},
+		{
+			name:     "no ref available",
+			item:     SearchItem{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ItemRef(&tt.item))
+		})
+	}
+}
+
+func TestMatchTerms(t *testing.T) {
+	withMatches := CreateTestSearchItem("owner/repo", "main.go", "func main() {\n}")
+	withMatches.TextMatches[0].Matches = []Match{{Text: StringPtr("main")}}
+	assert.Equal(t, []string{"main"}, matchTerms(&withMatches, []string{"fallback"}))
+
+	withoutMatches := CreateTestSearchItem("owner/repo", "main.go", "\nfunc main() {\n}")
+	assert.Equal(t, []string{"fallback"}, matchTerms(&withoutMatches, []string{"fallback"}))
+	assert.Equal(t, []string{"func main() {"}, matchTerms(&withoutMatches, nil))
+}
+
+func TestFetchFileContents(t *testing.T) {
+	mock := NewMockClient()
+	file := strings.Join([]string{
+		"{",
+		`  "compilerOptions": {`,
+		`    "strict": true,`,
+		`    "target": "es2022"`,
+		"  }",
+		"}",
+	}, "\n") + "\n"
+	mock.SetFileContent("owner", "repo", "tsconfig.json", "main", []byte(file))
+
+	found := CreateTestSearchItem("owner/repo", "tsconfig.json", `"strict": true`)
+	found.TextMatches[0].Matches = []Match{{Text: StringPtr("strict")}}
+
+	broken := CreateTestSearchItem("other/repo", "tsconfig.json", "{}")
+	broken.Path = nil
+
+	results := CreateTestSearchResults(2, found, broken)
+	stats := FetchFileContents(context.Background(), mock, results, ContentFetchOptions{ContextLines: 1})
+
+	assert.Equal(t, ContentFetchStats{Fetched: 1, Failed: 1}, stats)
+	assert.Equal(t, 1, mock.GetCallCount("GetFileContent"))
+	assert.True(t, mock.VerifyCall("GetFileContent", "owner", "repo", "tsconfig.json", "main"))
+
+	content := results.Items[0].Content
+	require.NotNil(t, content)
+	assert.Equal(t, "main", content.Ref)
+	assert.Equal(t, 6, content.TotalLines)
+	require.Len(t, content.Snippets, 1)
+	assert.Equal(t, 2, content.Snippets[0].StartLine)
+	assert.Equal(t, 4, content.Snippets[0].EndLine)
+	assert.True(t, content.Snippets[0].Lines[1].Match)
+
+	require.NotNil(t, results.Items[1].Content)
+	assert.NotEmpty(t, results.Items[1].Content.Error)
+}
+
+func TestFetchFileContents_Errors(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetError("GetFileContent", &NotFoundError{Message: "Not Found"})
+
+	results := CreateTestSearchResults(1, CreateTestSearchItem("owner/repo", "missing.json", "{}"))
+	stats := FetchFileContents(context.Background(), mock, results, ContentFetchOptions{})
+
+	assert.Equal(t, 1, stats.Failed)
+	require.NotNil(t, results.Items[0].Content)
+	assert.Contains(t, results.Items[0].Content.Error, "Not Found")
+	assert.Equal(t, 1, mock.GetCallCount("GetFileContent"), "not found errors should not be retried")
+}
+
+func TestFetchFileData(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetFileContent("owner", "repo", "tsconfig.json", "main", []byte(`{"compilerOptions": {"strict": true}}`))
+
+	broken := CreateTestSearchItem("other/repo", "tsconfig.json", "{}")
+	broken.Path = nil
+
+	results := CreateTestSearchResults(2, CreateTestSearchItem("owner/repo", "tsconfig.json", "strict"), broken)
+	files := FetchFileData(context.Background(), mock, results, ContentFetchOptions{})
+
+	require.Len(t, files, 2)
+	assert.Same(t, &results.Items[0], files[0].Item)
+	assert.NoError(t, files[0].Err)
+	assert.Equal(t, `{"compilerOptions": {"strict": true}}`, string(files[0].Data))
+	assert.ErrorContains(t, files[1].Err, "missing repository or path")
+	assert.Nil(t, results.Items[0].Content, "raw fetches leave snippets alone")
+}
+
+func TestFetchFileData_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := CreateTestSearchResults(1, CreateTestSearchItem("owner/repo", "tsconfig.json", "{}"))
+	files := FetchFileData(ctx, NewMockClient(), results, ContentFetchOptions{})
+
+	require.Len(t, files, 1)
+	assert.ErrorIs(t, files[0].Err, context.Canceled)
+}
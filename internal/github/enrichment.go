@@ -0,0 +1,532 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultEnrichmentWorkers bounds concurrent repository metadata requests
+const DefaultEnrichmentWorkers = 8
+
+// RepositoryFetcher retrieves metadata for a repository full name (owner/repo)
+type RepositoryFetcher func(ctx context.Context, fullName string) (*Repository, error)
+
+// RepositoryBatchFetcher retrieves metadata for several repositories in one
+// request, keyed by lowercase full name. Repositories missing from a
+// successful result do not exist or are not visible; after an error, the
+// missing ones can still be fetched another way.
+type RepositoryBatchFetcher func(ctx context.Context, fullNames []string) (map[string]*Repository, error)
+
+// errRepositoryUnresolved marks a repository a batch request could not load
+var errRepositoryUnresolved = errors.New("repository could not be resolved")
+
+// EnrichmentStats reports the cost of repository metadata enrichment
+type EnrichmentStats struct {
+	Repositories int           `json:"repositories"` // unique repositories needing metadata
+	APIRequests  int           `json:"api_requests"` // metadata requests sent to GitHub
+	CacheHits    int           `json:"cache_hits"`   // served from the memory or disk cache
+	Coalesced    int           `json:"coalesced"`    // joined a request already in flight
+	Failures     int           `json:"failures"`
+	Fallbacks    int           `json:"fallbacks"` // fetched one at a time after a batch request failed
+	Duration     time.Duration `json:"duration"`
+}
+
+// Sub returns the difference between two snapshots of cumulative stats
+func (s EnrichmentStats) Sub(prev EnrichmentStats) EnrichmentStats {
+	return EnrichmentStats{
+		Repositories: s.Repositories - prev.Repositories,
+		APIRequests:  s.APIRequests - prev.APIRequests,
+		CacheHits:    s.CacheHits - prev.CacheHits,
+		Coalesced:    s.Coalesced - prev.Coalesced,
+		Failures:     s.Failures - prev.Failures,
+		Fallbacks:    s.Fallbacks - prev.Fallbacks,
+		Duration:     s.Duration - prev.Duration,
+	}
+}
+
+// EnrichmentReporter is implemented by clients that enrich repository metadata
+type EnrichmentReporter interface {
+	EnrichmentStats() EnrichmentStats
+}
+
+// RepoMetadataCache is a concurrency-safe store of repository metadata.
+// Concurrent lookups for the same repository share a single request, and
+// entries can optionally be persisted to an on-disk ResultCache.
+type RepoMetadataCache struct {
+	mu       sync.Mutex
+	repos    map[string]*Repository
+	inflight map[string]*repoCall
+	store    *ResultCache
+	refresh  bool
+}
+
+// repoCall is a metadata request other lookups can wait on
+type repoCall struct {
+	done chan struct{}
+	repo *Repository
+	err  error
+}
+
+// lookupOutcome describes how a metadata lookup was satisfied
+type lookupOutcome int
+
+const (
+	lookupFetched lookupOutcome = iota
+	lookupCached
+	lookupCoalesced
+)
+
+// sharedRepoMetadata is shared by every client in the process so metadata
+// survives across pages, searches and batch entries
+var sharedRepoMetadata = NewRepoMetadataCache()
+
+// NewRepoMetadataCache creates an empty in-memory metadata cache
+func NewRepoMetadataCache() *RepoMetadataCache {
+	return &RepoMetadataCache{
+		repos:    make(map[string]*Repository),
+		inflight: make(map[string]*repoCall),
+	}
+}
+
+// SharedRepoMetadataCache returns the process-wide repository metadata cache
+func SharedRepoMetadataCache() *RepoMetadataCache {
+	return sharedRepoMetadata
+}
+
+// SetStore persists metadata to store. When refresh is true, stored entries
+// are ignored on read but fresh metadata is still written.
+func (c *RepoMetadataCache) SetStore(store *ResultCache, refresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+	c.refresh = refresh
+}
+
+// Len returns the number of repositories held in memory
+func (c *RepoMetadataCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.repos)
+}
+
+// put stores metadata for key in memory
+func (c *RepoMetadataCache) put(key string, repo *Repository) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.repos[key] = repo
+}
+
+// load returns metadata for key, calling fetch only if no cached or in-flight result exists
+func (c *RepoMetadataCache) load(ctx context.Context, key string, fetch func() (*Repository, error)) (*Repository, lookupOutcome, error) {
+	c.mu.Lock()
+	if repo, ok := c.repos[key]; ok {
+		c.mu.Unlock()
+		return repo, lookupCached, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.repo, lookupCoalesced, call.err
+		case <-ctx.Done():
+			return nil, lookupCoalesced, ctx.Err()
+		}
+	}
+
+	call := &repoCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	store, refresh := c.store, c.refresh
+	c.mu.Unlock()
+
+	outcome := lookupFetched
+	if store != nil && !refresh {
+		var stored Repository
+		if found, err := store.Get(CacheNamespaceRepos, key, &stored); err == nil && found {
+			call.repo = &stored
+			outcome = lookupCached
+		}
+	}
+	if call.repo == nil {
+		call.repo, call.err = fetch()
+		if call.err == nil && store != nil {
+			// Persistence failures are never fatal
+			_ = store.Put(CacheNamespaceRepos, key, call.repo)
+		}
+	}
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	// Failures are not cached so a later search can retry them
+	if call.err == nil {
+		c.repos[key] = call.repo
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.repo, outcome, call.err
+}
+
+// lookupResult is the outcome of one key in a batch lookup
+type lookupResult struct {
+	repo    *Repository
+	outcome lookupOutcome
+	err     error
+}
+
+// loadBatch is load for many keys, calling fetch once with every key that
+// has no cached or in-flight result. Keys fetch leaves out fail.
+func (c *RepoMetadataCache) loadBatch(ctx context.Context, keys []string, fetch func(keys []string) map[string]*Repository) map[string]lookupResult {
+	results := make(map[string]lookupResult, len(keys))
+	waiting := make(map[string]*repoCall)
+	claimed := make(map[string]*repoCall)
+	var claimedKeys []string
+
+	c.mu.Lock()
+	for _, key := range keys {
+		if _, ok := results[key]; ok || waiting[key] != nil || claimed[key] != nil {
+			continue
+		}
+		if repo, ok := c.repos[key]; ok {
+			results[key] = lookupResult{repo: repo, outcome: lookupCached}
+			continue
+		}
+		if call, ok := c.inflight[key]; ok {
+			waiting[key] = call
+			continue
+		}
+		call := &repoCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		claimed[key] = call
+		claimedKeys = append(claimedKeys, key)
+	}
+	store, refresh := c.store, c.refresh
+	c.mu.Unlock()
+
+	var missing []string
+	for _, key := range claimedKeys {
+		if store != nil && !refresh {
+			var stored Repository
+			if found, err := store.Get(CacheNamespaceRepos, key, &stored); err == nil && found {
+				claimed[key].repo = &stored
+				results[key] = lookupResult{repo: &stored, outcome: lookupCached}
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+
+	if len(missing) > 0 {
+		fetched := fetch(missing)
+		for _, key := range missing {
+			call := claimed[key]
+			if repo := fetched[key]; repo != nil {
+				call.repo = repo
+				if store != nil {
+					// Persistence failures are never fatal
+					_ = store.Put(CacheNamespaceRepos, key, repo)
+				}
+			} else {
+				call.err = errRepositoryUnresolved
+			}
+			results[key] = lookupResult{repo: call.repo, outcome: lookupFetched, err: call.err}
+		}
+	}
+
+	c.mu.Lock()
+	for key, call := range claimed {
+		delete(c.inflight, key)
+		// Failures are not cached so a later search can retry them
+		if call.err == nil {
+			c.repos[key] = call.repo
+		}
+	}
+	c.mu.Unlock()
+	for _, call := range claimed {
+		close(call.done)
+	}
+
+	for key, call := range waiting {
+		select {
+		case <-call.done:
+			results[key] = lookupResult{repo: call.repo, outcome: lookupCoalesced, err: call.err}
+		case <-ctx.Done():
+			results[key] = lookupResult{outcome: lookupCoalesced, err: ctx.Err()}
+		}
+	}
+
+	return results
+}
+
+// RepoEnricher fills in repository metadata for search results using a
+// bounded pool of workers backed by a RepoMetadataCache
+type RepoEnricher struct {
+	cache   *RepoMetadataCache
+	host    string
+	workers int
+	fetch   RepositoryFetcher
+
+	batch     RepositoryBatchFetcher
+	batchSize int
+
+	mu    sync.Mutex
+	stats EnrichmentStats
+}
+
+// NewRepoEnricher creates an enricher that fetches at most workers repositories at once
+func NewRepoEnricher(cache *RepoMetadataCache, host string, workers int, fetch RepositoryFetcher) *RepoEnricher {
+	if workers < 1 {
+		workers = 1
+	}
+	return &RepoEnricher{
+		cache:   cache,
+		host:    host,
+		workers: workers,
+		fetch:   fetch,
+	}
+}
+
+// SetBatchFetcher makes the enricher load uncached repositories size at a
+// time through fetch. Repositories a failed batch request leaves unresolved
+// fall back to the per-repository fetcher. Call it before enriching.
+func (e *RepoEnricher) SetBatchFetcher(fetch RepositoryBatchFetcher, size int) {
+	e.batch = fetch
+	e.batchSize = max(size, 1)
+}
+
+// Enrich replaces repository data on items that are missing star counts.
+// Repositories that fail to load are left as returned by the search API.
+func (e *RepoEnricher) Enrich(ctx context.Context, results *SearchResults) {
+	if results == nil {
+		return
+	}
+
+	// Collect unique repositories that still need metadata
+	var names []string
+	seen := make(map[string]bool)
+	for i := range results.Items {
+		repo := &results.Items[i].Repository
+		fullName := repositoryFullName(repo)
+		if fullName == "" || repo.StargazersCount != nil || seen[strings.ToLower(fullName)] {
+			continue
+		}
+		seen[strings.ToLower(fullName)] = true
+		names = append(names, fullName)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	start := time.Now()
+	var enriched map[string]*Repository
+	if e.batch != nil {
+		enriched = e.fetchBatched(ctx, names)
+	} else {
+		enriched = e.fetchAll(ctx, names)
+	}
+
+	for i := range results.Items {
+		item := &results.Items[i]
+		if item.Repository.StargazersCount != nil {
+			continue
+		}
+		if repo, ok := enriched[strings.ToLower(repositoryFullName(&item.Repository))]; ok {
+			item.Repository = *repo
+		}
+	}
+
+	e.mu.Lock()
+	e.stats.Repositories += len(names)
+	e.stats.Duration += time.Since(start)
+	e.mu.Unlock()
+}
+
+// Seed stores metadata obtained elsewhere (e.g. a repository listing)
+func (e *RepoEnricher) Seed(repo *Repository) {
+	if fullName := repositoryFullName(repo); fullName != "" {
+		e.cache.put(repoCacheKey(e.host, fullName), repo)
+	}
+}
+
+// Stats returns cumulative enrichment stats for this enricher
+func (e *RepoEnricher) Stats() EnrichmentStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stats
+}
+
+// fetchAll loads metadata for names concurrently, keyed by lowercase full name
+func (e *RepoEnricher) fetchAll(ctx context.Context, names []string) map[string]*Repository {
+	return e.parallel(ctx, names, e.lookup)
+}
+
+// fetchBatched loads metadata for names through the shared cache and the
+// batch fetcher, keyed by lowercase full name
+func (e *RepoEnricher) fetchBatched(ctx context.Context, names []string) map[string]*Repository {
+	keys := make([]string, len(names))
+	fullNames := make(map[string]string, len(names))
+	for i, fullName := range names {
+		keys[i] = repoCacheKey(e.host, fullName)
+		fullNames[keys[i]] = fullName
+	}
+
+	results := e.cache.loadBatch(ctx, keys, func(missing []string) map[string]*Repository {
+		missingNames := make([]string, len(missing))
+		for i, key := range missing {
+			missingNames[i] = fullNames[key]
+		}
+		loaded := e.fetchMissing(ctx, missingNames)
+
+		fetched := make(map[string]*Repository, len(loaded))
+		for _, key := range missing {
+			if repo, ok := loaded[strings.ToLower(fullNames[key])]; ok {
+				fetched[key] = repo
+			}
+		}
+		return fetched
+	})
+
+	enriched := make(map[string]*Repository, len(results))
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key, result := range results {
+		switch result.outcome {
+		case lookupCached:
+			e.stats.CacheHits++
+		case lookupCoalesced:
+			e.stats.Coalesced++
+		}
+		if result.err != nil {
+			e.stats.Failures++
+			continue
+		}
+		enriched[strings.ToLower(fullNames[key])] = result.repo
+	}
+	return enriched
+}
+
+// fetchMissing resolves names batchSize at a time, keyed by lowercase full
+// name, then fetches any a failed batch request left unresolved one by one
+func (e *RepoEnricher) fetchMissing(ctx context.Context, names []string) map[string]*Repository {
+	loaded := make(map[string]*Repository, len(names))
+	var retry []string
+	for chunk := range slices.Chunk(names, e.batchSize) {
+		if ctx.Err() != nil {
+			break
+		}
+
+		repos, err := e.batch(ctx, chunk)
+		e.mu.Lock()
+		e.stats.APIRequests++
+		e.mu.Unlock()
+
+		for _, fullName := range chunk {
+			key := strings.ToLower(fullName)
+			if repo := repos[key]; repo != nil {
+				loaded[key] = repo
+			} else if err != nil {
+				retry = append(retry, fullName)
+			}
+		}
+	}
+
+	if len(retry) > 0 {
+		e.mu.Lock()
+		e.stats.Fallbacks += len(retry)
+		e.mu.Unlock()
+		for key, repo := range e.parallel(ctx, retry, e.fetchOne) {
+			loaded[key] = repo
+		}
+	}
+	return loaded
+}
+
+// fetchOne fetches one repository, bypassing the cache
+func (e *RepoEnricher) fetchOne(ctx context.Context, fullName string) (*Repository, error) {
+	e.mu.Lock()
+	e.stats.APIRequests++
+	e.mu.Unlock()
+	return e.fetch(ctx, fullName)
+}
+
+// parallel calls fetch for names on the worker pool, keyed by lowercase full
+// name. Names that fail are left out.
+func (e *RepoEnricher) parallel(ctx context.Context, names []string, fetch RepositoryFetcher) map[string]*Repository {
+	jobs := make(chan string)
+	enriched := make(map[string]*Repository, len(names))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	workers := e.workers
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fullName := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				repo, err := fetch(ctx, fullName)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				enriched[strings.ToLower(fullName)] = repo
+				mu.Unlock()
+			}
+		}()
+	}
+
+sendLoop:
+	for _, fullName := range names {
+		select {
+		case jobs <- fullName:
+		case <-ctx.Done():
+			break sendLoop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return enriched
+}
+
+// lookup loads one repository through the shared cache and records the outcome
+func (e *RepoEnricher) lookup(ctx context.Context, fullName string) (*Repository, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo, outcome, err := e.cache.load(ctx, repoCacheKey(e.host, fullName), func() (*Repository, error) {
+		return e.fetch(ctx, fullName)
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch outcome {
+	case lookupFetched:
+		e.stats.APIRequests++
+	case lookupCached:
+		e.stats.CacheHits++
+	case lookupCoalesced:
+		e.stats.Coalesced++
+	}
+	if err != nil {
+		e.stats.Failures++
+		return nil, fmt.Errorf("failed to load repository %s: %w", fullName, err)
+	}
+	return repo, nil
+}
+
+// repoCacheKey builds the cache key for repository metadata on a host
+func repoCacheKey(host, fullName string) string {
+	return fmt.Sprintf("%s/%s", host, strings.ToLower(fullName))
+}
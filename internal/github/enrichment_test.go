@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFetcher counts requests and tracks peak concurrency
+type fakeFetcher struct {
+	delay   time.Duration
+	fail    map[string]bool
+	calls   atomic.Int32
+	active  atomic.Int32
+	peak    atomic.Int32
+	perRepo sync.Map
+}
+
+func (f *fakeFetcher) fetch(ctx context.Context, fullName string) (*Repository, error) {
+	f.calls.Add(1)
+	count, _ := f.perRepo.LoadOrStore(fullName, new(atomic.Int32))
+	count.(*atomic.Int32).Add(1)
+
+	active := f.active.Add(1)
+	defer f.active.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if active <= peak || f.peak.CompareAndSwap(peak, active) {
+			break
+		}
+	}
+
+	time.Sleep(f.delay)
+	if f.fail[fullName] {
+		return nil, &NotFoundError{Message: "Not Found"}
+	}
+
+	parts := strings.SplitN(fullName, "/", 2)
+	return &Repository{
+		FullName:        StringPtr(fullName),
+		Name:            StringPtr(parts[1]),
+		Owner:           &User{Login: StringPtr(parts[0])},
+		StargazersCount: IntPtr(len(fullName) * 100),
+	}, nil
+}
+
+// liteResults builds search results without star counts, one item per name
+func liteResults(names ...string) *SearchResults {
+	items := make([]SearchItem, 0, len(names))
+	for _, name := range names {
+		item := CreateTestSearchItem(name, "config.json", "{}")
+		item.Repository.StargazersCount = nil
+		items = append(items, item)
+	}
+	return CreateTestSearchResults(len(items), items...)
+}
+
+func TestRepoEnricher_DedupesAndBoundsConcurrency(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 20 * time.Millisecond}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 3, fetcher.fetch)
+
+	names := []string{"a/one", "a/two", "a/three", "b/one", "b/two", "b/three", "c/one", "a/one", "A/One"}
+	results := liteResults(names...)
+
+	enricher.Enrich(context.Background(), results)
+
+	assert.Equal(t, int32(7), fetcher.calls.Load(), "each unique repository should be fetched once")
+	assert.LessOrEqual(t, fetcher.peak.Load(), int32(3), "worker pool should bound concurrency")
+	assert.Greater(t, fetcher.peak.Load(), int32(1), "repositories should be fetched concurrently")
+
+	for _, item := range results.Items {
+		require.NotNil(t, item.Repository.StargazersCount, "%s should be enriched", *item.Repository.FullName)
+	}
+
+	stats := enricher.Stats()
+	assert.Equal(t, 7, stats.Repositories)
+	assert.Equal(t, 7, stats.APIRequests)
+	assert.Equal(t, 0, stats.Failures)
+	assert.Greater(t, stats.Duration, time.Duration(0))
+}
+
+func TestRepoEnricher_SharedCacheAcrossPages(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	cache := NewRepoMetadataCache()
+	first := NewRepoEnricher(cache, "github.com", 4, fetcher.fetch)
+	second := NewRepoEnricher(cache, "github.com", 4, fetcher.fetch)
+
+	first.Enrich(context.Background(), liteResults("owner/repo", "owner/other"))
+	second.Enrich(context.Background(), liteResults("owner/repo", "owner/third"))
+
+	assert.Equal(t, int32(3), fetcher.calls.Load())
+	assert.Equal(t, 3, cache.Len())
+	assert.Equal(t, 1, second.Stats().CacheHits)
+	assert.Equal(t, 1, second.Stats().APIRequests)
+
+	// Other hosts never share metadata
+	enterprise := NewRepoEnricher(cache, "github.example.com", 4, fetcher.fetch)
+	enterprise.Enrich(context.Background(), liteResults("owner/repo"))
+	assert.Equal(t, int32(4), fetcher.calls.Load())
+}
+
+func TestRepoEnricher_CoalescesInFlightRequests(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 50 * time.Millisecond}
+	cache := NewRepoMetadataCache()
+
+	var wg sync.WaitGroup
+	enrichers := make([]*RepoEnricher, 4)
+	for i := range enrichers {
+		enrichers[i] = NewRepoEnricher(cache, "github.com", 2, fetcher.fetch)
+		wg.Add(1)
+		go func(e *RepoEnricher) {
+			defer wg.Done()
+			e.Enrich(context.Background(), liteResults("owner/repo"))
+		}(enrichers[i])
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetcher.calls.Load(), "concurrent lookups should share one request")
+
+	total := EnrichmentStats{}
+	for _, e := range enrichers {
+		stats := e.Stats()
+		total.APIRequests += stats.APIRequests
+		total.Coalesced += stats.Coalesced
+		total.CacheHits += stats.CacheHits
+	}
+	assert.Equal(t, 1, total.APIRequests)
+	assert.Equal(t, 3, total.Coalesced+total.CacheHits)
+}
+
+func TestRepoEnricher_FailuresAreNotCached(t *testing.T) {
+	fetcher := &fakeFetcher{fail: map[string]bool{"owner/missing": true}}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 2, fetcher.fetch)
+
+	results := liteResults("owner/repo", "owner/missing")
+	enricher.Enrich(context.Background(), results)
+
+	assert.NotNil(t, results.Items[0].Repository.StargazersCount)
+	assert.Nil(t, results.Items[1].Repository.StargazersCount, "failed repositories are left unchanged")
+	assert.Equal(t, "owner/missing", *results.Items[1].Repository.FullName)
+	assert.Equal(t, 1, enricher.Stats().Failures)
+
+	enricher.Enrich(context.Background(), liteResults("owner/missing"))
+	assert.Equal(t, int32(3), fetcher.calls.Load(), "failed lookups should be retried")
+}
+
+func TestRepoEnricher_PersistentStore(t *testing.T) {
+	store := NewResultCache(t.TempDir(), time.Hour)
+
+	fetcher := &fakeFetcher{}
+	cache := NewRepoMetadataCache()
+	cache.SetStore(store, false)
+	NewRepoEnricher(cache, "github.com", 2, fetcher.fetch).Enrich(context.Background(), liteResults("owner/repo"))
+	require.Equal(t, int32(1), fetcher.calls.Load())
+
+	// A new process starts with an empty memory cache but reads from disk
+	restarted := NewRepoMetadataCache()
+	restarted.SetStore(store, false)
+	enricher := NewRepoEnricher(restarted, "github.com", 2, fetcher.fetch)
+	results := liteResults("owner/repo")
+	enricher.Enrich(context.Background(), results)
+
+	assert.Equal(t, int32(1), fetcher.calls.Load())
+	assert.Equal(t, 1, enricher.Stats().CacheHits)
+	assert.NotNil(t, results.Items[0].Repository.StargazersCount)
+
+	// Refresh ignores stored metadata
+	refreshed := NewRepoMetadataCache()
+	refreshed.SetStore(store, true)
+	NewRepoEnricher(refreshed, "github.com", 2, fetcher.fetch).Enrich(context.Background(), liteResults("owner/repo"))
+	assert.Equal(t, int32(2), fetcher.calls.Load())
+}
+
+func TestRepoEnricher_Cancellation(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 2, fetcher.fetch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := liteResults("owner/repo", "owner/other")
+	enricher.Enrich(ctx, results)
+
+	assert.Equal(t, int32(0), fetcher.calls.Load())
+	assert.Nil(t, results.Items[0].Repository.StargazersCount)
+}
+
+func TestEnrichmentStats_Sub(t *testing.T) {
+	before := EnrichmentStats{Repositories: 2, APIRequests: 2, Duration: time.Second}
+	after := EnrichmentStats{Repositories: 5, APIRequests: 3, CacheHits: 2, Failures: 1, Fallbacks: 1, Duration: 3 * time.Second}
+
+	assert.Equal(t, EnrichmentStats{
+		Repositories: 3,
+		APIRequests:  1,
+		CacheHits:    2,
+		Failures:     1,
+		Fallbacks:    1,
+		Duration:     2 * time.Second,
+	}, after.Sub(before))
+}
+
+func TestRepoEnricher_LookupError(t *testing.T) {
+	fetcher := &fakeFetcher{fail: map[string]bool{"owner/missing": true}}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 1, fetcher.fetch)
+
+	_, err := enricher.lookup(context.Background(), "owner/missing")
+	require.Error(t, err)
+	var notFound *NotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Contains(t, err.Error(), "owner/missing")
+}
+
+// fakeBatchFetcher resolves repositories in bulk, optionally failing
+type fakeBatchFetcher struct {
+	err     error
+	missing map[string]bool
+	calls   atomic.Int32
+	sizes   []int
+	mu      sync.Mutex
+}
+
+func (f *fakeBatchFetcher) fetch(ctx context.Context, fullNames []string) (map[string]*Repository, error) {
+	f.calls.Add(1)
+	f.mu.Lock()
+	f.sizes = append(f.sizes, len(fullNames))
+	f.mu.Unlock()
+
+	repos := make(map[string]*Repository)
+	for i, fullName := range fullNames {
+		// A failed request resolves only the first repository
+		if f.missing[fullName] || (f.err != nil && i > 0) {
+			continue
+		}
+		repos[strings.ToLower(fullName)] = &Repository{FullName: StringPtr(fullName), StargazersCount: IntPtr(42)}
+	}
+	return repos, f.err
+}
+
+func TestRepoEnricher_BatchFetcher(t *testing.T) {
+	rest := &fakeFetcher{}
+	batch := &fakeBatchFetcher{missing: map[string]bool{"owner/deleted": true}}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 2, rest.fetch)
+	enricher.SetBatchFetcher(batch.fetch, 2)
+
+	results := liteResults("owner/a", "owner/b", "owner/c", "owner/deleted", "OWNER/A")
+	enricher.Enrich(context.Background(), results)
+
+	assert.Equal(t, []int{2, 2}, batch.sizes, "four unique repositories in batches of two")
+	assert.Zero(t, rest.calls.Load(), "missing repositories are not retried over REST")
+	assert.Equal(t, 42, *results.Items[0].Repository.StargazersCount)
+	assert.Equal(t, 42, *results.Items[4].Repository.StargazersCount)
+	assert.Nil(t, results.Items[3].Repository.StargazersCount)
+
+	stats := enricher.Stats()
+	assert.Equal(t, 4, stats.Repositories)
+	assert.Equal(t, 2, stats.APIRequests)
+	assert.Equal(t, 1, stats.Failures)
+	assert.Zero(t, stats.Fallbacks)
+
+	// Resolved repositories are cached, the missing one is tried again
+	enricher.Enrich(context.Background(), liteResults("owner/a", "owner/deleted"))
+	assert.Equal(t, []int{2, 2, 1}, batch.sizes)
+	assert.Equal(t, 1, enricher.Stats().CacheHits)
+}
+
+func TestRepoEnricher_BatchFallsBackToREST(t *testing.T) {
+	rest := &fakeFetcher{}
+	batch := &fakeBatchFetcher{err: &APIError{Message: "GraphQL: Something went wrong"}}
+	enricher := NewRepoEnricher(NewRepoMetadataCache(), "github.com", 2, rest.fetch)
+	enricher.SetBatchFetcher(batch.fetch, MaxGraphQLRepositories)
+
+	results := liteResults("owner/a", "owner/b", "owner/c")
+	enricher.Enrich(context.Background(), results)
+
+	assert.Equal(t, int32(1), batch.calls.Load())
+	assert.Equal(t, int32(2), rest.calls.Load(), "repositories the failed query left unresolved use REST")
+	for _, item := range results.Items {
+		require.NotNil(t, item.Repository.StargazersCount, *item.Repository.FullName)
+	}
+
+	stats := enricher.Stats()
+	assert.Equal(t, 3, stats.APIRequests)
+	assert.Equal(t, 2, stats.Fallbacks)
+	assert.Zero(t, stats.Failures)
+}
+
+func TestRepoEnricher_BatchCoalescesWithSingleLookups(t *testing.T) {
+	rest := &fakeFetcher{delay: 50 * time.Millisecond}
+	cache := NewRepoMetadataCache()
+	single := NewRepoEnricher(cache, "github.com", 2, rest.fetch)
+
+	batch := &fakeBatchFetcher{}
+	bulk := NewRepoEnricher(cache, "github.com", 2, rest.fetch)
+	bulk.SetBatchFetcher(batch.fetch, MaxGraphQLRepositories)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		single.Enrich(context.Background(), liteResults("owner/slow"))
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	results := liteResults("owner/slow", "owner/fast")
+	bulk.Enrich(context.Background(), results)
+	<-done
+
+	assert.Equal(t, int32(1), rest.calls.Load())
+	assert.Equal(t, []int{1}, batch.sizes, "the in-flight repository is not requested again")
+	assert.Equal(t, 1, bulk.Stats().Coalesced)
+	assert.NotNil(t, results.Items[0].Repository.StargazersCount)
+}
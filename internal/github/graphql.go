@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// MaxGraphQLRepositories is how many repositories one GraphQL query resolves,
+// keeping queries well inside GitHub's node and complexity limits
+const MaxGraphQLRepositories = 100
+
+// repositoryFragment selects the metadata enrichment needs from a repository
+const repositoryFragment = `fragment repository on Repository {
+  databaseId
+  id
+  name
+  nameWithOwner
+  owner { login }
+  url
+  description
+  isFork
+  isArchived
+  isPrivate
+  stargazerCount
+  forkCount
+  watchers { totalCount }
+  createdAt
+  updatedAt
+  pushedAt
+  primaryLanguage { name }
+  defaultBranchRef { name }
+  licenseInfo { key name spdxId }
+  repositoryTopics(first: 20) { nodes { topic { name } } }
+}`
+
+// graphQLRepository is a repository as returned by repositoryFragment
+type graphQLRepository struct {
+	DatabaseID    *int64  `json:"databaseId"`
+	ID            *string `json:"id"`
+	Name          *string `json:"name"`
+	NameWithOwner *string `json:"nameWithOwner"`
+	Owner         *struct {
+		Login *string `json:"login"`
+	} `json:"owner"`
+	URL            *string    `json:"url"`
+	Description    *string    `json:"description"`
+	IsFork         *bool      `json:"isFork"`
+	IsArchived     *bool      `json:"isArchived"`
+	IsPrivate      *bool      `json:"isPrivate"`
+	StargazerCount *int       `json:"stargazerCount"`
+	ForkCount      *int       `json:"forkCount"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt"`
+	PushedAt       *time.Time `json:"pushedAt"`
+	Watchers       *struct {
+		TotalCount *int `json:"totalCount"`
+	} `json:"watchers"`
+	PrimaryLanguage *struct {
+		Name *string `json:"name"`
+	} `json:"primaryLanguage"`
+	DefaultBranchRef *struct {
+		Name *string `json:"name"`
+	} `json:"defaultBranchRef"`
+	LicenseInfo *struct {
+		Key    *string `json:"key"`
+		Name   *string `json:"name"`
+		SPDXID *string `json:"spdxId"`
+	} `json:"licenseInfo"`
+	RepositoryTopics *struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+}
+
+// toRepository converts GraphQL fields to the REST shape used everywhere else
+func (r *graphQLRepository) toRepository() *Repository {
+	repo := &Repository{
+		ID:              r.DatabaseID,
+		NodeID:          r.ID,
+		Name:            r.Name,
+		FullName:        r.NameWithOwner,
+		HTMLURL:         r.URL,
+		Description:     r.Description,
+		Private:         r.IsPrivate,
+		Fork:            r.IsFork,
+		Archived:        r.IsArchived,
+		StargazersCount: r.StargazerCount,
+		ForksCount:      r.ForkCount,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+		PushedAt:        r.PushedAt,
+	}
+	if r.Owner != nil {
+		repo.Owner = &User{Login: r.Owner.Login}
+	}
+	if r.Watchers != nil {
+		repo.WatchersCount = r.Watchers.TotalCount
+	}
+	if r.PrimaryLanguage != nil {
+		repo.Language = r.PrimaryLanguage.Name
+	}
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = r.DefaultBranchRef.Name
+	}
+	if r.LicenseInfo != nil {
+		repo.License = &License{Key: r.LicenseInfo.Key, Name: r.LicenseInfo.Name, SPDXID: r.LicenseInfo.SPDXID}
+	}
+	if r.RepositoryTopics != nil {
+		for _, node := range r.RepositoryTopics.Nodes {
+			repo.Topics = append(repo.Topics, node.Topic.Name)
+		}
+	}
+	return repo
+}
+
+// repositoriesQuery builds one query resolving every repository under an
+// alias, r0 for fullNames[0] and so on
+func repositoriesQuery(fullNames []string) (string, map[string]interface{}) {
+	params := make([]string, 0, len(fullNames))
+	variables := make(map[string]interface{}, 2*len(fullNames))
+	var fields strings.Builder
+	for i, fullName := range fullNames {
+		owner, name, _ := strings.Cut(fullName, "/")
+		variables[fmt.Sprintf("owner%d", i)] = owner
+		variables[fmt.Sprintf("name%d", i)] = name
+		params = append(params, fmt.Sprintf("$owner%d: String!, $name%d: String!", i, i))
+		fmt.Fprintf(&fields, "  r%d: repository(owner: $owner%d, name: $name%d) { ...repository }\n", i, i, i)
+	}
+
+	query := fmt.Sprintf("query Repositories(%s) {\n%s}\n%s", strings.Join(params, ", "), fields.String(), repositoryFragment)
+	return query, variables
+}
+
+// fetchRepositories implements RepositoryBatchFetcher over GraphQL. Missing
+// repositories are reported by GitHub as NOT_FOUND errors alongside the
+// others, so those alone do not fail the query.
+func (c *RealClient) fetchRepositories(ctx context.Context, fullNames []string) (map[string]*Repository, error) {
+	query, variables := repositoriesQuery(fullNames)
+
+	var response map[string]*graphQLRepository
+	err := c.graphql.DoWithContext(ctx, query, variables, &response)
+
+	repos := make(map[string]*Repository, len(fullNames))
+	for i, fullName := range fullNames {
+		if repo := response[fmt.Sprintf("r%d", i)]; repo != nil {
+			repos[strings.ToLower(fullName)] = repo.toRepository()
+		}
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) && onlyNotFound(gqlErr) {
+		return repos, nil
+	}
+	if err != nil {
+		return repos, formatGoGHError(err, c.host)
+	}
+	return repos, nil
+}
+
+// onlyNotFound reports whether every GraphQL error is a missing repository
+func onlyNotFound(err *api.GraphQLError) bool {
+	for _, item := range err.Errors {
+		if item.Type != "NOT_FOUND" {
+			return false
+		}
+	}
+	return true
+}
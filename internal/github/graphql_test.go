@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLTransport answers every GraphQL request with a canned response
+type graphQLTransport struct {
+	status   int
+	response string
+	requests []map[string]interface{}
+}
+
+func (t *graphQLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	t.requests = append(t.requests, body)
+
+	status := t.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(t.response)),
+		Request:    req,
+	}, nil
+}
+
+// newGraphQLTestClient creates a RealClient whose GraphQL requests go to transport
+func newGraphQLTestClient(t *testing.T, host string, transport *graphQLTransport) *RealClient {
+	t.Helper()
+	graphql, err := api.NewGraphQLClient(api.ClientOptions{Host: host, AuthToken: "token", Transport: transport})
+	require.NoError(t, err)
+	return &RealClient{graphql: graphql, host: host}
+}
+
+func TestRepositoriesQuery(t *testing.T) {
+	query, variables := repositoriesQuery([]string{"vercel/next.js", "facebook/react"})
+
+	assert.Contains(t, query, "query Repositories($owner0: String!, $name0: String!, $owner1: String!, $name1: String!)")
+	assert.Contains(t, query, "r0: repository(owner: $owner0, name: $name0) { ...repository }")
+	assert.Contains(t, query, "r1: repository(owner: $owner1, name: $name1) { ...repository }")
+	assert.Contains(t, query, "fragment repository on Repository")
+	assert.Equal(t, map[string]interface{}{
+		"owner0": "vercel", "name0": "next.js",
+		"owner1": "facebook", "name1": "react",
+	}, variables)
+}
+
+func TestRealClient_FetchRepositories(t *testing.T) {
+	transport := &graphQLTransport{response: `{"data": {
+		"r0": {
+			"databaseId": 70107786, "nameWithOwner": "vercel/next.js", "name": "next.js",
+			"owner": {"login": "vercel"}, "url": "https://github.com/vercel/next.js",
+			"isFork": false, "isArchived": false, "isPrivate": false,
+			"stargazerCount": 120000, "forkCount": 26000, "watchers": {"totalCount": 1500},
+			"pushedAt": "2025-05-30T12:00:00Z",
+			"primaryLanguage": {"name": "JavaScript"}, "defaultBranchRef": {"name": "canary"},
+			"licenseInfo": {"key": "mit", "name": "MIT License", "spdxId": "MIT"},
+			"repositoryTopics": {"nodes": [{"topic": {"name": "react"}}, {"topic": {"name": "nextjs"}}]}
+		},
+		"r1": null
+	}, "errors": [{"type": "NOT_FOUND", "path": ["r1"], "message": "Could not resolve to a Repository with the name 'gone/repo'."}]}`}
+	client := newGraphQLTestClient(t, "github.com", transport)
+
+	repos, err := client.fetchRepositories(context.Background(), []string{"Vercel/Next.js", "gone/repo"})
+	require.NoError(t, err, "missing repositories do not fail the query")
+	require.Len(t, transport.requests, 1)
+	require.Len(t, repos, 1)
+
+	repo := repos["vercel/next.js"]
+	require.NotNil(t, repo)
+	assert.Equal(t, "vercel/next.js", *repo.FullName)
+	assert.Equal(t, "vercel", repo.GetOwnerLogin())
+	assert.Equal(t, 120000, *repo.StargazersCount)
+	assert.Equal(t, 26000, *repo.ForksCount)
+	assert.Equal(t, 1500, *repo.WatchersCount)
+	assert.Equal(t, "JavaScript", *repo.Language)
+	assert.Equal(t, "canary", *repo.DefaultBranch)
+	assert.Equal(t, "MIT", *repo.License.SPDXID)
+	assert.Equal(t, []string{"react", "nextjs"}, repo.Topics)
+	assert.False(t, *repo.Archived)
+	assert.Equal(t, 2025, repo.PushedAt.Year())
+}
+
+func TestRealClient_FetchRepositoriesErrors(t *testing.T) {
+	// Other GraphQL errors fail the query but keep what was resolved
+	transport := &graphQLTransport{response: `{"data": {"r0": {"nameWithOwner": "owner/a", "stargazerCount": 5}, "r1": null},
+		"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`}
+	repos, err := newGraphQLTestClient(t, "github.com", transport).fetchRepositories(context.Background(), []string{"owner/a", "owner/b"})
+	require.Error(t, err)
+	assert.Len(t, repos, 1)
+
+	// HTTP errors report the host
+	transport = &graphQLTransport{status: http.StatusBadGateway, response: `{"message": "Bad Gateway"}`}
+	repos, err = newGraphQLTestClient(t, "ghe.example.com", transport).fetchRepositories(context.Background(), []string{"owner/a"})
+	require.Error(t, err)
+	assert.Empty(t, repos)
+	assert.Contains(t, err.Error(), "ghe.example.com")
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// DefaultHost is the hostname of github.com
+const DefaultHost = "github.com"
+
+// NormalizeHost turns a user-supplied host into the hostname go-gh expects.
+// It accepts URLs such as https://ghe.example.com/api/v3 and returns an
+// empty string for an empty host.
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	if host == "" {
+		return ""
+	}
+	return auth.NormalizeHostname(host)
+}
+
+// ResolveHost normalizes host, falling back to go-gh's default host
+// (GH_HOST, then the host gh is authenticated with) when it is empty
+func ResolveHost(host string) string {
+	if host = NormalizeHost(host); host != "" {
+		return host
+	}
+	host, _ = auth.DefaultHost()
+	return NormalizeHost(host)
+}
+
+// IsEnterpriseHost reports whether host is a GitHub Enterprise Server
+// instance, whose administrators configure their own rate limits
+func IsEnterpriseHost(host string) bool {
+	host = NormalizeHost(host)
+	return host != "" && auth.IsEnterprise(host)
+}
+
+// hostMessage prefixes an error message with the host it came from, so
+// failures against one of several hosts say which. github.com is implied.
+func hostMessage(host, message string) string {
+	host = NormalizeHost(host)
+	if host == "" || host == DefaultHost {
+		return message
+	}
+	return host + ": " + message
+}
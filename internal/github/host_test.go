@@ -0,0 +1,42 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	tests := map[string]string{
+		"":                                "",
+		"github.com":                      "github.com",
+		"GitHub.com":                      "github.com",
+		"api.github.com":                  "github.com",
+		"ghe.example.com":                 "ghe.example.com",
+		" https://GHE.example.com/ ":      "ghe.example.com",
+		"https://ghe.example.com/api/v3":  "ghe.example.com",
+		"http://ghe.example.com:8443/api": "ghe.example.com:8443",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, NormalizeHost(input), input)
+	}
+}
+
+func TestResolveHost(t *testing.T) {
+	t.Setenv("GH_HOST", "ghe.example.com")
+	assert.Equal(t, "ghe.example.com", ResolveHost(""))
+	assert.Equal(t, "github.com", ResolveHost("https://github.com"))
+}
+
+func TestIsEnterpriseHost(t *testing.T) {
+	assert.True(t, IsEnterpriseHost("ghe.example.com"))
+	assert.False(t, IsEnterpriseHost("github.com"))
+	assert.False(t, IsEnterpriseHost("api.github.com"))
+	assert.False(t, IsEnterpriseHost(""))
+}
+
+func TestHostMessage(t *testing.T) {
+	assert.Equal(t, "Bad credentials", hostMessage("github.com", "Bad credentials"))
+	assert.Equal(t, "Bad credentials", hostMessage("", "Bad credentials"))
+	assert.Equal(t, "ghe.example.com: Bad credentials", hostMessage("ghe.example.com", "Bad credentials"))
+}
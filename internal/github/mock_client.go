@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,6 +16,11 @@ type MockClient struct {
 	Errors                 map[string]error
 	CallLog                []MockCall
 	RateLimits             map[string]*RateLimit
+	OrgRepositories        map[string][]Repository
+	QueryErrors            map[string]error // SearchCode errors for specific queries
+
+	// mu guards CallLog, which concurrent callers append to
+	mu sync.Mutex
 }
 
 // MockCall represents a logged API call for verification
@@ -32,6 +39,8 @@ func NewMockClient() *MockClient {
 		Errors:                 make(map[string]error),
 		CallLog:                make([]MockCall, 0),
 		RateLimits:             make(map[string]*RateLimit),
+		OrgRepositories:        make(map[string][]Repository),
+		QueryErrors:            make(map[string]error),
 	}
 }
 
@@ -43,6 +52,9 @@ func (m *MockClient) SearchCode(ctx context.Context, query string, opts *SearchO
 	if err, exists := m.Errors["SearchCode"]; exists {
 		return nil, err
 	}
+	if err, exists := m.QueryErrors[query]; exists {
+		return nil, err
+	}
 
 	// Check for paginated results first (for testing pagination)
 	if paginatedResults, exists := m.PaginatedSearchResults[query]; exists {
@@ -113,6 +125,22 @@ func (m *MockClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
 	}, nil
 }
 
+// ListOrgRepositories implements GitHubAPI.ListOrgRepositories for testing
+func (m *MockClient) ListOrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	m.logCall("ListOrgRepositories", org)
+
+	// Check for configured errors
+	if err, exists := m.Errors["ListOrgRepositories"]; exists {
+		return nil, err
+	}
+
+	if repos, exists := m.OrgRepositories[org]; exists {
+		return repos, nil
+	}
+
+	return nil, &NotFoundError{Message: fmt.Sprintf("organization %s not found", org)}
+}
+
 // Helper methods for test setup
 
 // SetSearchResults configures mock search results for a query
@@ -131,6 +159,16 @@ func (m *MockClient) SetFileContent(owner, repo, path, ref string, content []byt
 	m.FileContents[key] = content
 }
 
+// SetOrgRepositories configures the repositories listed for an organization
+func (m *MockClient) SetOrgRepositories(org string, repos []Repository) {
+	m.OrgRepositories[org] = repos
+}
+
+// SetQueryError configures a SearchCode error for a specific query
+func (m *MockClient) SetQueryError(query string, err error) {
+	m.QueryErrors[query] = err
+}
+
 // SetError configures an error for a specific method
 func (m *MockClient) SetError(method string, err error) {
 	m.Errors[method] = err
@@ -143,16 +181,22 @@ func (m *MockClient) SetRateLimit(limit *RateLimit) {
 
 // GetCallLog returns the logged API calls for verification
 func (m *MockClient) GetCallLog() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.CallLog
 }
 
 // ClearCallLog clears the call log
 func (m *MockClient) ClearCallLog() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.CallLog = make([]MockCall, 0)
 }
 
 // GetLastCall returns the most recent API call
 func (m *MockClient) GetLastCall() *MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.CallLog) == 0 {
 		return nil
 	}
@@ -161,6 +205,8 @@ func (m *MockClient) GetLastCall() *MockCall {
 
 // GetCallCount returns the number of calls to a specific method
 func (m *MockClient) GetCallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	count := 0
 	for _, call := range m.CallLog {
 		if call.Method == method {
@@ -172,6 +218,8 @@ func (m *MockClient) GetCallCount(method string) int {
 
 // VerifyCall checks if a specific method was called with expected arguments
 func (m *MockClient) VerifyCall(method string, args ...interface{}) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, call := range m.CallLog {
 		if call.Method == method {
 			if len(args) == 0 {
@@ -196,6 +244,8 @@ func (m *MockClient) VerifyCall(method string, args ...interface{}) bool {
 
 // GetAllCalls returns all logged API calls for verification
 func (m *MockClient) GetAllCalls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.CallLog
 }
 
@@ -207,6 +257,8 @@ func (m *MockClient) Reset() {
 	m.Errors = make(map[string]error)
 	m.CallLog = make([]MockCall, 0)
 	m.RateLimits = make(map[string]*RateLimit)
+	m.OrgRepositories = make(map[string][]Repository)
+	m.QueryErrors = make(map[string]error)
 }
 
 // Private helper to log API calls
@@ -216,7 +268,9 @@ func (m *MockClient) logCall(method string, args ...interface{}) {
 		Args:   args,
 		Time:   time.Now(),
 	}
+	m.mu.Lock()
 	m.CallLog = append(m.CallLog, call)
+	m.mu.Unlock()
 }
 
 // Helper functions for creating test data
@@ -249,6 +303,20 @@ func CreateTestSearchItem(repoName, path, content string) SearchItem {
 	}
 }
 
+// CreateTestRepository creates a sample repository for testing
+func CreateTestRepository(fullName string, stars int) Repository {
+	owner, name, _ := strings.Cut(fullName, "/")
+	return Repository{
+		Name:            StringPtr(name),
+		FullName:        StringPtr(fullName),
+		Owner:           &User{Login: StringPtr(owner)},
+		HTMLURL:         StringPtr(fmt.Sprintf("https://github.com/%s", fullName)),
+		StargazersCount: IntPtr(stars),
+		Fork:            BoolPtr(false),
+		Archived:        BoolPtr(false),
+	}
+}
+
 // Helper to extract filename from path
 func extractFileName(path string) string {
 	for i := len(path) - 1; i >= 0; i-- {
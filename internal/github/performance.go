@@ -3,6 +3,7 @@ package github
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,6 +22,12 @@ type PerformanceMetrics struct {
 	RateLimitHits       int           `json:"rate_limit_hits"`
 	AbuseDetections     int           `json:"abuse_detections"`
 	ServerErrors        int           `json:"server_errors"`
+	EnrichedRepos       int           `json:"enriched_repos"`
+	EnrichmentRequests  int           `json:"enrichment_requests"`
+	EnrichmentCacheHits int           `json:"enrichment_cache_hits"`
+	EnrichmentCoalesced int           `json:"enrichment_coalesced"`
+	EnrichmentFailures  int           `json:"enrichment_failures"`
+	EnrichmentTime      time.Duration `json:"enrichment_time"`
 }
 
 // SearchMetrics tracks metrics for individual searches
@@ -34,15 +41,26 @@ type SearchMetrics struct {
 	DelayTime   time.Duration `json:"delay_time"`
 	ErrorType   string        `json:"error_type,omitempty"`
 	Success     bool          `json:"success"`
+
+	EnrichmentRequests int `json:"enrichment_requests"`
 }
 
-// PerformanceTracker manages performance tracking for batch operations
+// PerformanceTracker manages performance tracking for batch operations.
+// Concurrent searches are tracked with TrackSearch; StartSearch/EndSearch
+// track a single current search.
 type PerformanceTracker struct {
+	mu            sync.Mutex
 	metrics       *PerformanceMetrics
 	searchMetrics []SearchMetrics
 	currentSearch *SearchMetrics
 }
 
+// SearchTracker tracks one search while others run concurrently
+type SearchTracker struct {
+	pt      *PerformanceTracker
+	metrics SearchMetrics
+}
+
 // NewPerformanceTracker creates a new performance tracker
 func NewPerformanceTracker() *PerformanceTracker {
 	return &PerformanceTracker{
@@ -55,6 +73,8 @@ func NewPerformanceTracker() *PerformanceTracker {
 
 // StartBatch initializes tracking for a batch operation
 func (pt *PerformanceTracker) StartBatch(totalSearches int) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	pt.metrics.StartTime = time.Now()
 	pt.metrics.TotalSearches = totalSearches
 	pt.searchMetrics = make([]SearchMetrics, 0, totalSearches)
@@ -62,6 +82,8 @@ func (pt *PerformanceTracker) StartBatch(totalSearches int) {
 
 // StartSearch begins tracking an individual search
 func (pt *PerformanceTracker) StartSearch(name, query string) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	pt.currentSearch = &SearchMetrics{
 		SearchName: name,
 		Query:      query,
@@ -71,20 +93,63 @@ func (pt *PerformanceTracker) StartSearch(name, query string) {
 
 // EndSearch completes tracking for the current search
 func (pt *PerformanceTracker) EndSearch(resultCount int, err error) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	if pt.currentSearch == nil {
 		return
 	}
 
-	pt.currentSearch.Duration = time.Since(pt.currentSearch.StartTime)
-	pt.currentSearch.ResultCount = resultCount
-	pt.currentSearch.Success = err == nil
+	pt.finishSearch(pt.currentSearch, resultCount, err)
+	pt.currentSearch = nil
+}
+
+// TrackSearch begins tracking a search that may run concurrently with others
+func (pt *PerformanceTracker) TrackSearch(name, query string) *SearchTracker {
+	return &SearchTracker{
+		pt: pt,
+		metrics: SearchMetrics{
+			SearchName: name,
+			Query:      query,
+			StartTime:  time.Now(),
+		},
+	}
+}
+
+// RecordRetry tracks a retry attempt for this search
+func (st *SearchTracker) RecordRetry() {
+	st.pt.mu.Lock()
+	defer st.pt.mu.Unlock()
+	st.metrics.RetryCount++
+	st.pt.metrics.RetryCount++
+}
+
+// RecordDelay tracks time this search spent waiting
+func (st *SearchTracker) RecordDelay(delay time.Duration) {
+	st.pt.mu.Lock()
+	defer st.pt.mu.Unlock()
+	st.metrics.DelayTime += delay
+	st.pt.metrics.DelayTime += delay
+}
+
+// End completes tracking for this search
+func (st *SearchTracker) End(resultCount int, err error) {
+	st.pt.mu.Lock()
+	defer st.pt.mu.Unlock()
+	st.pt.finishSearch(&st.metrics, resultCount, err)
+}
+
+// finishSearch records a completed search (caller holds mu)
+func (pt *PerformanceTracker) finishSearch(search *SearchMetrics, resultCount int, err error) {
+	search.Duration = time.Since(search.StartTime)
+	search.ResultCount = resultCount
+	search.Success = err == nil
 
 	if err != nil {
-		pt.currentSearch.ErrorType = classifyError(err)
+		search.ErrorType = classifyError(err)
 		pt.metrics.FailedSearches++
 
 		// Track specific error types
-		switch pt.currentSearch.ErrorType {
+		switch search.ErrorType {
 		case "rate_limit":
 			pt.metrics.RateLimitHits++
 		case "abuse_detection":
@@ -97,12 +162,13 @@ func (pt *PerformanceTracker) EndSearch(resultCount int, err error) {
 		pt.metrics.TotalResults += resultCount
 	}
 
-	pt.searchMetrics = append(pt.searchMetrics, *pt.currentSearch)
-	pt.currentSearch = nil
+	pt.searchMetrics = append(pt.searchMetrics, *search)
 }
 
 // RecordRetry tracks retry attempts
 func (pt *PerformanceTracker) RecordRetry() {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	if pt.currentSearch != nil {
 		pt.currentSearch.RetryCount++
 	}
@@ -111,14 +177,33 @@ func (pt *PerformanceTracker) RecordRetry() {
 
 // RecordDelay tracks delay time
 func (pt *PerformanceTracker) RecordDelay(delay time.Duration) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	if pt.currentSearch != nil {
 		pt.currentSearch.DelayTime += delay
 	}
 	pt.metrics.DelayTime += delay
 }
 
+// RecordEnrichment tracks repository metadata enrichment cost
+func (pt *PerformanceTracker) RecordEnrichment(stats EnrichmentStats) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	if pt.currentSearch != nil {
+		pt.currentSearch.EnrichmentRequests += stats.APIRequests
+	}
+	pt.metrics.EnrichedRepos += stats.Repositories
+	pt.metrics.EnrichmentRequests += stats.APIRequests
+	pt.metrics.EnrichmentCacheHits += stats.CacheHits
+	pt.metrics.EnrichmentCoalesced += stats.Coalesced
+	pt.metrics.EnrichmentFailures += stats.Failures
+	pt.metrics.EnrichmentTime += stats.Duration
+}
+
 // EndBatch completes batch operation tracking
 func (pt *PerformanceTracker) EndBatch() {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
 	pt.metrics.EndTime = time.Now()
 	pt.metrics.TotalDuration = pt.metrics.EndTime.Sub(pt.metrics.StartTime)
 
@@ -150,6 +235,12 @@ func (pt *PerformanceTracker) GetSearchMetrics() []SearchMetrics {
 func (pt *PerformanceTracker) GenerateReport() string {
 	m := pt.metrics
 
+	// Concurrent searches can wait at the same time, so delays may exceed wall time
+	workTime := m.TotalDuration - m.DelayTime
+	if workTime < 0 {
+		workTime = 0
+	}
+
 	report := fmt.Sprintf(`🚀 **Batch Operation Performance Report**
 
 ⏱️  **Timing**:
@@ -169,13 +260,11 @@ func (pt *PerformanceTracker) GenerateReport() string {
   • Total retries: %d
   • Rate limit hits: %d
   • Abuse detections: %d
-  • Server errors: %d
-
-⚡ **Performance Insights**:`,
+  • Server errors: %d`,
 		m.TotalDuration,
 		m.AverageResponseTime,
 		m.DelayTime,
-		m.TotalDuration-m.DelayTime,
+		workTime,
 		m.TotalSearches,
 		m.SuccessfulSearches, float64(m.SuccessfulSearches)/float64(m.TotalSearches)*100,
 		m.FailedSearches, float64(m.FailedSearches)/float64(m.TotalSearches)*100,
@@ -186,6 +275,26 @@ func (pt *PerformanceTracker) GenerateReport() string {
 		m.AbuseDetections,
 		m.ServerErrors)
 
+	if m.EnrichedRepos > 0 {
+		report += fmt.Sprintf(`
+
+🏷️  **Repository Enrichment**:
+  • Repositories enriched: %d
+  • API requests: %d
+  • Cache hits: %d
+  • Coalesced requests: %d
+  • Failures: %d
+  • Time spent: %s`,
+			m.EnrichedRepos,
+			m.EnrichmentRequests,
+			m.EnrichmentCacheHits,
+			m.EnrichmentCoalesced,
+			m.EnrichmentFailures,
+			m.EnrichmentTime)
+	}
+
+	report += "\n\n⚡ **Performance Insights**:"
+
 	// Add performance insights
 	if m.AverageResponseTime > 2*time.Second {
 		report += "\n  • ⚠️ Slower than expected response times - consider smaller batch sizes"
@@ -256,6 +365,10 @@ func (pt *PerformanceTracker) GenerateDetailedReport() string {
 				report += fmt.Sprintf(" | Delays: %s", search.DelayTime)
 			}
 
+			if search.EnrichmentRequests > 0 {
+				report += fmt.Sprintf(" | Enrichment requests: %d", search.EnrichmentRequests)
+			}
+
 			if !search.Success {
 				report += fmt.Sprintf(" | Error: %s", search.ErrorType)
 			}
@@ -2,7 +2,9 @@ package github
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -227,6 +229,56 @@ func TestEndBatch(t *testing.T) {
 }
 
 // TestClassifyError tests error classification
+func TestTrackSearchConcurrent(t *testing.T) {
+	pt := NewPerformanceTracker()
+	pt.StartBatch(10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			st := pt.TrackSearch(fmt.Sprintf("search-%d", i), "query")
+			st.RecordRetry()
+			st.RecordDelay(10 * time.Millisecond)
+			if i%2 == 0 {
+				st.End(0, errors.New("500 Internal Server Error"))
+				return
+			}
+			st.End(3, nil)
+		}(i)
+	}
+	wg.Wait()
+	pt.EndBatch()
+
+	metrics := pt.GetMetrics()
+	if metrics.SuccessfulSearches != 5 || metrics.FailedSearches != 5 {
+		t.Errorf("Expected 5 successful and 5 failed searches, got %d and %d", metrics.SuccessfulSearches, metrics.FailedSearches)
+	}
+	if metrics.TotalResults != 15 {
+		t.Errorf("Expected 15 total results, got %d", metrics.TotalResults)
+	}
+	if metrics.RetryCount != 10 {
+		t.Errorf("Expected 10 retries, got %d", metrics.RetryCount)
+	}
+	if metrics.DelayTime != 100*time.Millisecond {
+		t.Errorf("Expected 100ms delay, got %v", metrics.DelayTime)
+	}
+	if metrics.ServerErrors != 5 {
+		t.Errorf("Expected 5 server errors, got %d", metrics.ServerErrors)
+	}
+
+	searches := pt.GetSearchMetrics()
+	if len(searches) != 10 {
+		t.Fatalf("Expected 10 search metrics, got %d", len(searches))
+	}
+	for _, search := range searches {
+		if search.RetryCount != 1 {
+			t.Errorf("Expected 1 retry for %s, got %d", search.SearchName, search.RetryCount)
+		}
+	}
+}
+
 func TestClassifyError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -257,6 +309,41 @@ func TestClassifyError(t *testing.T) {
 	}
 }
 
+// TestRecordEnrichment tests enrichment cost tracking
+func TestRecordEnrichment(t *testing.T) {
+	pt := NewPerformanceTracker()
+	pt.StartBatch(1)
+
+	pt.StartSearch("search1", "query1")
+	pt.RecordEnrichment(EnrichmentStats{
+		Repositories: 4,
+		APIRequests:  3,
+		CacheHits:    1,
+		Duration:     200 * time.Millisecond,
+	})
+	pt.EndSearch(10, nil)
+	pt.EndBatch()
+
+	metrics := pt.GetMetrics()
+	if metrics.EnrichedRepos != 4 || metrics.EnrichmentRequests != 3 || metrics.EnrichmentCacheHits != 1 {
+		t.Errorf("Unexpected enrichment metrics: %+v", metrics)
+	}
+	if metrics.EnrichmentTime != 200*time.Millisecond {
+		t.Errorf("Expected enrichment time 200ms, got %v", metrics.EnrichmentTime)
+	}
+	if pt.GetSearchMetrics()[0].EnrichmentRequests != 3 {
+		t.Errorf("Expected 3 enrichment requests for search, got %d", pt.GetSearchMetrics()[0].EnrichmentRequests)
+	}
+
+	report := pt.GenerateDetailedReport()
+	if !strings.Contains(report, "Repository Enrichment") || !strings.Contains(report, "API requests: 3") {
+		t.Error("Expected report to include enrichment section")
+	}
+	if !strings.Contains(report, "Enrichment requests: 3") {
+		t.Error("Expected detailed report to include per-search enrichment requests")
+	}
+}
+
 // TestGenerateReport tests report generation
 func TestGenerateReport(t *testing.T) {
 	pt := NewPerformanceTracker()
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit resources GitHub reports in X-RateLimit-Resource
+const (
+	ResourceCore       = "core"
+	ResourceSearch     = "search"
+	ResourceCodeSearch = "code_search"
+	ResourceGraphQL    = "graphql"
+)
+
+// Quota is the last rate limit state GitHub reported for one resource
+type Quota struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+	Observed  time.Time
+}
+
+// QuotaTracker records rate limit headers from every response, per host and
+// resource, so callers can slow down before GitHub starts rejecting requests.
+// It is safe for concurrent use.
+type QuotaTracker struct {
+	mu         sync.Mutex
+	quotas     map[string]Quota
+	retryUntil map[string]time.Time
+	now        func() time.Time
+}
+
+// NewQuotaTracker creates an empty quota tracker
+func NewQuotaTracker() *QuotaTracker {
+	return &QuotaTracker{
+		quotas:     make(map[string]Quota),
+		retryUntil: make(map[string]time.Time),
+		now:        time.Now,
+	}
+}
+
+var sharedQuotaTracker = NewQuotaTracker()
+
+// SharedQuotaTracker returns the process-wide tracker every RealClient reports to
+func SharedQuotaTracker() *QuotaTracker {
+	return sharedQuotaTracker
+}
+
+// quotaKey identifies a resource on a host
+func quotaKey(host, resource string) string {
+	return NormalizeHost(host) + "/" + resource
+}
+
+// Observe records the X-RateLimit-* and Retry-After headers of a response
+// from host. Responses without a resource, such as from GitHub Enterprise
+// Server with rate limiting disabled, only record Retry-After.
+func (t *QuotaTracker) Observe(host string, header http.Header) {
+	if header == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+
+	if retryAfter := ParseRetryAfterHeader(header); retryAfter > 0 {
+		until := now.Add(retryAfter)
+		if until.After(t.retryUntil[NormalizeHost(host)]) {
+			t.retryUntil[NormalizeHost(host)] = until
+		}
+	}
+
+	resource := header.Get("X-RateLimit-Resource")
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if resource == "" || err != nil {
+		return
+	}
+
+	quota := Quota{Resource: resource, Remaining: remaining, Observed: now}
+	quota.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	quota.Used, _ = strconv.Atoi(header.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		quota.Reset = time.Unix(reset, 0)
+	}
+
+	// Concurrent responses can arrive out of order; within one window the
+	// lowest remaining count is the most recent
+	key := quotaKey(host, resource)
+	if previous, ok := t.quotas[key]; ok && previous.Reset.Equal(quota.Reset) && previous.Remaining < quota.Remaining {
+		return
+	}
+	t.quotas[key] = quota
+}
+
+// Quota returns the last observed quota for resource on host
+func (t *QuotaTracker) Quota(host, resource string) (Quota, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	quota, ok := t.quotas[quotaKey(host, resource)]
+	return quota, ok
+}
+
+// Wait returns how long to hold off before the next request for resource on
+// host: until a Retry-After deadline, or until the quota resets once no more
+// than buffer requests remain. It returns 0 when requests can go ahead.
+func (t *QuotaTracker) Wait(host, resource string, buffer int) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+
+	var wait time.Duration
+	if until, ok := t.retryUntil[NormalizeHost(host)]; ok {
+		wait = until.Sub(now)
+	}
+	if quota, ok := t.quotas[quotaKey(host, resource)]; ok && quota.Remaining <= buffer {
+		if untilReset := quota.Reset.Sub(now); untilReset > wait {
+			wait = untilReset
+		}
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// quotaTransport reports the rate limit headers of every response from host
+// to a QuotaTracker, including error responses
+type quotaTransport struct {
+	host    string
+	tracker *QuotaTracker
+	base    http.RoundTripper
+}
+
+// newQuotaTransport wraps http.DefaultTransport so responses from host are
+// reported to tracker
+func newQuotaTransport(host string, tracker *QuotaTracker) http.RoundTripper {
+	return &quotaTransport{host: host, tracker: tracker, base: http.DefaultTransport}
+}
+
+func (t *quotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		t.tracker.Observe(t.host, resp.Header)
+	}
+	return resp, err
+}
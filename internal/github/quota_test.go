@@ -0,0 +1,163 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitHeader builds the rate limit headers GitHub sends for resource
+func rateLimitHeader(resource string, limit, remaining int, reset time.Time) http.Header {
+	return http.Header{
+		"X-Ratelimit-Resource":  []string{resource},
+		"X-Ratelimit-Limit":     []string{strconv.Itoa(limit)},
+		"X-Ratelimit-Remaining": []string{strconv.Itoa(remaining)},
+		"X-Ratelimit-Used":      []string{strconv.Itoa(limit - remaining)},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+	}
+}
+
+func TestQuotaTracker_Observe(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reset := now.Add(time.Minute)
+	tracker := NewQuotaTracker()
+	tracker.now = func() time.Time { return now }
+
+	tracker.Observe("github.com", rateLimitHeader("search", 30, 12, reset))
+	tracker.Observe("github.com", rateLimitHeader("core", 5000, 4999, now.Add(time.Hour)))
+	tracker.Observe("ghe.example.com", rateLimitHeader("search", 60, 60, reset))
+
+	quota, ok := tracker.Quota("github.com", ResourceSearch)
+	require.True(t, ok)
+	assert.Equal(t, Quota{Resource: "search", Limit: 30, Remaining: 12, Used: 18, Reset: reset, Observed: now}, quota)
+
+	quota, ok = tracker.Quota("GHE.example.com", ResourceSearch)
+	require.True(t, ok, "hosts are normalized")
+	assert.Equal(t, 60, quota.Remaining)
+
+	_, ok = tracker.Quota("github.com", ResourceGraphQL)
+	assert.False(t, ok)
+
+	// A response that finished late does not raise the remaining count
+	tracker.Observe("github.com", rateLimitHeader("search", 30, 15, reset))
+	quota, _ = tracker.Quota("github.com", ResourceSearch)
+	assert.Equal(t, 12, quota.Remaining)
+
+	// A new window replaces the old one
+	tracker.Observe("github.com", rateLimitHeader("search", 30, 29, reset.Add(time.Minute)))
+	quota, _ = tracker.Quota("github.com", ResourceSearch)
+	assert.Equal(t, 29, quota.Remaining)
+
+	// Servers with rate limiting disabled send no resource
+	tracker.Observe("ghe.example.com", http.Header{"Content-Type": []string{"application/json"}})
+	quota, _ = tracker.Quota("ghe.example.com", ResourceSearch)
+	assert.Equal(t, 60, quota.Remaining)
+}
+
+func TestQuotaTracker_Wait(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name    string
+		header  http.Header
+		buffer  int
+		wantFor time.Duration
+	}{
+		{"plenty remaining", rateLimitHeader("search", 30, 20, now.Add(time.Minute)), 5, 0},
+		{"at the buffer", rateLimitHeader("search", 30, 5, now.Add(time.Minute)), 5, time.Minute},
+		{"exhausted", rateLimitHeader("search", 30, 0, now.Add(40*time.Second)), 0, 40 * time.Second},
+		{"reset already passed", rateLimitHeader("search", 30, 0, now.Add(-time.Second)), 5, 0},
+		{"retry after", http.Header{"Retry-After": []string{"90"}}, 5, 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewQuotaTracker()
+			tracker.now = func() time.Time { return now }
+			tracker.Observe("github.com", tt.header)
+			assert.Equal(t, tt.wantFor, tracker.Wait("github.com", ResourceSearch, tt.buffer))
+		})
+	}
+}
+
+func TestQuotaTracker_RetryAfterAppliesToHost(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tracker := NewQuotaTracker()
+	tracker.now = func() time.Time { return now }
+
+	header := rateLimitHeader("core", 5000, 4000, now.Add(time.Hour))
+	header.Set("Retry-After", "30")
+	tracker.Observe("github.com", header)
+
+	assert.Equal(t, 30*time.Second, tracker.Wait("github.com", ResourceSearch, 5), "secondary limits span resources")
+	assert.Zero(t, tracker.Wait("ghe.example.com", ResourceSearch, 5))
+
+	now = now.Add(time.Minute)
+	assert.Zero(t, tracker.Wait("github.com", ResourceSearch, 5))
+}
+
+func TestQuotaTracker_Concurrent(t *testing.T) {
+	reset := time.Now().Add(time.Minute)
+	tracker := NewQuotaTracker()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(remaining int) {
+			defer wg.Done()
+			tracker.Observe("github.com", rateLimitHeader("search", 30, remaining%30, reset))
+			tracker.Wait("github.com", ResourceSearch, 5)
+		}(i)
+	}
+	wg.Wait()
+
+	quota, ok := tracker.Quota("github.com", ResourceSearch)
+	require.True(t, ok)
+	assert.Equal(t, 0, quota.Remaining, "the lowest count in a window wins")
+}
+
+// headerTransport answers every request with a fixed status, headers and
+// body, which defaults to GitHub's error message for the status
+type headerTransport struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := t.body
+	if body == "" {
+		body = fmt.Sprintf(`{"message": "%s"}`, http.StatusText(t.status))
+	}
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     t.header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestQuotaTransport(t *testing.T) {
+	tracker := NewQuotaTracker()
+	header := rateLimitHeader("core", 5000, 0, time.Now().Add(time.Hour))
+	header.Set("Content-Type", "application/json")
+	transport := &quotaTransport{host: "github.com", tracker: tracker, base: headerTransport{status: http.StatusForbidden, header: header}}
+
+	rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: transport})
+	require.NoError(t, err)
+	require.Error(t, rest.Get("repos/owner/repo", nil))
+
+	// Error responses are tracked too
+	quota, ok := tracker.Quota("github.com", ResourceCore)
+	require.True(t, ok)
+	assert.Equal(t, 5000, quota.Limit)
+	assert.Zero(t, quota.Remaining)
+}
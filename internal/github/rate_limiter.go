@@ -16,6 +16,13 @@ type RateLimiter struct {
 	baseDelay     time.Duration
 	maxDelay      time.Duration
 	backoffFactor float64
+
+	// Quota the limiter waits on before each attempt, see WithQuota
+	quota         *QuotaTracker
+	quotaHost     string
+	quotaResource string
+	quotaBuffer   int
+	onQuotaWait   func(resource string, wait time.Duration)
 }
 
 // RateLimiterConfig holds configuration for rate limiting behavior
@@ -54,19 +61,73 @@ func NewRateLimiterWithConfig(config RateLimiterConfig) *RateLimiter {
 	return rl
 }
 
+// WithQuota returns a copy of the limiter that, before each attempt, sleeps
+// until the tracked quota for resource on host resets once no more than
+// buffer requests remain, rather than spending them and erroring
+func (rl *RateLimiter) WithQuota(tracker *QuotaTracker, host, resource string, buffer int) *RateLimiter {
+	limited := *rl
+	limited.quota = tracker
+	limited.quotaHost = host
+	limited.quotaResource = resource
+	limited.quotaBuffer = buffer
+	return &limited
+}
+
+// OnQuotaWait sets a callback invoked before the limiter sleeps for a quota
+// reset, so long waits can be reported
+func (rl *RateLimiter) OnQuotaWait(fn func(resource string, wait time.Duration)) *RateLimiter {
+	rl.onQuotaWait = fn
+	return rl
+}
+
+// waitForQuota sleeps while the tracked quota is exhausted
+func (rl *RateLimiter) waitForQuota(ctx context.Context) error {
+	if rl.quota == nil {
+		return nil
+	}
+	wait := rl.quota.Wait(rl.quotaHost, rl.quotaResource, rl.quotaBuffer)
+	if wait <= 0 {
+		return nil
+	}
+	if rl.onQuotaWait != nil {
+		rl.onQuotaWait(rl.quotaResource, wait)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
 // RetryableFunc represents a function that can be retried
 type RetryableFunc func() error
 
 // WithRetry executes a function with intelligent retry and exponential backoff
 func (rl *RateLimiter) WithRetry(ctx context.Context, operation string, fn RetryableFunc) error {
+	return rl.WithRetryBudget(ctx, operation, rl.maxRetries, fn)
+}
+
+// WithRetryBudget is WithRetry with a per-operation retry budget.
+// A negative budget uses the limiter's default.
+func (rl *RateLimiter) WithRetryBudget(ctx context.Context, operation string, maxRetries int, fn RetryableFunc) error {
+	if maxRetries < 0 {
+		maxRetries = rl.maxRetries
+	}
+
 	var lastErr error
 
-	for attempt := 0; attempt <= rl.maxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Check context cancellation before each attempt
 		if ctx.Err() != nil {
 			return fmt.Errorf("operation cancelled: %w", ctx.Err())
 		}
 
+		if err := rl.waitForQuota(ctx); err != nil {
+			return fmt.Errorf("operation cancelled waiting for %s rate limit reset: %w", rl.quotaResource, err)
+		}
+
 		err := fn()
 		if err == nil {
 			return nil // Success!
@@ -75,7 +136,7 @@ func (rl *RateLimiter) WithRetry(ctx context.Context, operation string, fn Retry
 		lastErr = err
 
 		// Don't retry on the last attempt
-		if attempt == rl.maxRetries {
+		if attempt == maxRetries {
 			break
 		}
 
@@ -96,7 +157,7 @@ func (rl *RateLimiter) WithRetry(ctx context.Context, operation string, fn Retry
 		}
 	}
 
-	return rl.formatFinalError(operation, lastErr)
+	return rl.formatFinalError(operation, maxRetries, lastErr)
 }
 
 // analyzeErrorAndGetDelay determines retry strategy based on error type
@@ -107,7 +168,9 @@ func (rl *RateLimiter) analyzeErrorAndGetDelay(err error, attempt int) (time.Dur
 	switch {
 	case rl.isRateLimitError(err):
 		// Primary rate limit - check for Retry-After header
-		if rateLimitErr, ok := err.(*RateLimitError); ok {
+		// GitHub Enterprise Server may omit the reset headers, so only a known
+		// reset time replaces the backoff
+		if rateLimitErr, ok := err.(*RateLimitError); ok && rateLimitErr.ResetTime > 0 {
 			// Honor the reset time from GitHub, but cap it reasonably for tests
 			resetTime := rateLimitErr.ResetTime
 			if resetTime > 30*time.Second {
@@ -211,21 +274,21 @@ func (rl *RateLimiter) capDelay(delay time.Duration) time.Duration {
 }
 
 // formatFinalError creates a detailed error message for final failure
-func (rl *RateLimiter) formatFinalError(operation string, err error) error {
+func (rl *RateLimiter) formatFinalError(operation string, retries int, err error) error {
 	errStr := err.Error()
 
 	switch {
 	case rl.isRateLimitError(err):
-		return fmt.Errorf("rate limit exceeded during %s after %d retries: %w\n\n💡 Suggestions:\n  • Wait until your rate limit resets (check: gh scout rate-limit)\n  • Use authenticated requests (verify: gh auth status)\n  • Consider reducing batch operation frequency\n  • Add delays between operations", operation, rl.maxRetries, err)
+		return fmt.Errorf("rate limit exceeded during %s after %d retries: %w\n\n💡 Suggestions:\n  • Wait until your rate limit resets (check: gh scout rate-limit)\n  • Use authenticated requests (verify: gh auth status)\n  • Consider reducing batch operation frequency\n  • Add delays between operations", operation, retries, err)
 
 	case rl.isAbuseRateLimitError(err):
-		return fmt.Errorf("GitHub abuse detection triggered during %s after %d retries: %w\n\n💡 Suggestions:\n  • You're making requests too rapidly\n  • Wait at least 1 minute before retrying\n  • Implement longer delays between batch operations\n  • Reduce concurrent operations", operation, rl.maxRetries, err)
+		return fmt.Errorf("GitHub abuse detection triggered during %s after %d retries: %w\n\n💡 Suggestions:\n  • You're making requests too rapidly\n  • Wait at least 1 minute before retrying\n  • Implement longer delays between batch operations\n  • Reduce concurrent operations", operation, retries, err)
 
 	case rl.isServerError(strings.ToLower(errStr)):
-		return fmt.Errorf("GitHub server error during %s after %d retries: %w\n\n💡 Suggestions:\n  • This is a temporary GitHub server issue\n  • Check GitHub's status page: https://status.github.com\n  • Try again later with smaller batch sizes\n  • Consider using --verbose to monitor retry attempts", operation, rl.maxRetries, err)
+		return fmt.Errorf("GitHub server error during %s after %d retries: %w\n\n💡 Suggestions:\n  • This is a temporary GitHub server issue\n  • Check GitHub's status page: https://status.github.com\n  • Try again later with smaller batch sizes\n  • Consider using --verbose to monitor retry attempts", operation, retries, err)
 
 	default:
-		return fmt.Errorf("operation %s failed after %d retries: %w", operation, rl.maxRetries, err)
+		return fmt.Errorf("operation %s failed after %d retries: %w", operation, retries, err)
 	}
 }
 
@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -260,6 +262,13 @@ func TestAnalyzeErrorAndGetDelay(t *testing.T) {
 			expectRetry: true,
 			expectDelay: true,
 		},
+		{
+			name:        "rate limit error without reset time",
+			err:         &RateLimitError{Message: "ghe.example.com: API rate limit exceeded"},
+			attempt:     0,
+			expectRetry: true,
+			expectDelay: true,
+		},
 		{
 			name:        "abuse rate limit error",
 			err:         &AbuseRateLimitError{Message: "Abuse detection"},
@@ -478,3 +487,94 @@ func BenchmarkExponentialBackoff(b *testing.B) {
 		rl.calculateExponentialBackoff(i % 10)
 	}
 }
+
+// TestWithRetryBudget tests per-call retry budgets
+func TestWithRetryBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    int
+		wantCalls int
+	}{
+		{"zero budget makes a single attempt", 0, 1},
+		{"explicit budget overrides default", 1, 2},
+		{"negative budget uses default", -1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiter()
+			rl.baseDelay = time.Millisecond
+
+			callCount := 0
+			err := rl.WithRetryBudget(context.Background(), "test operation", tt.budget, func() error {
+				callCount++
+				return errors.New("502 Bad Gateway")
+			})
+
+			if err == nil {
+				t.Error("Expected error after exhausting the budget, got nil")
+			}
+			if callCount != tt.wantCalls {
+				t.Errorf("Expected function to be called %d times, got %d", tt.wantCalls, callCount)
+			}
+		})
+	}
+}
+
+// TestWithQuota tests waiting for an exhausted quota before each attempt
+func TestWithQuota(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	tracker := NewQuotaTracker()
+	tracker.now = func() time.Time { return reset.Add(-50 * time.Millisecond) }
+	tracker.Observe("github.com", http.Header{
+		"X-Ratelimit-Resource":  []string{"search"},
+		"X-Ratelimit-Remaining": []string{"5"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+	})
+	// Unix timestamps drop the sub-second part of reset
+	wantWait := tracker.Wait("github.com", ResourceSearch, 5)
+
+	var waited time.Duration
+	rl := NewRateLimiter().WithQuota(tracker, "github.com", ResourceSearch, 5).OnQuotaWait(func(resource string, wait time.Duration) {
+		if resource != ResourceSearch {
+			t.Errorf("Expected wait on search, got %s", resource)
+		}
+		waited = wait
+	})
+
+	start := time.Now()
+	calls := 0
+	err := rl.WithRetry(context.Background(), "search", func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+	if waited != wantWait || time.Since(start) < wantWait {
+		t.Errorf("Expected to wait %v for the reset, waited %v", wantWait, waited)
+	}
+
+	// Above the buffer, or on another resource, nothing waits
+	if wait := tracker.Wait("github.com", ResourceSearch, 4); wait != 0 {
+		t.Errorf("Expected no wait above the buffer, got %v", wait)
+	}
+	if wait := tracker.Wait("github.com", ResourceCore, 5); wait != 0 {
+		t.Errorf("Expected no wait for an untracked resource, got %v", wait)
+	}
+
+	// Cancelling the context ends the wait
+	tracker.now = func() time.Time { return reset.Add(-time.Minute) }
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = rl.WithRetry(ctx, "search", func() error {
+		t.Error("Expected no attempt while the quota is exhausted")
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -16,19 +17,34 @@ import (
 // RealClient implements GitHubAPI using go-gh (GitHub CLI's library).
 // It provides a production implementation that communicates with GitHub's API.
 type RealClient struct {
-	client *api.RESTClient
+	client   *api.RESTClient
+	graphql  *api.GraphQLClient
+	host     string
+	enricher *RepoEnricher
 }
 
 // NewRealClient creates a new GitHub API client using go-gh.
 // It automatically handles authentication via gh auth token or GH_TOKEN env var
 func NewRealClient() (*RealClient, error) {
+	return NewRealClientForHost("")
+}
+
+// NewRealClientForHost creates a GitHub API client for host, such as a GitHub
+// Enterprise Server instance. An empty host uses go-gh's default resolution
+// (GH_HOST, then the host gh is authenticated with).
+func NewRealClientForHost(host string) (*RealClient, error) {
 	// go-gh automatically handles:
-	// - Authentication (gh auth token, GH_TOKEN env var)
-	// - Host configuration (GH_HOST, GH_ENTERPRISE_TOKEN)
+	// - Authentication (gh auth token, GH_TOKEN/GH_ENTERPRISE_TOKEN env vars)
+	// - API URLs for enterprise hosts (https://HOST/api/v3/)
 	// - Request headers and API versioning
+	host = ResolveHost(host)
 
-	// Create client with text-match header for code snippets
+	// Create client with text-match header for code snippets. Every response
+	// reports its rate limit headers to the shared quota tracker.
+	transport := newQuotaTransport(host, SharedQuotaTracker())
 	opts := api.ClientOptions{
+		Host:      host,
+		Transport: transport,
 		Headers: map[string]string{
 			"Accept": "application/vnd.github.text-match+json",
 		},
@@ -36,12 +52,35 @@ func NewRealClient() (*RealClient, error) {
 
 	client, err := api.NewRESTClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+		return nil, fmt.Errorf("failed to create GitHub client for %s: %w", host, err)
 	}
 
-	return &RealClient{
+	c := &RealClient{
 		client: client,
-	}, nil
+		host:   host,
+	}
+	c.enricher = NewRepoEnricher(SharedRepoMetadataCache(), host, DefaultEnrichmentWorkers, c.fetchRepository)
+
+	// Resolve repositories in bulk over GraphQL, falling back to REST
+	if graphql, err := api.NewGraphQLClient(api.ClientOptions{Host: host, Transport: transport}); err == nil {
+		c.graphql = graphql
+		c.enricher.SetBatchFetcher(c.fetchRepositories, MaxGraphQLRepositories)
+	}
+
+	return c, nil
+}
+
+// Host returns the GitHub hostname this client talks to
+func (c *RealClient) Host() string {
+	return c.host
+}
+
+// EnrichmentStats implements EnrichmentReporter
+func (c *RealClient) EnrichmentStats() EnrichmentStats {
+	if c.enricher == nil {
+		return EnrichmentStats{}
+	}
+	return c.enricher.Stats()
 }
 
 // SearchCode implements GitHubAPI.SearchCode.
@@ -69,11 +108,10 @@ func (c *RealClient) SearchCode(ctx context.Context, query string, opts *SearchO
 		Items             []SearchItem `json:"items"`
 	}
 
-	// Use regular Get since go-gh doesn't support custom headers easily
-	// The text-match header is nice to have but not required
-	err := c.client.Get(endpoint, &result)
+	// Requests are bound to ctx so timeouts and Ctrl-C abort them in flight
+	err := c.client.DoWithContext(ctx, http.MethodGet, endpoint, nil, &result)
 	if err != nil {
-		return nil, formatGoGHError(err)
+		return nil, formatGoGHError(err, c.host)
 	}
 
 	// Convert to our format
@@ -105,9 +143,9 @@ func (c *RealClient) GetFileContent(ctx context.Context, owner, repo, path, ref
 		Encoding string `json:"encoding"`
 	}
 
-	err := c.client.Get(endpoint, &fileContent)
+	err := c.client.DoWithContext(ctx, http.MethodGet, endpoint, nil, &fileContent)
 	if err != nil {
-		return nil, formatGoGHError(err)
+		return nil, formatGoGHError(err, c.host)
 	}
 
 	// Decode base64 content
@@ -126,89 +164,143 @@ func (c *RealClient) GetFileContent(ctx context.Context, owner, repo, path, ref
 // It returns the current API rate limit status for search operations
 func (c *RealClient) GetRateLimit(ctx context.Context) (*RateLimit, error) {
 	var rateLimits struct {
-		Resources struct {
-			Core struct {
-				Limit     int   `json:"limit"`
-				Remaining int   `json:"remaining"`
-				Reset     int64 `json:"reset"`
-			} `json:"core"`
-			Search struct {
-				Limit     int   `json:"limit"`
-				Remaining int   `json:"remaining"`
-				Reset     int64 `json:"reset"`
-			} `json:"search"`
+		Resources map[string]struct {
+			Limit     int   `json:"limit"`
+			Remaining int   `json:"remaining"`
+			Used      int   `json:"used"`
+			Reset     int64 `json:"reset"`
 		} `json:"resources"`
 	}
 
-	err := c.client.Get("rate_limit", &rateLimits)
+	err := c.client.DoWithContext(ctx, http.MethodGet, "rate_limit", nil, &rateLimits)
 	if err != nil {
-		return nil, formatGoGHError(err)
+		// GitHub Enterprise Server answers 404 when rate limiting is turned off
+		var httpErr *api.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound && IsEnterpriseHost(c.host) {
+			return &RateLimit{Disabled: true}, nil
+		}
+		return nil, formatGoGHError(err, c.host)
 	}
 
-	// Return search-specific rate limits
-	searchLimit := rateLimits.Resources.Search
-	return &RateLimit{
-		Limit:     searchLimit.Limit,
-		Remaining: searchLimit.Remaining,
-		Reset:     time.Unix(searchLimit.Reset, 0),
-	}, nil
-}
-
-// enrichRepositoryMetadata fetches additional repository data if needed
-func (c *RealClient) enrichRepositoryMetadata(ctx context.Context, results *SearchResults) {
-	// Skip if results is nil or client is not properly initialized
-	if results == nil || c == nil || c.client == nil {
-		return
+	rateLimit := &RateLimit{Resources: make(map[string]ResourceRateLimit, len(rateLimits.Resources))}
+	for resource, limit := range rateLimits.Resources {
+		rateLimit.Resources[resource] = ResourceRateLimit{
+			Limit:     limit.Limit,
+			Remaining: limit.Remaining,
+			Used:      limit.Used,
+			Reset:     time.Unix(limit.Reset, 0),
+		}
 	}
 
-	// Track which repos we've already enriched to avoid duplicates
-	enriched := make(map[string]bool)
+	// The top-level fields keep describing the search quota
+	search := rateLimit.Resources[ResourceSearch]
+	rateLimit.Limit, rateLimit.Remaining, rateLimit.Reset = search.Limit, search.Remaining, search.Reset
+	return rateLimit, nil
+}
 
-	for i := range results.Items {
-		item := &results.Items[i]
-		repoKey := fmt.Sprintf("%s/%s",
-			item.Repository.GetOwnerLogin(),
-			item.Repository.GetName())
+// maxOrgRepoPages caps organization listings at 1000 repositories
+const maxOrgRepoPages = 10
+
+// ListOrgRepositories implements GitHubAPI.ListOrgRepositories.
+// It lists every repository owned by an organization, falling back to the
+// user endpoint so personal accounts work too.
+func (c *RealClient) ListOrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	repos, err := c.listRepositories(ctx, fmt.Sprintf("orgs/%s/repos", url.PathEscape(org)))
+	if _, ok := err.(*NotFoundError); ok {
+		repos, err = c.listRepositories(ctx, fmt.Sprintf("users/%s/repos", url.PathEscape(org)))
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		// Skip if already enriched or has star count
-		if enriched[repoKey] || item.Repository.StargazersCount != nil {
-			continue
+	// Listings carry full metadata, so later searches need no enrichment
+	if c.enricher != nil {
+		for i := range repos {
+			c.enricher.Seed(&repos[i])
 		}
+	}
 
-		// Fetch repository metadata
-		var repoData Repository
-		endpoint := fmt.Sprintf("repos/%s", repoKey)
+	return repos, nil
+}
 
-		err := c.client.Get(endpoint, &repoData)
+// listRepositories pages through a repository listing endpoint
+func (c *RealClient) listRepositories(ctx context.Context, endpoint string) ([]Repository, error) {
+	var all []Repository
+	for page := 1; page <= maxOrgRepoPages; page++ {
+		var repos []Repository
+		err := c.client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?per_page=100&page=%d", endpoint, page), nil, &repos)
 		if err != nil {
-			// Don't fail the entire search if enrichment fails
-			// Just skip this repository
-			continue
+			return nil, formatGoGHError(err, c.host)
+		}
+		all = append(all, repos...)
+		if len(repos) < 100 {
+			break
 		}
+	}
+	return all, nil
+}
 
-		// Update the repository data
-		item.Repository = repoData
-		enriched[repoKey] = true
+// enrichRepositoryMetadata fetches additional repository data if needed.
+// Unique repositories are fetched concurrently and shared process-wide, so
+// repeated pages and batch searches reuse metadata that was already loaded.
+func (c *RealClient) enrichRepositoryMetadata(ctx context.Context, results *SearchResults) {
+	// Skip if results is nil or client is not properly initialized
+	if results == nil || c == nil || c.client == nil || c.enricher == nil {
+		return
 	}
+
+	// A cancelled search returns its results without metadata rather than
+	// starting requests that would be aborted
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Enrichment failures never fail the search, those repositories are left as-is
+	c.enricher.Enrich(ctx, results)
+}
+
+// fetchRepository retrieves metadata for a single repository
+func (c *RealClient) fetchRepository(ctx context.Context, fullName string) (*Repository, error) {
+	var repo Repository
+	if err := c.client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s", fullName), nil, &repo); err != nil {
+		return nil, formatGoGHError(err, c.host)
+	}
+	return &repo, nil
 }
 
-// formatGoGHError converts go-gh errors to our error types
-func formatGoGHError(err error) error {
+// newAbuseRateLimitError creates a secondary rate limit error that waits as
+// long as the Retry-After header asks
+func newAbuseRateLimitError(message string, header http.Header) *AbuseRateLimitError {
+	abuseErr := &AbuseRateLimitError{Message: message}
+	if retryAfter := ParseRetryAfterHeader(header); retryAfter > 0 {
+		abuseErr.RetryAfter = &retryAfter
+	}
+	return abuseErr
+}
+
+// formatGoGHError converts go-gh errors to our error types. Messages name
+// the host unless it is github.com.
+func formatGoGHError(err error, host string) error {
 	if err == nil {
 		return nil
 	}
 
 	// go-gh returns api.HTTPError for HTTP errors
 	if httpErr, ok := err.(*api.HTTPError); ok {
+		message := hostMessage(host, httpErr.Message)
 		switch httpErr.StatusCode {
 		case http.StatusUnauthorized:
-			return &AuthenticationError{Message: httpErr.Message}
+			return &AuthenticationError{Message: message}
 		case http.StatusForbidden:
+			// Secondary rate limits come back as 403s with a Retry-After header
+			if strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit") {
+				return newAbuseRateLimitError(message, httpErr.Headers)
+			}
 			// Check if it's a rate limit error
 			if strings.Contains(strings.ToLower(httpErr.Message), "rate limit") {
 				// Parse rate limit info from headers if available
 				rateLimitErr := &RateLimitError{
-					Message: httpErr.Message,
+					Message: message,
 				}
 				// Extract rate limit info from headers if available
 				if httpErr.Headers != nil {
@@ -231,19 +323,19 @@ func formatGoGHError(err error) error {
 				}
 				return rateLimitErr
 			}
-			return &AuthorizationError{Message: httpErr.Message}
+			return &AuthorizationError{Message: message}
 		case http.StatusNotFound:
-			return &NotFoundError{Message: httpErr.Message}
+			return &NotFoundError{Message: message}
 		case http.StatusUnprocessableEntity:
 			return &ValidationError{
-				Message: httpErr.Message,
+				Message: message,
 				Errors:  []string{httpErr.Message},
 			}
 		case http.StatusTooManyRequests:
-			return &AbuseRateLimitError{Message: httpErr.Message}
+			return newAbuseRateLimitError(message, httpErr.Headers)
 		default:
-			return fmt.Errorf("GitHub API error (status %d): %s",
-				httpErr.StatusCode, httpErr.Message)
+			return errors.New(hostMessage(host, fmt.Sprintf("GitHub API error (status %d): %s",
+				httpErr.StatusCode, httpErr.Message)))
 		}
 	}
 
@@ -2,9 +2,12 @@ package github
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/cli/go-gh/v2/pkg/api"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +43,14 @@ func TestNewRealClient(t *testing.T) {
 	}
 }
 
+func TestNewRealClientForHost(t *testing.T) {
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+
+	client, err := NewRealClientForHost("https://GHE.example.com/api/v3")
+	require.NoError(t, err)
+	assert.Equal(t, "ghe.example.com", client.Host())
+}
+
 // NewRealClientWithToken was removed since go-gh handles auth automatically
 // Authentication is now handled via gh auth token or GH_TOKEN env var
 
@@ -142,6 +153,47 @@ func TestRealClient_GetRateLimit_Integration(t *testing.T) {
 	assert.False(t, rateLimit.Reset.IsZero(), "Reset time should be set")
 }
 
+func TestRealClient_GetRateLimit(t *testing.T) {
+	transport := headerTransport{
+		status: http.StatusOK,
+		header: http.Header{"Content-Type": []string{"application/json"}},
+		body: `{"resources": {
+			"core": {"limit": 5000, "remaining": 4990, "used": 10, "reset": 1700003600},
+			"search": {"limit": 30, "remaining": 28, "used": 2, "reset": 1700000060},
+			"code_search": {"limit": 10, "remaining": 9, "used": 1, "reset": 1700000060},
+			"graphql": {"limit": 5000, "remaining": 5000, "used": 0, "reset": 1700003600}
+		}}`,
+	}
+	rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: transport})
+	require.NoError(t, err)
+	client := &RealClient{client: rest, host: "github.com"}
+
+	rateLimit, err := client.GetRateLimit(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, rateLimit.Resources, 4)
+	assert.Equal(t, ResourceRateLimit{Limit: 5000, Remaining: 4990, Used: 10, Reset: time.Unix(1700003600, 0)}, rateLimit.Resources[ResourceCore])
+	assert.Equal(t, ResourceRateLimit{Limit: 10, Remaining: 9, Used: 1, Reset: time.Unix(1700000060, 0)}, rateLimit.Resources[ResourceCodeSearch])
+
+	// The top-level fields describe the search quota
+	assert.Equal(t, 30, rateLimit.Limit)
+	assert.Equal(t, 28, rateLimit.Remaining)
+	assert.Equal(t, time.Unix(1700000060, 0), rateLimit.Reset)
+}
+
+func TestRateLimit_Resource(t *testing.T) {
+	reset := time.Unix(1700000060, 0)
+	legacy := &RateLimit{Limit: 30, Remaining: 20, Reset: reset}
+
+	search, ok := legacy.Resource(ResourceSearch)
+	require.True(t, ok, "the top-level fields stand in for the search quota")
+	assert.Equal(t, ResourceRateLimit{Limit: 30, Remaining: 20, Used: 10, Reset: reset}, search)
+
+	_, ok = legacy.Resource(ResourceCore)
+	assert.False(t, ok)
+	_, ok = (&RateLimit{Disabled: true}).Resource(ResourceSearch)
+	assert.False(t, ok)
+}
+
 func TestRealClient_GetFileContent_Integration(t *testing.T) {
 	// Skip if we can't create a real client (no auth)
 	client, err := NewRealClient()
@@ -305,7 +357,100 @@ func TestErrorHandling(t *testing.T) {
 
 func TestHelperFunctions(t *testing.T) {
 	t.Run("formatGoGHError", func(t *testing.T) {
-		// Test error conversion from go-gh API errors
-		// Full testing would be done via integration tests
+		notFound := &api.HTTPError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+
+		err := formatGoGHError(notFound, "github.com")
+		assert.IsType(t, &NotFoundError{}, err)
+		assert.EqualError(t, err, "Not Found")
+
+		// Errors from other hosts say which host failed
+		err = formatGoGHError(notFound, "ghe.example.com")
+		assert.IsType(t, &NotFoundError{}, err)
+		assert.EqualError(t, err, "ghe.example.com: Not Found")
+
+		rateLimited := &api.HTTPError{StatusCode: http.StatusForbidden, Message: "API rate limit exceeded"}
+		err = formatGoGHError(rateLimited, "ghe.example.com")
+		var rateLimitErr *RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Equal(t, "ghe.example.com: API rate limit exceeded", rateLimitErr.Message)
+		assert.Zero(t, rateLimitErr.ResetTime, "enterprise servers may send no reset headers")
+
+		// Secondary rate limits wait as long as Retry-After asks
+		for _, secondary := range []*api.HTTPError{
+			{StatusCode: http.StatusTooManyRequests, Message: "Too Many Requests", Headers: http.Header{"Retry-After": []string{"45"}}},
+			{StatusCode: http.StatusForbidden, Message: "You have exceeded a secondary rate limit", Headers: http.Header{"Retry-After": []string{"45"}}},
+		} {
+			var abuseErr *AbuseRateLimitError
+			require.ErrorAs(t, formatGoGHError(secondary, "github.com"), &abuseErr)
+			require.NotNil(t, abuseErr.RetryAfter)
+			assert.Equal(t, 45*time.Second, *abuseErr.RetryAfter)
+		}
+
+		var abuseErr *AbuseRateLimitError
+		require.ErrorAs(t, formatGoGHError(&api.HTTPError{StatusCode: http.StatusTooManyRequests, Message: "Too Many Requests"}, "github.com"), &abuseErr)
+		assert.Nil(t, abuseErr.RetryAfter)
+
+		unavailable := &api.HTTPError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}
+		assert.EqualError(t, formatGoGHError(unavailable, "ghe.example.com"), "ghe.example.com: GitHub API error (status 502): Bad Gateway")
+	})
+}
+
+// blockingTransport holds every request until its context is done
+type blockingTransport struct{}
+
+func (blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestRealClient_RequestsUseContext(t *testing.T) {
+	rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: blockingTransport{}})
+	require.NoError(t, err)
+	client := &RealClient{client: rest, host: "github.com"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := map[string]func() error{
+		"SearchCode": func() error {
+			_, err := client.SearchCode(ctx, "config", &SearchOptions{ListOptions: ListOptions{Page: 1, PerPage: 10}})
+			return err
+		},
+		"GetFileContent": func() error {
+			_, err := client.GetFileContent(ctx, "owner", "repo", "config.json", "")
+			return err
+		},
+		"GetRateLimit": func() error {
+			_, err := client.GetRateLimit(ctx)
+			return err
+		},
+		"ListOrgRepositories": func() error {
+			_, err := client.ListOrgRepositories(ctx, "owner")
+			return err
+		},
+	}
+	for name, call := range calls {
+		start := time.Now()
+		err := call()
+		require.Error(t, err, name)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, name)
+		assert.Less(t, time.Since(start), 5*time.Second, "%s should stop when the context ends", name)
+	}
+}
+
+func TestRealClient_EnrichmentSkippedWhenCancelled(t *testing.T) {
+	fetched := 0
+	client := &RealClient{client: &api.RESTClient{}, host: "github.com"}
+	client.enricher = NewRepoEnricher(NewRepoMetadataCache(), "github.com", 2, func(ctx context.Context, fullName string) (*Repository, error) {
+		fetched++
+		return &Repository{StargazersCount: IntPtr(1)}, nil
 	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := liteResults("owner/repo")
+	client.enrichRepositoryMetadata(ctx, results)
+	assert.Zero(t, fetched)
+	assert.Nil(t, results.Items[0].Repository.StargazersCount)
 }
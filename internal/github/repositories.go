@@ -0,0 +1,58 @@
+package github
+
+import (
+	"strings"
+)
+
+// RepoFilter selects repositories from an organization listing.
+// Archived repositories and forks are excluded unless explicitly included.
+type RepoFilter struct {
+	Topics          []string // repository must have every listed topic
+	Language        string   // primary repository language (case-insensitive)
+	MinStars        int
+	IncludeArchived bool
+	IncludeForks    bool
+}
+
+// Matches reports whether repo passes the filter
+func (f RepoFilter) Matches(repo *Repository) bool {
+	if !f.IncludeArchived && repo.Archived != nil && *repo.Archived {
+		return false
+	}
+	if !f.IncludeForks && repo.Fork != nil && *repo.Fork {
+		return false
+	}
+	if f.MinStars > 0 && (repo.StargazersCount == nil || *repo.StargazersCount < f.MinStars) {
+		return false
+	}
+	if f.Language != "" && (repo.Language == nil || !strings.EqualFold(*repo.Language, f.Language)) {
+		return false
+	}
+	for _, topic := range f.Topics {
+		if !hasTopic(repo, topic) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterRepositories returns the repositories that pass the filter, in order
+func FilterRepositories(repos []Repository, filter RepoFilter) []Repository {
+	var filtered []Repository
+	for i := range repos {
+		if filter.Matches(&repos[i]) {
+			filtered = append(filtered, repos[i])
+		}
+	}
+	return filtered
+}
+
+// hasTopic reports whether repo is tagged with topic (case-insensitive)
+func hasTopic(repo *Repository, topic string) bool {
+	for _, t := range repo.Topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoFilter_Matches(t *testing.T) {
+	base := CreateTestRepository("acme/web", 500)
+	base.Language = StringPtr("TypeScript")
+	base.Topics = []string{"frontend", "react"}
+
+	archived := base
+	archived.Archived = BoolPtr(true)
+
+	fork := base
+	fork.Fork = BoolPtr(true)
+
+	noStars := base
+	noStars.StargazersCount = nil
+
+	tests := []struct {
+		name     string
+		repo     Repository
+		filter   RepoFilter
+		expected bool
+	}{
+		{name: "empty filter matches", repo: base, filter: RepoFilter{}, expected: true},
+		{name: "archived excluded by default", repo: archived, filter: RepoFilter{}, expected: false},
+		{name: "archived included on request", repo: archived, filter: RepoFilter{IncludeArchived: true}, expected: true},
+		{name: "fork excluded by default", repo: fork, filter: RepoFilter{}, expected: false},
+		{name: "fork included on request", repo: fork, filter: RepoFilter{IncludeForks: true}, expected: true},
+		{name: "min stars met", repo: base, filter: RepoFilter{MinStars: 500}, expected: true},
+		{name: "min stars not met", repo: base, filter: RepoFilter{MinStars: 501}, expected: false},
+		{name: "unknown stars fail min stars", repo: noStars, filter: RepoFilter{MinStars: 1}, expected: false},
+		{name: "language is case-insensitive", repo: base, filter: RepoFilter{Language: "typescript"}, expected: true},
+		{name: "language mismatch", repo: base, filter: RepoFilter{Language: "go"}, expected: false},
+		{name: "all topics required", repo: base, filter: RepoFilter{Topics: []string{"React", "frontend"}}, expected: true},
+		{name: "missing topic", repo: base, filter: RepoFilter{Topics: []string{"react", "cli"}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.Matches(&tt.repo))
+		})
+	}
+}
+
+func TestFilterRepositories(t *testing.T) {
+	small := CreateTestRepository("acme/small", 5)
+	large := CreateTestRepository("acme/large", 5000)
+
+	filtered := FilterRepositories([]Repository{small, large}, RepoFilter{MinStars: 100})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "acme/large", *filtered[0].FullName)
+
+	assert.Empty(t, FilterRepositories(nil, RepoFilter{}))
+}
+
+func TestCachingClient_ListOrgRepositories(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetOrgRepositories("acme", []Repository{CreateTestRepository("acme/web", 10)})
+	client := NewCachingClient(mock, NewResultCache(t.TempDir(), time.Hour), "github.com", false)
+
+	for i := 0; i < 2; i++ {
+		repos, err := client.ListOrgRepositories(context.Background(), "acme")
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, "acme/web", *repos[0].FullName)
+	}
+	assert.Equal(t, 1, mock.GetCallCount("ListOrgRepositories"))
+
+	_, err := client.ListOrgRepositories(context.Background(), "missing")
+	var notFound *NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
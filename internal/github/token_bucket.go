@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// SearchRequestsPerMinute is GitHub's code search limit for authenticated users
+const SearchRequestsPerMinute = 30
+
+// TokenBucket paces requests to a sustained rate while allowing short bursts.
+// It is safe for concurrent use; waiting callers are served in arrival order.
+type TokenBucket struct {
+	mu        sync.Mutex
+	capacity  float64
+	tokens    float64
+	interval  time.Duration // time to refill one token
+	last      time.Time
+	now       func() time.Time
+	unlimited bool
+}
+
+// NewTokenBucket creates a full bucket that refills capacity tokens every period
+func NewTokenBucket(capacity int, period time.Duration) *TokenBucket {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TokenBucket{
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		interval: period / time.Duration(capacity),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// NewSearchTokenBucket creates a bucket sized to the code search rate limit
+func NewSearchTokenBucket() *TokenBucket {
+	return NewTokenBucket(SearchRequestsPerMinute, time.Minute)
+}
+
+// NewSearchTokenBucketForLimit creates a bucket sized to a search rate limit
+// reported by the server. GitHub Enterprise Server administrators set their
+// own per-minute search limit or turn rate limiting off, in which case the
+// bucket never waits. Without a reported limit it falls back to github.com's.
+func NewSearchTokenBucketForLimit(limit *RateLimit) *TokenBucket {
+	switch {
+	case limit != nil && limit.Disabled:
+		return &TokenBucket{unlimited: true}
+	case limit != nil && limit.Limit > 0:
+		return NewTokenBucket(limit.Limit, time.Minute)
+	default:
+		return NewSearchTokenBucket()
+	}
+}
+
+// Wait blocks until a token is available and returns how long it waited
+func (b *TokenBucket) Wait(ctx context.Context) (time.Duration, error) {
+	if b.unlimited {
+		return 0, ctx.Err()
+	}
+
+	b.mu.Lock()
+	b.refill()
+	// Reserve a token now; a negative balance queues later callers behind us
+	b.tokens--
+	wait := time.Duration(0)
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens * float64(b.interval))
+	}
+	b.mu.Unlock()
+
+	if wait == 0 {
+		return 0, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		// Return the reservation so other callers are not delayed by it
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return 0, ctx.Err()
+	case <-time.After(wait):
+		return wait, nil
+	}
+}
+
+// Available returns the number of whole tokens currently available
+func (b *TokenBucket) Available() int {
+	if b.unlimited {
+		return math.MaxInt
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < 0 {
+		return 0
+	}
+	return int(b.tokens)
+}
+
+// refill adds tokens for the time elapsed since the last refill (caller holds mu)
+func (b *TokenBucket) refill() {
+	now := b.now()
+	elapsed := now.Sub(b.last)
+	b.last = now
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens += float64(elapsed) / float64(b.interval)
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+}
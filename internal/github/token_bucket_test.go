@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock for deterministic bucket tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestBucket(capacity int, period time.Duration) (*TokenBucket, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	bucket := NewTokenBucket(capacity, period)
+	bucket.now = clock.Now
+	bucket.last = clock.Now()
+	return bucket, clock
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket, _ := newTestBucket(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		waited, err := bucket.Wait(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, waited, "request %d should be served from the burst", i+1)
+	}
+	assert.Equal(t, 0, bucket.Available())
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	bucket, clock := newTestBucket(4, time.Minute)
+
+	for i := 0; i < 4; i++ {
+		_, err := bucket.Wait(context.Background())
+		require.NoError(t, err)
+	}
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 2, bucket.Available())
+
+	clock.Advance(5 * time.Minute)
+	assert.Equal(t, 4, bucket.Available(), "refill should be capped at capacity")
+}
+
+func TestTokenBucketWaitsWhenEmpty(t *testing.T) {
+	bucket := NewTokenBucket(1, 50*time.Millisecond)
+
+	_, err := bucket.Wait(context.Background())
+	require.NoError(t, err)
+
+	start := time.Now()
+	waited, err := bucket.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, waited, time.Duration(0))
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestTokenBucketCancelReturnsToken(t *testing.T) {
+	bucket, clock := newTestBucket(1, time.Minute)
+
+	_, err := bucket.Wait(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bucket.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The cancelled reservation must not delay the next refill
+	clock.Advance(time.Minute)
+	assert.Equal(t, 1, bucket.Available())
+}
+
+func TestNewSearchTokenBucket(t *testing.T) {
+	bucket := NewSearchTokenBucket()
+	assert.Equal(t, SearchRequestsPerMinute, bucket.Available())
+	assert.Equal(t, 2*time.Second, bucket.interval)
+}
+
+func TestNewSearchTokenBucketForLimit(t *testing.T) {
+	assert.Equal(t, SearchRequestsPerMinute, NewSearchTokenBucketForLimit(nil).Available())
+	assert.Equal(t, SearchRequestsPerMinute, NewSearchTokenBucketForLimit(&RateLimit{}).Available())
+	assert.Equal(t, 120, NewSearchTokenBucketForLimit(&RateLimit{Limit: 120}).Available())
+
+	// A server without rate limiting never makes callers wait
+	unlimited := NewSearchTokenBucketForLimit(&RateLimit{Disabled: true})
+	for i := 0; i < 100; i++ {
+		waited, err := unlimited.Wait(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, waited)
+	}
+}
@@ -0,0 +1,210 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/jsonpretty"
+	"github.com/cli/go-gh/v2/pkg/template"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// fieldValues extracts each --json field from a search result. Dotted
+// names are written as nested objects, so repository.fullName becomes
+// {"repository": {"fullName": ...}}. Missing values are written as null.
+var fieldValues = map[string]func(item *github.SearchItem) interface{}{
+	"name":     func(item *github.SearchItem) interface{} { return stringField(item.Name) },
+	"path":     func(item *github.SearchItem) interface{} { return stringField(item.Path) },
+	"sha":      func(item *github.SearchItem) interface{} { return stringField(item.SHA) },
+	"url":      func(item *github.SearchItem) interface{} { return stringField(item.HTMLURL) },
+	"score":    func(item *github.SearchItem) interface{} { return pointerField(item.Score) },
+	"fragment": func(item *github.SearchItem) interface{} { return firstFragment(item) },
+	"fragments": func(item *github.SearchItem) interface{} {
+		fragments := make([]string, 0, len(item.TextMatches))
+		for _, match := range item.TextMatches {
+			if match.Fragment != nil {
+				fragments = append(fragments, *match.Fragment)
+			}
+		}
+		return fragments
+	},
+	"stars":    func(item *github.SearchItem) interface{} { return pointerField(item.Repository.StargazersCount) },
+	"forks":    func(item *github.SearchItem) interface{} { return pointerField(item.Repository.ForksCount) },
+	"language": func(item *github.SearchItem) interface{} { return stringField(item.Repository.Language) },
+	"pushedAt": func(item *github.SearchItem) interface{} { return timeField(item.Repository.PushedAt) },
+
+	"repository.fullName":    func(item *github.SearchItem) interface{} { return stringField(item.Repository.FullName) },
+	"repository.name":        func(item *github.SearchItem) interface{} { return stringField(item.Repository.Name) },
+	"repository.url":         func(item *github.SearchItem) interface{} { return stringField(item.Repository.HTMLURL) },
+	"repository.description": func(item *github.SearchItem) interface{} { return stringField(item.Repository.Description) },
+	"repository.owner": func(item *github.SearchItem) interface{} {
+		if item.Repository.Owner == nil {
+			return nil
+		}
+		return stringField(item.Repository.Owner.Login)
+	},
+	"repository.topics":        func(item *github.SearchItem) interface{} { return item.Repository.Topics },
+	"repository.isArchived":    func(item *github.SearchItem) interface{} { return pointerField(item.Repository.Archived) },
+	"repository.isFork":        func(item *github.SearchItem) interface{} { return pointerField(item.Repository.Fork) },
+	"repository.defaultBranch": func(item *github.SearchItem) interface{} { return stringField(item.Repository.DefaultBranch) },
+	"repository.license": func(item *github.SearchItem) interface{} {
+		if item.Repository.License == nil {
+			return nil
+		}
+		return stringField(item.Repository.License.SPDXID)
+	},
+	"repository.createdAt": func(item *github.SearchItem) interface{} { return timeField(item.Repository.CreatedAt) },
+	"repository.updatedAt": func(item *github.SearchItem) interface{} { return timeField(item.Repository.UpdatedAt) },
+}
+
+// SupportedFields lists the field names accepted by ParseFields
+func SupportedFields() []string {
+	return []string{
+		"name", "path", "sha", "url", "score", "fragment", "fragments",
+		"stars", "forks", "language", "pushedAt",
+		"repository.fullName", "repository.name", "repository.owner", "repository.url",
+		"repository.description", "repository.topics", "repository.isArchived", "repository.isFork",
+		"repository.defaultBranch", "repository.license", "repository.createdAt", "repository.updatedAt",
+	}
+}
+
+// ParseFields validates --json field names, which may also be
+// comma-separated within an entry. Names are case-sensitive, as in gh.
+func ParseFields(names []string) ([]string, error) {
+	var fields []string
+	for _, name := range names {
+		for _, field := range strings.Split(name, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if _, ok := fieldValues[field]; !ok {
+				return nil, fmt.Errorf("Unknown JSON field: %q\n%s", field, FieldsHelp("Available fields:"))
+			}
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New(FieldsHelp("Specify one or more comma-separated fields for `--json`:"))
+	}
+	return fields, nil
+}
+
+// FieldsHelp lists the supported fields under heading
+func FieldsHelp(heading string) string {
+	return heading + "\n  " + strings.Join(SupportedFields(), "\n  ")
+}
+
+// ExportFields returns one object per item holding only fields
+func ExportFields(items []github.SearchItem, fields []string) []map[string]interface{} {
+	exported := make([]map[string]interface{}, len(items))
+	for i := range items {
+		object := map[string]interface{}{}
+		for _, field := range fields {
+			setField(object, strings.Split(field, "."), fieldValues[field](&items[i]))
+		}
+		exported[i] = object
+	}
+	return exported
+}
+
+// ExportFormatter renders selected fields as JSON, optionally filtered
+// through a jq expression or rendered with a Go template, the way gh's
+// --json, --jq and --template flags do
+type ExportFormatter struct {
+	Fields   []string
+	JQ       string
+	Template string
+	Color    bool // colorize JSON and enable the template color helpers
+	Width    int  // terminal width for template tables and truncation
+}
+
+// Format formats search results as the selected fields
+func (f *ExportFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	data, err := json.Marshal(ExportFields(results.Items, f.Fields))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.write(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// write renders data, a JSON array of exported results, to w
+func (f *ExportFormatter) write(w io.Writer, data []byte) error {
+	switch {
+	case f.JQ != "":
+		if err := jq.EvaluateFormatted(bytes.NewReader(data), w, f.JQ, "  ", f.Color); err != nil {
+			return fmt.Errorf("failed to evaluate --jq: %w", err)
+		}
+	case f.Template != "":
+		tmpl := template.New(w, f.Width, f.Color)
+		if err := tmpl.Parse(f.Template); err != nil {
+			return fmt.Errorf("failed to parse --template: %w", err)
+		}
+		if err := tmpl.Execute(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to execute --template: %w", err)
+		}
+		return tmpl.Flush()
+	default:
+		return jsonpretty.Format(w, bytes.NewReader(data), "  ", f.Color)
+	}
+	return nil
+}
+
+// setField stores value under the nested keys of path
+func setField(object map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := object[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			object[key] = child
+		}
+		object = child
+	}
+	object[path[len(path)-1]] = value
+}
+
+// firstFragment returns the first text match fragment, or null
+func firstFragment(item *github.SearchItem) interface{} {
+	for _, match := range item.TextMatches {
+		if match.Fragment != nil {
+			return *match.Fragment
+		}
+	}
+	return nil
+}
+
+// stringField returns the string or null
+func stringField(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// pointerField returns the pointed-to value or null
+func pointerField[T any](v *T) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// timeField returns the time in RFC 3339, or null
+func timeField(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
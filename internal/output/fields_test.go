@@ -0,0 +1,112 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields([]string{"path,repository.fullName", " stars "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"path", "repository.fullName", "stars"}, fields)
+
+	_, err = ParseFields([]string{"Stars"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unknown JSON field: \"Stars\"\nAvailable fields:\n  name\n  path\n")
+
+	_, err = ParseFields(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Specify one or more comma-separated fields for `--json`:")
+
+	// Every supported field has an extractor
+	for _, field := range SupportedFields() {
+		assert.Contains(t, fieldValues, field)
+	}
+	assert.Len(t, fieldValues, len(SupportedFields()))
+}
+
+func TestExportFields(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[1].Repository.StargazersCount = nil
+
+	exported := ExportFields(results.Items, []string{"repository.fullName", "repository.owner", "stars", "fragment"})
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"repository": {"fullName": "facebook/react", "owner": "facebook"}, "stars": 50000,
+		 "fragment": "function useState<S>(initialState: S | (() => S)): [S, Dispatch<SetStateAction<S>>] {\n  return resolveDispatcher().useState(initialState);\n}"},
+		{"repository": {"fullName": "vercel/next.js", "owner": "vercel"}, "stars": null,
+		 "fragment": "{\n  \"name\": \"next\",\n  \"version\": \"13.0.0\"\n}"}
+	]`, string(data))
+}
+
+func TestExportFormatter(t *testing.T) {
+	results := createTestSearchResults()
+
+	tests := []struct {
+		name      string
+		formatter ExportFormatter
+		want      string
+	}{
+		{
+			name:      "json",
+			formatter: ExportFormatter{Fields: []string{"path", "stars"}},
+			want:      "[\n  {\n    \"path\": \"packages/react/src/ReactHooks.ts\",\n    \"stars\": 50000\n  },\n  {\n    \"path\": \"packages/next/package.json\",\n    \"stars\": 30000\n  }\n]\n",
+		},
+		{
+			name:      "jq",
+			formatter: ExportFormatter{Fields: []string{"repository.fullName", "stars"}, JQ: `.[] | select(.stars > 40000) | .repository.fullName`},
+			want:      "facebook/react\n",
+		},
+		{
+			name:      "template",
+			formatter: ExportFormatter{Fields: []string{"repository.fullName", "path"}, Template: `{{range .}}{{.repository.fullName}} {{truncate 12 .path}}{{"\n"}}{{end}}`, Width: 80},
+			want:      "facebook/react packages/...\nvercel/next.js packages/...\n",
+		},
+		{
+			name:      "autocolor without color",
+			formatter: ExportFormatter{Fields: []string{"path"}, Template: `{{range .}}{{autocolor "green" .path}}{{"\n"}}{{end}}`, Width: 80},
+			want:      "packages/react/src/ReactHooks.ts\npackages/next/package.json\n",
+		},
+		{
+			name:      "autocolor with color",
+			formatter: ExportFormatter{Fields: []string{"path"}, Template: `{{with index . 1}}{{autocolor "green" .path}}{{end}}`, Width: 80, Color: true},
+			want:      "\x1b[0;32mpackages/next/package.json\x1b[0m",
+		},
+		{
+			name:      "hyperlink",
+			formatter: ExportFormatter{Fields: []string{"url", "path"}, Template: `{{with index . 0}}{{hyperlink .url .path}}{{end}}`, Width: 80},
+			want:      "\x1b]8;;https://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts\x1b\\packages/react/src/ReactHooks.ts\x1b]8;;\x1b\\",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.formatter.Format(results, "useState")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestExportFormatter_Errors(t *testing.T) {
+	results := createTestSearchResults()
+
+	_, err := (&ExportFormatter{Fields: []string{"path"}, JQ: ".[] |"}).Format(results, "q")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to evaluate --jq")
+
+	_, err = (&ExportFormatter{Fields: []string{"path"}, Template: "{{range .}"}).Format(results, "q")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse --template")
+
+	// Empty results export an empty array
+	out, err := (&ExportFormatter{Fields: []string{"path"}}).Format(&github.SearchResults{Total: github.IntPtr(0)}, "q")
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", out)
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// Output format names accepted by NewFormatter
+const (
+	FormatDefault  = "default"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatCompact  = "compact"
+	FormatPipe     = "pipe"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatNDJSON   = "ndjson"
+	FormatHTML     = "html"
+	FormatSARIF    = "sarif"
+)
+
+// Formatter renders search results in a single output format
+type Formatter interface {
+	Format(results *github.SearchResults, query string) (string, error)
+}
+
+// Options controls what formatters include in their output.
+// Display settings come from the user's output configuration; Page and
+// Limit describe the request so formatters can report result ranges.
+type Options struct {
+	ShowStars       bool
+	ShowRepository  bool
+	ShowLineNumbers bool
+	ShowPatterns    bool
+	MaxContentLines int      // 0 means unlimited
+	Columns         []string // csv and tsv columns, DefaultColumns when empty
+
+	Page  int // requested page, 0 when results were auto-paginated
+	Limit int // results requested per page (or in total when auto-paginated)
+}
+
+// DefaultOptions returns options matching the default output configuration
+func DefaultOptions() Options {
+	return Options{
+		ShowStars:       true,
+		ShowRepository:  true,
+		ShowPatterns:    true,
+		MaxContentLines: 50,
+		Limit:           50,
+	}
+}
+
+// SupportedFormats lists the format names accepted by NewFormatter
+func SupportedFormats() []string {
+	return []string{FormatDefault, FormatJSON, FormatMarkdown, FormatCompact, FormatPipe, FormatCSV, FormatTSV, FormatNDJSON, FormatHTML, FormatSARIF}
+}
+
+// NewFormatter returns the formatter for format configured with opts.
+// An empty format selects the default formatter.
+func NewFormatter(format string, opts Options) (Formatter, error) {
+	switch format {
+	case FormatDefault, "":
+		return &DefaultFormatter{Options: opts}, nil
+	case FormatJSON:
+		return &JSONFormatter{Options: opts}, nil
+	case FormatMarkdown:
+		f := NewMarkdownFormatter()
+		f.ShowStars = opts.ShowStars
+		f.ShowRepository = opts.ShowRepository
+		f.ShowLineNumbers = opts.ShowLineNumbers
+		f.ShowPatterns = opts.ShowPatterns
+		f.MaxContentLines = opts.MaxContentLines
+		return f, nil
+	case FormatCompact:
+		return &CompactFormatter{Options: opts}, nil
+	case FormatPipe:
+		return &PipeFormatter{}, nil
+	case FormatCSV:
+		return &TableFormatter{Options: opts, Comma: ','}, nil
+	case FormatTSV:
+		return &TableFormatter{Options: opts, Comma: '\t'}, nil
+	case FormatNDJSON:
+		return &NDJSONFormatter{Options: opts}, nil
+	case FormatHTML:
+		return &HTMLFormatter{Options: opts}, nil
+	case FormatSARIF:
+		return &SARIFFormatter{Options: opts}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s (supported: %s)", format, strings.Join(SupportedFormats(), ", "))
+	}
+}
+
+// resultRange returns the 1-based range of results shown for the requested page
+func resultRange(results *github.SearchResults, opts Options) (start, end int) {
+	return displayedRange(len(results.Items), opts)
+}
+
+// displayedRange returns the 1-based range of displayed results on the requested page
+func displayedRange(displayed int, opts Options) (start, end int) {
+	if opts.Page > 0 {
+		start = (opts.Page-1)*opts.Limit + 1
+		return start, start + displayed - 1
+	}
+	return 1, displayed
+}
+
+// hasFetchedContent reports whether an item has snippets from --fetch-content
+func hasFetchedContent(item *github.SearchItem) bool {
+	return item.Content != nil && item.Content.Error == "" && len(item.Content.Snippets) > 0
+}
+
+// duplicateSummary describes the copies --dedupe collapsed into an item,
+// or returns "" when there are none
+func duplicateSummary(item *github.SearchItem) string {
+	if len(item.Duplicates) == 0 {
+		return ""
+	}
+	if repositories := len(item.DuplicateRepositories()); repositories > 0 {
+		if repositories == 1 {
+			return "Also found in 1 other repo"
+		}
+		return fmt.Sprintf("Also found in %d other repos", repositories)
+	}
+	if len(item.Duplicates) == 1 {
+		return "Also found at 1 other path"
+	}
+	return fmt.Sprintf("Also found at %d other paths", len(item.Duplicates))
+}
+
+// writeContentSnippets renders fetched snippets as numbered code blocks.
+// Matching lines use "N:" and context lines "N-", like grep. Each snippet
+// is cut to maxLines when maxLines is positive.
+func writeContentSnippets(buf *strings.Builder, content *github.FileContent, lang string, maxLines int) {
+	width := len(fmt.Sprint(content.TotalLines))
+	for _, snippet := range content.Snippets {
+		lines := snippet.Lines
+		if maxLines > 0 && len(lines) > maxLines {
+			lines = lines[:maxLines]
+		}
+
+		buf.WriteString(fmt.Sprintf("```%s\n", lang))
+		for _, line := range lines {
+			separator := "-"
+			if line.Match {
+				separator = ":"
+			}
+			buf.WriteString(fmt.Sprintf("%*d%s %s\n", width, line.Number, separator, line.Text))
+		}
+		if remaining := len(snippet.Lines) - len(lines); remaining > 0 {
+			buf.WriteString(fmt.Sprintf("... (%d more lines)\n", remaining))
+		}
+		buf.WriteString("```\n")
+	}
+}
+
+// formatFragment applies line numbering and the content line limit to a match fragment
+func formatFragment(fragment string, showLineNumbers bool, maxLines int) string {
+	lines := strings.Split(strings.TrimSuffix(fragment, "\n"), "\n")
+	remaining := 0
+	if maxLines > 0 && len(lines) > maxLines {
+		remaining = len(lines) - maxLines
+		lines = lines[:maxLines]
+	}
+
+	var buf strings.Builder
+	for i, line := range lines {
+		if showLineNumbers {
+			buf.WriteString(fmt.Sprintf("%3d: %s\n", i+1, line))
+		} else {
+			buf.WriteString(line + "\n")
+		}
+	}
+	if remaining > 0 {
+		buf.WriteString(fmt.Sprintf("... (%d more lines)\n", remaining))
+	}
+	return buf.String()
+}
@@ -0,0 +1,190 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormatter(t *testing.T) {
+	tests := []struct {
+		format string
+		want   Formatter
+	}{
+		{"", &DefaultFormatter{}},
+		{FormatDefault, &DefaultFormatter{}},
+		{FormatJSON, &JSONFormatter{}},
+		{FormatMarkdown, &MarkdownFormatter{}},
+		{FormatCompact, &CompactFormatter{}},
+		{FormatPipe, &PipeFormatter{}},
+		{FormatCSV, &TableFormatter{}},
+		{FormatTSV, &TableFormatter{}},
+		{FormatNDJSON, &NDJSONFormatter{}},
+		{FormatHTML, &HTMLFormatter{}},
+		{FormatSARIF, &SARIFFormatter{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			formatter, err := NewFormatter(tt.format, DefaultOptions())
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, formatter)
+		})
+	}
+
+	_, err := NewFormatter("yaml", DefaultOptions())
+	assert.EqualError(t, err, "unsupported format: yaml (supported: default, json, markdown, compact, pipe, csv, tsv, ndjson, html, sarif)")
+}
+
+func TestNewFormatter_MarkdownOptions(t *testing.T) {
+	opts := Options{ShowLineNumbers: true, MaxContentLines: 2}
+	formatter, err := NewFormatter(FormatMarkdown, opts)
+	require.NoError(t, err)
+
+	markdown := formatter.(*MarkdownFormatter)
+	assert.True(t, markdown.ShowLineNumbers)
+	assert.False(t, markdown.ShowStars)
+	assert.False(t, markdown.ShowRepository)
+	assert.Equal(t, 2, markdown.MaxContentLines)
+
+	output, err := formatter.Format(createTestSearchResults(), "useState")
+	require.NoError(t, err)
+	assert.Contains(t, output, "## 1. 🔷 packages/react/src/ReactHooks.ts")
+	assert.NotContains(t, output, "📁 [facebook/react]")
+	assert.Contains(t, output, "  2:   return resolveDispatcher().useState(initialState);\n... (1 more lines)")
+	assert.NotContains(t, output, "⭐")
+}
+
+func TestDefaultFormatter(t *testing.T) {
+	results := createTestSearchResults()
+
+	t.Run("default options", func(t *testing.T) {
+		output, err := (&DefaultFormatter{Options: DefaultOptions()}).Format(results, "useState")
+		require.NoError(t, err)
+		assert.Contains(t, output, "🔍 Found 2 results")
+		assert.Contains(t, output, "📁 [facebook/react](https://github.com/facebook/react) ⭐ 50000")
+		assert.Contains(t, output, "📄 **packages/react/src/ReactHooks.ts**")
+		assert.Contains(t, output, "```typescript\nfunction useState")
+		assert.Contains(t, output, "🔗 [View on GitHub](https://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts)")
+	})
+
+	t.Run("output settings applied", func(t *testing.T) {
+		opts := Options{ShowRepository: true, ShowLineNumbers: true, MaxContentLines: 1, Limit: 50}
+		output, err := (&DefaultFormatter{Options: opts}).Format(results, "useState")
+		require.NoError(t, err)
+		assert.Contains(t, output, "📁 [facebook/react](https://github.com/facebook/react)\n")
+		assert.NotContains(t, output, "⭐")
+		assert.Contains(t, output, "  1: function useState")
+		assert.Contains(t, output, "... (2 more lines)")
+		assert.NotContains(t, output, "resolveDispatcher")
+	})
+
+	t.Run("repository hidden", func(t *testing.T) {
+		output, err := (&DefaultFormatter{Options: Options{ShowStars: true}}).Format(results, "useState")
+		require.NoError(t, err)
+		assert.NotContains(t, output, "📁")
+		assert.Contains(t, output, "📄 **packages/react/src/ReactHooks.ts**")
+	})
+
+	t.Run("pagination guidance", func(t *testing.T) {
+		paged := createTestSearchResults()
+		paged.Total = github.IntPtr(30)
+		output, err := (&DefaultFormatter{Options: Options{Page: 2, Limit: 2}}).Format(paged, "useState")
+		require.NoError(t, err)
+		assert.Contains(t, output, "🔍 Found 30 total results (showing 3-4)")
+		assert.Contains(t, output, "💡 **26 more results available** - Use `--page 3` to see results 5-6")
+	})
+}
+
+func TestDefaultFormatter_FetchedContent(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[0].Content = &github.FileContent{
+		Ref:        "main",
+		TotalLines: 12,
+		Snippets: []github.ContentSnippet{{
+			StartLine: 8,
+			EndLine:   10,
+			Lines: []github.ContentLine{
+				{Number: 8, Text: "// hooks"},
+				{Number: 9, Text: "function useState() {", Match: true},
+				{Number: 10, Text: "}"},
+			},
+		}},
+	}
+
+	output, err := (&DefaultFormatter{Options: Options{MaxContentLines: 2}}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Contains(t, output, "```typescript\n 8- // hooks\n 9: function useState() {\n... (1 more lines)\n```")
+
+	markdown, err := (&MarkdownFormatter{ShowRepository: true}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "**Code:** (main, 12 lines)")
+	assert.Contains(t, markdown, "10- }")
+}
+
+func TestJSONFormatter(t *testing.T) {
+	results := createTestSearchResults()
+	results.Total = github.IntPtr(10)
+
+	output, err := (&JSONFormatter{Options: Options{Page: 1, Limit: 2}}).Format(results, "useState")
+	require.NoError(t, err)
+
+	var decoded struct {
+		Items      []github.SearchItem `json:"items"`
+		Pagination PaginationInfo      `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Len(t, decoded.Items, 2)
+	assert.Equal(t, PaginationInfo{
+		TotalResults:     10,
+		DisplayedResults: 2,
+		StartResult:      1,
+		EndResult:        2,
+		CurrentPage:      1,
+		PerPage:          2,
+		HasNextPage:      true,
+		NextPage:         2,
+	}, decoded.Pagination)
+}
+
+func TestCompactAndPipeFormatters(t *testing.T) {
+	results := createTestSearchResults()
+	results.Total = github.IntPtr(5)
+
+	compact, err := (&CompactFormatter{}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Equal(t, "# Results 1-2 of 5 total\nfacebook/react:packages/react/src/ReactHooks.ts\nvercel/next.js:packages/next/package.json\n", compact)
+
+	piped, err := (&PipeFormatter{}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Contains(t, piped, "facebook/react:packages/react/src/ReactHooks.ts:https://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts\n")
+}
+
+func TestFormatters_Duplicates(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[0].Duplicates = []github.Duplicate{
+		{Repository: "preactjs/preact", Path: "hooks/src/index.js", Stars: 36000, HTMLURL: "https://github.com/preactjs/preact/blob/main/hooks/src/index.js"},
+		{Repository: "someone/react", Path: "packages/react/src/ReactHooks.ts", Stars: 2},
+	}
+	results.Items[1].Duplicates = []github.Duplicate{
+		{Repository: "vercel/next.js", Path: "examples/app/package.json"},
+	}
+
+	text, err := (&DefaultFormatter{Options: DefaultOptions()}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Contains(t, text, "🧬 Also found in 2 other repos\n")
+	assert.Contains(t, text, "🧬 Also found at 1 other path\n")
+
+	markdown, err := (&MarkdownFormatter{ShowRepository: true}).Format(results, "useState")
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "🧬 **Also found in 2 other repos:**\n- [preactjs/preact/hooks/src/index.js](https://github.com/preactjs/preact/blob/main/hooks/src/index.js) ⭐ 36000\n- someone/react/packages/react/src/ReactHooks.ts ⭐ 2\n")
+
+	encoded, err := (&JSONFormatter{}).Format(results, "useState")
+	require.NoError(t, err)
+	var decoded github.SearchResults
+	require.NoError(t, json.Unmarshal([]byte(encoded), &decoded))
+	assert.Equal(t, results.Items[0].Duplicates, decoded.Items[0].Duplicates)
+}
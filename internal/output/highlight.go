@@ -0,0 +1,184 @@
+package output
+
+import (
+	"html/template"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// highlightStyle is the chroma style embedded in HTML reports
+const highlightStyle = "github"
+
+// highlightCSS styles the token classes written by highlightLines
+var highlightCSS = func() template.CSS {
+	var buf strings.Builder
+	if err := chromahtml.New(chromahtml.WithClasses(true)).WriteCSS(&buf, styles.Get(highlightStyle)); err != nil {
+		return ""
+	}
+	return template.CSS(buf.String())
+}()
+
+// byteRange is a half-open range of byte offsets
+type byteRange struct {
+	start, end int
+}
+
+// highlightLines tokenizes code with the lexer for filePath, falling back
+// to guessing from the code, and returns one HTML line per source line.
+// Bytes inside marks are wrapped in <mark>.
+func highlightLines(code, filePath string, marks []byteRange) []template.HTML {
+	var lines []template.HTML
+	var line strings.Builder
+	offset := 0
+
+	for _, token := range tokenize(code, filePath) {
+		class := tokenClass(token.Type)
+		text := token.Value
+		for text != "" {
+			if text[0] == '\n' {
+				lines = append(lines, template.HTML(line.String()))
+				line.Reset()
+				text = text[1:]
+				offset++
+				continue
+			}
+
+			n := len(text)
+			if i := strings.IndexByte(text, '\n'); i > 0 {
+				n = i
+			}
+			marked, boundary := markAt(marks, offset)
+			n = min(n, boundary-offset)
+
+			writeHighlighted(&line, class, marked, text[:n])
+			text = text[n:]
+			offset += n
+		}
+	}
+	if line.Len() > 0 || len(lines) == 0 {
+		lines = append(lines, template.HTML(line.String()))
+	}
+	return lines
+}
+
+// tokenize splits code into chroma tokens. Code the lexer does not
+// reproduce byte for byte is returned as a single text token so match
+// offsets stay valid.
+func tokenize(code, filePath string) []chroma.Token {
+	plain := []chroma.Token{{Type: chroma.Text, Value: code}}
+
+	lexer := lexers.Match(filepath.Base(filePath))
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		return plain
+	}
+
+	// The default options rewrite CRLF line endings, which would shift offsets
+	iterator, err := chroma.Coalesce(lexer).Tokenise(&chroma.TokeniseOptions{State: "root"}, code)
+	if err != nil {
+		return plain
+	}
+	tokens := iterator.Tokens()
+
+	var rebuilt strings.Builder
+	for _, token := range tokens {
+		rebuilt.WriteString(token.Value)
+	}
+	if !strings.HasPrefix(rebuilt.String(), code) {
+		return plain
+	}
+	return tokens
+}
+
+// tokenClass returns the CSS class chroma uses for a token type or its
+// nearest parent. Fragments are cut mid-file, so lexer errors are left
+// unstyled rather than flagged.
+func tokenClass(t chroma.TokenType) string {
+	if t == chroma.Error {
+		return ""
+	}
+	for ; t != 0; t = t.Parent() {
+		if class, ok := chroma.StandardTypes[t]; ok {
+			return class
+		}
+	}
+	return ""
+}
+
+// markAt reports whether offset falls inside one of the sorted marks, and
+// the offset at which that changes
+func markAt(marks []byteRange, offset int) (bool, int) {
+	for _, mark := range marks {
+		if offset < mark.start {
+			return false, mark.start
+		}
+		if offset < mark.end {
+			return true, mark.end
+		}
+	}
+	return false, int(^uint(0) >> 1)
+}
+
+// writeHighlighted writes escaped text inside its token class span and,
+// when marked, a <mark>
+func writeHighlighted(buf *strings.Builder, class string, marked bool, text string) {
+	if marked {
+		buf.WriteString("<mark>")
+	}
+	if class != "" {
+		buf.WriteString(`<span class="` + class + `">`)
+	}
+	buf.WriteString(template.HTMLEscapeString(text))
+	if class != "" {
+		buf.WriteString("</span>")
+	}
+	if marked {
+		buf.WriteString("</mark>")
+	}
+}
+
+// matchRanges converts the character indices GitHub reports for matches
+// within fragment to sorted, merged byte ranges. Indices outside the
+// fragment are clamped.
+func matchRanges(fragment string, matches []github.Match) []byteRange {
+	runeOffsets := make([]int, 0, len(fragment)+1)
+	for i := range fragment {
+		runeOffsets = append(runeOffsets, i)
+	}
+	runeOffsets = append(runeOffsets, len(fragment))
+	byteOffset := func(index int) int {
+		return runeOffsets[max(0, min(index, len(runeOffsets)-1))]
+	}
+
+	var ranges []byteRange
+	for _, match := range matches {
+		if len(match.Indices) < 2 {
+			continue
+		}
+		start, end := byteOffset(match.Indices[0]), byteOffset(match.Indices[1])
+		if start < end {
+			ranges = append(ranges, byteRange{start, end})
+		}
+	}
+
+	slices.SortFunc(ranges, func(a, b byteRange) int { return a.start - b.start })
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, r.end)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,352 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// HTMLReport is a self-contained HTML page of search results. Each section
+// becomes a tab when there is more than one.
+type HTMLReport struct {
+	Title       string
+	Description string
+	Summary     []string
+	Sections    []HTMLSection
+	MaxLines    int // fragment lines shown per match, all when zero
+}
+
+// HTMLSection is one tab of an HTML report: a search's results or error,
+// followed by prepared content such as pattern tables or comparisons
+type HTMLSection struct {
+	Name    string
+	Query   string
+	Tags    []string
+	Error   string
+	Results *github.SearchResults
+	Extra   template.HTML
+}
+
+// htmlRepository groups a section's results by repository into one card
+type htmlRepository struct {
+	Name        string
+	URL         string
+	Description string
+	Language    string
+	Stars       string
+	Forks       string
+	Files       []htmlFile
+}
+
+// htmlFile is one matched file within a repository card
+type htmlFile struct {
+	Path       string
+	URL        string
+	Filter     string // lowercase text the filter box searches
+	Fragments  [][]template.HTML
+	Snippets   []htmlSnippet
+	Duplicates string
+}
+
+// htmlSnippet is a numbered window of a file fetched with --fetch-content
+type htmlSnippet struct {
+	Lines []htmlLine
+}
+
+// htmlLine is one numbered, highlighted line of a snippet
+type htmlLine struct {
+	Number int
+	Match  bool
+	Code   template.HTML
+}
+
+// htmlTab is the rendered data of one section
+type htmlTab struct {
+	HTMLSection
+	ID           string
+	Count        int
+	Repositories []htmlRepository
+}
+
+// Render renders the report as a single HTML document with its styles and
+// scripts inline
+func (r HTMLReport) Render() ([]byte, error) {
+	page := struct {
+		HTMLReport
+		CSS  template.CSS
+		Tabs []htmlTab
+	}{HTMLReport: r, CSS: highlightCSS}
+
+	for i, section := range r.Sections {
+		tab := htmlTab{HTMLSection: section, ID: fmt.Sprintf("section-%d", i+1)}
+		if section.Results != nil {
+			tab.Count = len(section.Results.Items)
+			tab.Repositories = htmlRepositories(section.Results.Items, r.MaxLines)
+		}
+		page.Tabs = append(page.Tabs, tab)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, page); err != nil {
+		return nil, fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// HTMLFormatter renders results as a self-contained HTML report
+type HTMLFormatter struct {
+	Options Options
+}
+
+// Format formats search results as an HTML report
+func (f *HTMLFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	report := HTMLReport{
+		Title:    "Search results: " + query,
+		Sections: []HTMLSection{{Name: query, Query: query, Results: results}},
+		MaxLines: f.Options.MaxContentLines,
+	}
+	if results.Total != nil {
+		start, end := resultRange(results, f.Options)
+		report.Summary = []string{fmt.Sprintf("Showing results %d-%d of %d", start, end, *results.Total)}
+	}
+	if results.IncompleteResults != nil && *results.IncompleteResults {
+		report.Summary = append(report.Summary, "⚠️ GitHub timed out and returned incomplete results")
+	}
+
+	content, err := report.Render()
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// htmlRepositories groups items into repository cards in the order each
+// repository first appears
+func htmlRepositories(items []github.SearchItem, maxLines int) []htmlRepository {
+	var repositories []htmlRepository
+	index := make(map[string]int)
+
+	for i := range items {
+		item := &items[i]
+		name := getStringValue(item.Repository.FullName)
+		position, seen := index[name]
+		if !seen {
+			position = len(repositories)
+			index[name] = position
+			repositories = append(repositories, htmlRepository{
+				Name:        name,
+				URL:         getStringValue(item.Repository.HTMLURL),
+				Description: getStringValue(item.Repository.Description),
+				Language:    getStringValue(item.Repository.Language),
+				Stars:       optionalNumber(item.Repository.StargazersCount),
+				Forks:       optionalNumber(item.Repository.ForksCount),
+			})
+		}
+		repositories[position].Files = append(repositories[position].Files, htmlFileFor(item, maxLines))
+	}
+	return repositories
+}
+
+// htmlFileFor highlights an item's fetched snippets, or its match
+// fragments when no content was fetched
+func htmlFileFor(item *github.SearchItem, maxLines int) htmlFile {
+	path := getStringValue(item.Path)
+	file := htmlFile{
+		Path:       path,
+		URL:        getStringValue(item.HTMLURL),
+		Duplicates: duplicateSummary(item),
+	}
+	filterText := []string{getStringValue(item.Repository.FullName), path, getStringValue(item.Repository.Language)}
+
+	if hasFetchedContent(item) {
+		for _, snippet := range item.Content.Snippets {
+			code := make([]string, len(snippet.Lines))
+			for i, line := range snippet.Lines {
+				code[i] = line.Text
+			}
+			highlighted := highlightLines(strings.Join(code, "\n"), path, nil)
+
+			var lines []htmlLine
+			for i, line := range snippet.Lines {
+				if maxLines > 0 && i >= maxLines {
+					break
+				}
+				lines = append(lines, htmlLine{Number: line.Number, Match: line.Match, Code: highlighted[min(i, len(highlighted)-1)]})
+			}
+			file.Snippets = append(file.Snippets, htmlSnippet{Lines: lines})
+			filterText = append(filterText, code...)
+		}
+	} else {
+		for _, match := range item.TextMatches {
+			fragment := getStringValue(match.Fragment)
+			if fragment == "" {
+				continue
+			}
+			lines := highlightLines(fragment, path, matchRanges(fragment, match.Matches))
+			if maxLines > 0 && len(lines) > maxLines {
+				lines = lines[:maxLines]
+			}
+			file.Fragments = append(file.Fragments, lines)
+			filterText = append(filterText, fragment)
+		}
+	}
+
+	file.Filter = strings.ToLower(strings.Join(filterText, "\n"))
+	return file
+}
+
+// optionalNumber formats a count, or returns "" when it is unknown
+func optionalNumber(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return formatNumber(*n)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 72rem; padding: 0 1rem; color: #1f2328; background: #fff; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+code { background: #f6f8fa; padding: 0.1rem 0.3rem; border-radius: 4px; }
+table { border-collapse: collapse; margin: 1rem 0; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: 0.4rem 0.6rem; text-align: left; }
+th { background: #f6f8fa; }
+.toolbar { position: sticky; top: 0; background: #fff; padding: 0.5rem 0; border-bottom: 1px solid #d0d7de; z-index: 1; }
+.toolbar input { width: 100%; box-sizing: border-box; padding: 0.5rem; font-size: 1rem; border: 1px solid #d0d7de; border-radius: 6px; }
+.status { color: #656d76; font-size: 0.85rem; margin: 0.3rem 0 0; }
+.tabs { display: flex; flex-wrap: wrap; gap: 0.25rem; margin-top: 0.5rem; }
+.tabs button { border: 1px solid #d0d7de; background: #f6f8fa; border-radius: 6px; padding: 0.3rem 0.7rem; cursor: pointer; font: inherit; }
+.tabs button[aria-selected="true"] { background: #0969da; border-color: #0969da; color: #fff; }
+.count { display: inline-block; min-width: 1.2rem; padding: 0 0.3rem; margin-left: 0.3rem; border-radius: 1rem; background: rgba(175, 184, 193, 0.4); font-size: 0.8rem; text-align: center; }
+.repo { border: 1px solid #d0d7de; border-radius: 6px; margin: 1rem 0; }
+.repo header { background: #f6f8fa; border-bottom: 1px solid #d0d7de; padding: 0.6rem 0.8rem; }
+.repo h3 { margin: 0; font-size: 1rem; }
+.repo .meta { color: #656d76; font-size: 0.85rem; margin: 0.3rem 0 0; }
+.repo .meta span + span::before { content: " · "; }
+.file { padding: 0.6rem 0.8rem; border-top: 1px solid #d0d7de; }
+.file:first-of-type { border-top: 0; }
+.file .path { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.9rem; }
+.file .duplicates { color: #656d76; font-size: 0.85rem; }
+pre.chroma { margin: 0.5rem 0 0; padding: 0.5rem; border-radius: 6px; overflow-x: auto; font-size: 0.85rem; line-height: 1.45; }
+pre.chroma .ln { display: inline-block; min-width: 3ch; margin-right: 1ch; color: #8c959f; text-align: right; user-select: none; }
+pre.chroma .hit { display: block; background: #fff8c5; }
+mark { background: #fae17d; color: inherit; border-radius: 2px; }
+.failed { color: #cf222e; }
+[hidden] { display: none !important; }
+{{.CSS}}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- range .Summary}}
+<p>{{.}}</p>
+{{- end}}
+<div class="toolbar">
+<input id="filter" type="search" placeholder="Filter by repository, path, language or code" autocomplete="off">
+<p class="status" id="filter-status"></p>
+{{- if gt (len .Tabs) 1}}
+<nav class="tabs" role="tablist">
+{{- range .Tabs}}
+<button role="tab" data-tab="{{.ID}}" aria-selected="false">{{.Name}}{{if .Results}}<span class="count">{{.Count}}</span>{{end}}</button>
+{{- end}}
+</nav>
+{{- end}}
+</div>
+{{- range .Tabs}}
+<section class="panel" id="{{.ID}}" role="tabpanel">
+{{- if .Error}}
+<h2>{{.Name}} <span class="failed">failed</span></h2>
+{{- if .Query}}
+<p>Query: <code>{{.Query}}</code></p>
+{{- end}}
+<p class="failed">{{.Error}}</p>
+{{- else}}
+{{- if .Results}}
+<h2>{{.Name}} ({{.Count}} results)</h2>
+{{- else}}
+<h2>{{.Name}}</h2>
+{{- end}}
+{{- if .Query}}
+<p>Query: <code>{{.Query}}</code></p>
+{{- end}}
+{{- if .Tags}}
+<p>Tags: {{range $i, $tag := .Tags}}{{if $i}}, {{end}}{{$tag}}{{end}}</p>
+{{- end}}
+{{- if and .Results (not .Repositories)}}
+<p>No results found.</p>
+{{- end}}
+{{- range .Repositories}}
+<article class="repo">
+<header>
+<h3>{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</h3>
+<p class="meta">{{if .Stars}}<span>⭐ {{.Stars}}</span>{{end}}{{if .Forks}}<span>🍴 {{.Forks}}</span>{{end}}{{if .Language}}<span>{{.Language}}</span>{{end}}{{if .Description}}<span>{{.Description}}</span>{{end}}</p>
+</header>
+{{- range .Files}}
+<div class="file" data-filter="{{.Filter}}">
+<div class="path">{{if .URL}}<a href="{{.URL}}">{{.Path}}</a>{{else}}{{.Path}}{{end}}</div>
+{{- if .Duplicates}}
+<div class="duplicates">{{.Duplicates}}</div>
+{{- end}}
+{{- range .Snippets}}
+<pre class="chroma"><code>{{range .Lines}}<span class="{{if .Match}}hit{{end}}"><span class="ln">{{.Number}}</span>{{.Code}}</span>
+{{end}}</code></pre>
+{{- end}}
+{{- range .Fragments}}
+<pre class="chroma"><code>{{range .}}{{.}}
+{{end}}</code></pre>
+{{- end}}
+</div>
+{{- end}}
+</article>
+{{- end}}
+{{- end}}
+{{- .Extra}}
+</section>
+{{- end}}
+<script>
+(function () {
+  var tabs = document.querySelectorAll('[role="tab"]');
+  var panels = document.querySelectorAll('[role="tabpanel"]');
+  function select(id) {
+    tabs.forEach(function (tab) { tab.setAttribute('aria-selected', String(tab.dataset.tab === id)); });
+    panels.forEach(function (panel) { panel.hidden = panel.id !== id; });
+  }
+  tabs.forEach(function (tab) { tab.addEventListener('click', function () { select(tab.dataset.tab); }); });
+  if (tabs.length > 0) { select(tabs[0].dataset.tab); }
+
+  var input = document.getElementById('filter');
+  var status = document.getElementById('filter-status');
+  var files = document.querySelectorAll('.file');
+  function filter() {
+    var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    var shown = 0;
+    files.forEach(function (file) {
+      var text = file.dataset.filter;
+      var match = terms.every(function (term) { return text.indexOf(term) !== -1; });
+      file.hidden = !match;
+      if (match) { shown++; }
+    });
+    document.querySelectorAll('.repo').forEach(function (repo) {
+      repo.hidden = repo.querySelector('.file:not([hidden])') === null;
+    });
+    status.textContent = terms.length ? shown + ' of ' + files.length + ' files match' : files.length + ' files';
+  }
+  input.addEventListener('input', filter);
+  filter();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+func TestMatchRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment string
+		matches  []github.Match
+		want     []byteRange
+	}{
+		{"ascii", "use useState here", []github.Match{{Indices: []int{4, 12}}}, []byteRange{{4, 12}}},
+		{"character indices", "é useState", []github.Match{{Indices: []int{2, 10}}}, []byteRange{{3, 11}}},
+		{"sorted and merged", "abcdefghij", []github.Match{{Indices: []int{6, 8}}, {Indices: []int{0, 2}}, {Indices: []int{1, 4}}}, []byteRange{{0, 4}, {6, 8}}},
+		{"clamped", "abc", []github.Match{{Indices: []int{1, 50}}}, []byteRange{{1, 3}}},
+		{"missing indices", "abc", []github.Match{{Indices: []int{1}}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchRanges(tt.fragment, tt.matches)
+			if len(tt.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHighlightLines(t *testing.T) {
+	code := "func main() {\n\tfmt.Println(\"<b>\")\n}"
+	lines := highlightLines(code, "main.go", []byteRange{{5, 9}})
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, template.HTML(`<span class="kd">func</span> <mark><span class="nf">main</span></mark><span class="p">()</span> <span class="p">{</span>`), lines[0])
+	assert.Contains(t, string(lines[1]), `<span class="s">&#34;&lt;b&gt;&#34;</span>`, "code is escaped")
+
+	// Marks spanning lines are closed and reopened on each line
+	lines = highlightLines("one\ntwo", "notes.unknownext", []byteRange{{1, 6}})
+	assert.Equal(t, []template.HTML{"o<mark>ne</mark>", "<mark>tw</mark>o"}, lines)
+
+	// CRLF line endings keep match offsets aligned
+	lines = highlightLines("a: 1\r\nb: 2", "config.yaml", []byteRange{{6, 7}})
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[1]), "<mark>")
+	assert.Contains(t, string(lines[1]), ">b</span></mark>")
+
+	assert.Equal(t, []template.HTML{""}, highlightLines("", "empty.go", nil))
+}
+
+func TestHTMLFormatter(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[1].Repository.StargazersCount = nil
+	results.Items[1].Content = &github.FileContent{TotalLines: 20, Snippets: []github.ContentSnippet{{
+		StartLine: 2, EndLine: 3,
+		Lines: []github.ContentLine{{Number: 2, Text: `  "name": "next",`, Match: true}, {Number: 3, Text: `  "version": "13.0.0"`}},
+	}}}
+
+	formatter, err := NewFormatter(FormatHTML, DefaultOptions())
+	require.NoError(t, err)
+	out, err := formatter.Format(results, "useState <script>")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+	assert.Contains(t, out, "<title>Search results: useState &lt;script&gt;</title>")
+	assert.Contains(t, out, "<p>Showing results 1-2 of 2</p>")
+	assert.Contains(t, out, ".chroma .kd {", "highlighting styles are inline")
+	assert.NotContains(t, out, `<link`, "no external resources")
+	assert.NotContains(t, out, `src=`, "no external resources")
+
+	// Repository cards with stars and language, omitting unknown counts
+	assert.Contains(t, out, `<h3><a href="https://github.com/facebook/react">facebook/react</a></h3>`)
+	assert.Contains(t, out, `<span>⭐ 50.0k</span><span>🍴 10.0k</span><span>TypeScript</span>`)
+	assert.Contains(t, out, `<p class="meta"><span>🍴 5.0k</span><span>JavaScript</span>`)
+
+	// Fragments are highlighted with the matches marked
+	assert.Contains(t, out, `<span class="kd">function</span> <mark><span class="nx">useState</span></mark>`)
+
+	// Fetched content replaces fragments, with numbered lines and matching lines flagged
+	assert.Contains(t, out, `<span class="hit"><span class="ln">2</span>  <span class="s2">&#34;name&#34;</span>: <span class="s2">&#34;next&#34;</span>,</span>`)
+	assert.Contains(t, out, `<span class=""><span class="ln">3</span>`)
+
+	// The filter box searches lowercase repository, path, language and code
+	assert.Contains(t, out, `<input id="filter" type="search"`)
+	assert.Contains(t, out, "data-filter=\"facebook/react\npackages/react/src/reacthooks.ts\ntypescript\nfunction usestate")
+
+	// A single search has no tabs
+	assert.NotContains(t, out, `<nav class="tabs"`)
+}
+
+func TestHTMLReport_Sections(t *testing.T) {
+	report := HTMLReport{
+		Title: "Batch",
+		Sections: []HTMLSection{
+			{Name: "configs", Query: "tsconfig", Tags: []string{"ts"}, Results: createTestSearchResults()},
+			{Name: "empty", Query: "nothing", Results: &github.SearchResults{}},
+			{Name: "broken", Query: "bad", Error: "validation failed"},
+			{Name: "Comparison", Extra: template.HTML("<h3>Overview</h3>")},
+		},
+	}
+
+	content, err := report.Render()
+	require.NoError(t, err)
+	out := string(content)
+
+	assert.Contains(t, out, `<button role="tab" data-tab="section-1" aria-selected="false">configs<span class="count">2</span></button>`)
+	assert.Contains(t, out, `<button role="tab" data-tab="section-4" aria-selected="false">Comparison</button>`)
+	assert.Contains(t, out, "<h2>configs (2 results)</h2>\n<p>Query: <code>tsconfig</code></p>\n<p>Tags: ts</p>")
+	assert.Contains(t, out, "<h2>empty (0 results)</h2>\n<p>Query: <code>nothing</code></p>\n<p>No results found.</p>")
+	assert.Contains(t, out, `<p class="failed">validation failed</p>`)
+	assert.Contains(t, out, "<h2>Comparison</h2><h3>Overview</h3>")
+}
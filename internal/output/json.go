@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// PaginationInfo contains metadata about pagination
+type PaginationInfo struct {
+	TotalResults     int  `json:"total_results"`
+	DisplayedResults int  `json:"displayed_results"`
+	StartResult      int  `json:"start_result"`
+	EndResult        int  `json:"end_result"`
+	CurrentPage      int  `json:"current_page"`
+	PerPage          int  `json:"per_page"`
+	HasNextPage      bool `json:"has_next_page"`
+	NextPage         int  `json:"next_page,omitempty"`
+}
+
+// JSONFormatter renders results as indented JSON with pagination metadata
+type JSONFormatter struct {
+	Options Options
+}
+
+// Format formats search results as JSON
+func (f *JSONFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	enhancedResults := struct {
+		*github.SearchResults
+		Pagination *PaginationInfo `json:"pagination,omitempty"`
+	}{
+		SearchResults: results,
+		Pagination:    f.pagination(results),
+	}
+
+	data, err := json.MarshalIndent(enhancedResults, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// pagination describes where results sit in the full result set
+func (f *JSONFormatter) pagination(results *github.SearchResults) *PaginationInfo {
+	if results.Total == nil {
+		return nil
+	}
+	return paginationInfo(*results.Total, len(results.Items), f.Options)
+}
+
+// paginationInfo describes where displayed results sit among total
+func paginationInfo(total, displayed int, opts Options) *PaginationInfo {
+	startResult, endResult := displayedRange(displayed, opts)
+	info := &PaginationInfo{
+		TotalResults:     total,
+		DisplayedResults: displayed,
+		StartResult:      startResult,
+		EndResult:        endResult,
+		CurrentPage:      max(1, opts.Page),
+		PerPage:          opts.Limit,
+		HasNextPage:      total > endResult,
+	}
+	if info.HasNextPage {
+		info.NextPage = info.CurrentPage + 1
+	}
+	return info
+}
@@ -120,12 +120,21 @@ func (f *MarkdownFormatter) formatSearchItem(buf *strings.Builder, item *github.
 	// Code content with syntax highlighting
 	f.formatCodeContent(buf, item)
 
+	// Copies collapsed by --dedupe
+	f.formatDuplicates(buf, item)
+
 	// File link and metadata
 	f.formatFileFooter(buf, item)
 }
 
 // formatRepositoryHeader formats the repository header section
 func (f *MarkdownFormatter) formatRepositoryHeader(buf *strings.Builder, item *github.SearchItem, index int) {
+	if !f.ShowRepository {
+		path := getStringValue(item.Path)
+		buf.WriteString(fmt.Sprintf("## %d. %s %s\n\n", index, f.getFileIcon(path), sanitizeMarkdown(path)))
+		return
+	}
+
 	repoName := sanitizeMarkdown(getStringValue(item.Repository.FullName))
 	repoURL := getStringValue(item.Repository.HTMLURL)
 
@@ -167,8 +176,10 @@ func (f *MarkdownFormatter) formatFileInfo(buf *strings.Builder, item *github.Se
 	// File icon based on extension
 	icon := f.getFileIcon(filePath)
 
-	// File path with icon
-	buf.WriteString(fmt.Sprintf("**%s %s**\n\n", icon, filePath))
+	// File path with icon (already the heading when repositories are hidden)
+	if f.ShowRepository {
+		buf.WriteString(fmt.Sprintf("**%s %s**\n\n", icon, filePath))
+	}
 
 	// File metadata
 	if f.ShowLineNumbers || f.ShowPatterns {
@@ -181,6 +192,14 @@ func (f *MarkdownFormatter) formatFileInfo(buf *strings.Builder, item *github.Se
 
 // formatCodeContent formats the code content with syntax highlighting
 func (f *MarkdownFormatter) formatCodeContent(buf *strings.Builder, item *github.SearchItem) {
+	// Snippets fetched with --fetch-content carry real line numbers
+	if hasFetchedContent(item) {
+		buf.WriteString(fmt.Sprintf("**Code:** (%s, %d lines)\n\n", item.Content.Ref, item.Content.TotalLines))
+		writeContentSnippets(buf, item.Content, f.detectLanguage(getStringValue(item.Path)), f.MaxContentLines)
+		buf.WriteString("\n")
+		return
+	}
+
 	if len(item.TextMatches) == 0 {
 		buf.WriteString("*No code preview available*\n\n")
 		return
@@ -214,10 +233,7 @@ func (f *MarkdownFormatter) formatTextMatch(buf *strings.Builder, match *github.
 
 	// Format the fragment with line numbers if requested
 	if f.ShowLineNumbers {
-		lines := strings.Split(fragment, "\n")
-		for i, line := range lines {
-			buf.WriteString(fmt.Sprintf("%3d: %s\n", i+1, line))
-		}
+		buf.WriteString(formatFragment(fragment, true, f.MaxContentLines))
 	} else {
 		// Apply content length limit
 		if f.MaxContentLines > 0 {
@@ -272,6 +288,24 @@ func (f *MarkdownFormatter) formatFileFooter(buf *strings.Builder, item *github.
 	buf.WriteString("\n")
 }
 
+// formatDuplicates lists the copies of a file collapsed into it by --dedupe
+func (f *MarkdownFormatter) formatDuplicates(buf *strings.Builder, item *github.SearchItem) {
+	summary := duplicateSummary(item)
+	if summary == "" {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("🧬 **%s:**\n", summary))
+	for _, duplicate := range item.Duplicates {
+		location := sanitizeMarkdown(duplicate.Repository + "/" + duplicate.Path)
+		if duplicate.HTMLURL != "" {
+			location = fmt.Sprintf("[%s](%s)", location, duplicate.HTMLURL)
+		}
+		buf.WriteString(fmt.Sprintf("- %s ⭐ %d\n", location, duplicate.Stars))
+	}
+	buf.WriteString("\n")
+}
+
 // writeFooter writes the markdown footer with summary information
 func (f *MarkdownFormatter) writeFooter(buf *strings.Builder, results *github.SearchResults) {
 	if len(results.Items) == 0 {
@@ -452,6 +486,11 @@ func (f *MarkdownFormatter) getFileIcon(filePath string) string {
 
 // detectLanguage detects programming language from file path
 func (f *MarkdownFormatter) detectLanguage(path string) string {
+	return languageForPath(path)
+}
+
+// languageForPath returns the code fence language for a file path
+func languageForPath(path string) string {
 	ext := strings.ToLower(filepath.Ext(path))
 	filename := strings.ToLower(filepath.Base(path))
 
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// NDJSONSummary is the record that ends NDJSON output
+type NDJSONSummary struct {
+	Type              string          `json:"type"` // always "summary"
+	TotalCount        *int            `json:"total_count,omitempty"`
+	IncompleteResults *bool           `json:"incomplete_results,omitempty"`
+	Pagination        *PaginationInfo `json:"pagination,omitempty"`
+}
+
+// NDJSONWriter streams results as newline-delimited JSON: one object per
+// search item as pages arrive, then a summary record
+type NDJSONWriter struct {
+	w       io.Writer
+	options Options
+	written int
+}
+
+// NewNDJSONWriter creates a writer that streams to w
+func NewNDJSONWriter(w io.Writer, opts Options) *NDJSONWriter {
+	return &NDJSONWriter{w: w, options: opts}
+}
+
+// WriteItems writes one line per item
+func (n *NDJSONWriter) WriteItems(items []github.SearchItem) error {
+	encoder := json.NewEncoder(n.w)
+	for i := range items {
+		if err := encoder.Encode(&items[i]); err != nil {
+			return fmt.Errorf("failed to write NDJSON: %w", err)
+		}
+		n.written++
+	}
+	return nil
+}
+
+// WriteSummary writes the summary record for a search whose first page
+// reported total and incomplete
+func (n *NDJSONWriter) WriteSummary(total *int, incomplete *bool) error {
+	summary := NDJSONSummary{
+		Type:              "summary",
+		TotalCount:        total,
+		IncompleteResults: incomplete,
+	}
+	if total != nil {
+		summary.Pagination = paginationInfo(*total, n.written, n.options)
+	}
+
+	if err := json.NewEncoder(n.w).Encode(summary); err != nil {
+		return fmt.Errorf("failed to write NDJSON: %w", err)
+	}
+	return nil
+}
+
+// NDJSONFormatter renders already fetched results as NDJSON, for output
+// that cannot be streamed
+type NDJSONFormatter struct {
+	Options Options
+}
+
+// Format formats search results as NDJSON
+func (f *NDJSONFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	var buf strings.Builder
+	writer := NewNDJSONWriter(&buf, f.Options)
+	if err := writer.WriteItems(results.Items); err != nil {
+		return "", err
+	}
+	if err := writer.WriteSummary(results.Total, results.IncompleteResults); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	results := createTestSearchResults()
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf, Options{Limit: 50})
+
+	// Each page is written as it arrives
+	require.NoError(t, writer.WriteItems(results.Items[:1]))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	require.NoError(t, writer.WriteItems(results.Items[1:]))
+	require.NoError(t, writer.WriteSummary(github.IntPtr(240), github.BoolPtr(false)))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+
+	var item github.SearchItem
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &item))
+	assert.Equal(t, "packages/react/src/ReactHooks.ts", *item.Path)
+	assert.Equal(t, "facebook/react", *item.Repository.FullName)
+
+	var summary NDJSONSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &summary))
+	assert.Equal(t, "summary", summary.Type)
+	assert.Equal(t, 240, *summary.TotalCount)
+	assert.False(t, *summary.IncompleteResults)
+	require.NotNil(t, summary.Pagination)
+	assert.Equal(t, 2, summary.Pagination.DisplayedResults)
+	assert.True(t, summary.Pagination.HasNextPage)
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	formatter, err := NewFormatter(FormatNDJSON, DefaultOptions())
+	require.NoError(t, err)
+
+	out, err := formatter.Format(createTestSearchResults(), "useState")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), line)
+	}
+	assert.Contains(t, lines[2], `"type":"summary","total_count":2,"incomplete_results":false`)
+
+	// Empty results still end with a summary
+	out, err = formatter.Format(&github.SearchResults{Total: github.IntPtr(0)}, "none")
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"summary","total_count":0,"pagination":{"total_results":0,"displayed_results":0,"start_result":1,"end_result":0,"current_page":1,"per_page":50,"has_next_page":false}}`+"\n", out)
+}
@@ -0,0 +1,416 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// SARIF log constants
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "gh-scout"
+	sarifToolURI  = "https://github.com/silouanwright/gh-scout"
+)
+
+// commitSHA matches a full commit hash, the only ref SARIF accepts as a revisionId
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// SARIFReport is a SARIF 2.1.0 log of search results with one rule per
+// search, so code scanning tools can track matches like findings
+type SARIFReport struct {
+	Rules []SARIFRule
+}
+
+// SARIFRule is one search reported as a SARIF rule. Each of its results
+// becomes a SARIF result; a failed search is reported as an error
+// notification instead.
+type SARIFRule struct {
+	ID      string
+	Name    string
+	Query   string
+	Tags    []string
+	Error   string
+	Results *github.SearchResults
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool                     sarifTool                        `json:"tool"`
+	Invocations              []sarifInvocation                `json:"invocations"`
+	OriginalURIBaseIDs       map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	VersionControlProvenance []sarifVersionControl            `json:"versionControlProvenance,omitempty"`
+	ColumnKind               string                           `json:"columnKind"`
+	Results                  []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           *sarifProperties   `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level          string              `json:"level"`
+	Message        sarifMessage        `json:"message"`
+	AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+}
+
+type sarifRuleReference struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+type sarifVersionControl struct {
+	RepositoryURI string                `json:"repositoryUri"`
+	RevisionID    string                `json:"revisionId,omitempty"`
+	MappedTo      sarifArtifactLocation `json:"mappedTo"`
+}
+
+type sarifResult struct {
+	RuleID           string                 `json:"ruleId"`
+	RuleIndex        int                    `json:"ruleIndex"`
+	Message          sarifMessage           `json:"message"`
+	Locations        []sarifLocation        `json:"locations"`
+	RelatedLocations []sarifLocation        `json:"relatedLocations,omitempty"`
+	HostedViewerURI  string                 `json:"hostedViewerUri,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+	ContextRegion    *sarifRegion          `json:"contextRegion,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndLine     int           `json:"endLine,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	Snippet     *sarifContent `json:"snippet,omitempty"`
+}
+
+type sarifContent struct {
+	Text string `json:"text"`
+}
+
+// sarifBases assigns each repository and ref a uriBaseId so result paths
+// stay relative to the repository they were found in
+type sarifBases struct {
+	run *sarifRun
+	ids map[string]string
+}
+
+// Render renders the report as an indented SARIF log with a single run
+func (r SARIFReport) Render() ([]byte, error) {
+	run := sarifRun{
+		Tool:       sarifTool{Driver: sarifDriver{Name: sarifToolName, InformationURI: sarifToolURI}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    []sarifResult{},
+	}
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+	bases := &sarifBases{run: &run, ids: make(map[string]string)}
+
+	for index, rule := range r.Rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleFor(rule))
+		reference := &sarifRuleReference{ID: rule.ID, Index: index}
+
+		if rule.Error != "" {
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:          "error",
+				Message:        sarifMessage{Text: fmt.Sprintf("Search %q failed: %s", rule.Name, rule.Error)},
+				AssociatedRule: reference,
+			})
+			continue
+		}
+		if rule.Results == nil {
+			continue
+		}
+		if rule.Results.IncompleteResults != nil && *rule.Results.IncompleteResults {
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:          "warning",
+				Message:        sarifMessage{Text: fmt.Sprintf("GitHub timed out and returned incomplete results for %q", rule.Name)},
+				AssociatedRule: reference,
+			})
+		}
+
+		for i := range rule.Results.Items {
+			run.Results = append(run.Results, sarifResultFor(&rule.Results.Items[i], rule, index, bases))
+		}
+	}
+	run.Invocations = []sarifInvocation{invocation}
+
+	data, err := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// SARIFFormatter renders results as a SARIF log with the query as its rule
+type SARIFFormatter struct {
+	Options Options
+}
+
+// Format formats search results as SARIF
+func (f *SARIFFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	report := SARIFReport{Rules: []SARIFRule{{ID: "code-search", Name: query, Query: query, Results: results}}}
+	content, err := report.Render()
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// sarifRuleFor describes a search as a rule, warning by default
+func sarifRuleFor(rule SARIFRule) sarifRule {
+	described := sarifRule{
+		ID:                   rule.ID,
+		Name:                 rule.Name,
+		ShortDescription:     sarifMessage{Text: rule.Name},
+		DefaultConfiguration: sarifConfiguration{Level: "warning"},
+	}
+	if described.ShortDescription.Text == "" {
+		described.ShortDescription.Text = rule.ID
+	}
+	if rule.Query != "" {
+		described.FullDescription = &sarifMessage{Text: "Code search: " + rule.Query}
+	}
+
+	// SARIF requires tags to be unique
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range rule.Tags {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		described.Properties = &sarifProperties{Tags: tags}
+	}
+	return described
+}
+
+// sarifResultFor reports an item at its first matched line, with any
+// further matched lines as related locations. Lines are known only for
+// content fetched with --fetch-content; otherwise the result points at the
+// file.
+func sarifResultFor(item *github.SearchItem, rule SARIFRule, ruleIndex int, bases *sarifBases) sarifResult {
+	repository := getStringValue(item.Repository.FullName)
+	path := getStringValue(item.Path)
+	terms := matchedTexts(item)
+
+	file := strings.TrimPrefix(repository+"/"+path, "/")
+	message := fmt.Sprintf("Matched search %q in %s", rule.Name, file)
+	if len(terms) > 0 {
+		quoted := make([]string, len(terms))
+		for i, term := range terms {
+			quoted[i] = "`" + term + "`"
+		}
+		message = fmt.Sprintf("Matched %s in %s", strings.Join(quoted, ", "), file)
+	}
+
+	result := sarifResult{
+		RuleID:          rule.ID,
+		RuleIndex:       ruleIndex,
+		Message:         sarifMessage{Text: message},
+		HostedViewerURI: getStringValue(item.HTMLURL),
+	}
+	if repository != "" {
+		result.Properties = map[string]interface{}{"repository": repository}
+		if item.Repository.StargazersCount != nil {
+			result.Properties["stars"] = *item.Repository.StargazersCount
+		}
+	}
+
+	artifact := bases.artifactLocation(item)
+	if !hasFetchedContent(item) {
+		result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}}
+		return result
+	}
+
+	for _, snippet := range item.Content.Snippets {
+		contextRegion := sarifSnippetRegion(snippet)
+		for _, line := range snippet.Lines {
+			if !line.Match {
+				continue
+			}
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: artifact,
+				Region:           sarifLineRegion(line, terms),
+				ContextRegion:    contextRegion,
+			}}
+			if len(result.Locations) == 0 {
+				result.Locations = append(result.Locations, location)
+				continue
+			}
+			location.ID = len(result.RelatedLocations) + 1
+			location.Message = &sarifMessage{Text: fmt.Sprintf("Also matched on line %d", line.Number)}
+			result.RelatedLocations = append(result.RelatedLocations, location)
+		}
+	}
+	if len(result.Locations) == 0 {
+		result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}}
+	}
+	return result
+}
+
+// artifactLocation returns the item's path relative to its repository's
+// base, or its absolute URL when the repository is unknown
+func (b *sarifBases) artifactLocation(item *github.SearchItem) sarifArtifactLocation {
+	uri := (&url.URL{Path: getStringValue(item.Path)}).String()
+
+	repositoryURL := getStringValue(item.Repository.HTMLURL)
+	if repositoryURL == "" {
+		repositoryURL, _, _ = strings.Cut(getStringValue(item.HTMLURL), "/blob/")
+	}
+	name := getStringValue(item.Repository.FullName)
+	if repositoryURL == "" || name == "" {
+		return sarifArtifactLocation{URI: getStringValue(item.HTMLURL)}
+	}
+
+	ref := github.ItemRef(item)
+	key := repositoryURL + "\x00" + ref
+	id, ok := b.ids[key]
+	if !ok {
+		id = name
+		if b.used(id) {
+			id = name + "@" + ref
+		}
+		b.ids[key] = id
+
+		blobRef := ref
+		if blobRef == "" {
+			blobRef = "HEAD"
+		}
+		if b.run.OriginalURIBaseIDs == nil {
+			b.run.OriginalURIBaseIDs = make(map[string]sarifArtifactLocation)
+		}
+		b.run.OriginalURIBaseIDs[id] = sarifArtifactLocation{URI: strings.TrimSuffix(repositoryURL, "/") + "/blob/" + url.PathEscape(blobRef) + "/"}
+
+		provenance := sarifVersionControl{RepositoryURI: repositoryURL, MappedTo: sarifArtifactLocation{URIBaseID: id}}
+		if commitSHA.MatchString(ref) {
+			provenance.RevisionID = ref
+		}
+		b.run.VersionControlProvenance = append(b.run.VersionControlProvenance, provenance)
+	}
+	return sarifArtifactLocation{URI: uri, URIBaseID: id}
+}
+
+// used reports whether id already names a base
+func (b *sarifBases) used(id string) bool {
+	for _, existing := range b.ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sarifLineRegion covers a matched line, narrowed to the first term found
+// on it. Columns count characters from 1, with the end column exclusive.
+func sarifLineRegion(line github.ContentLine, terms []string) *sarifRegion {
+	region := &sarifRegion{StartLine: line.Number, Snippet: &sarifContent{Text: line.Text}}
+	for _, term := range terms {
+		if i := strings.Index(line.Text, term); i >= 0 {
+			region.StartColumn = utf8.RuneCountInString(line.Text[:i]) + 1
+			region.EndColumn = region.StartColumn + utf8.RuneCountInString(term)
+			break
+		}
+	}
+	return region
+}
+
+// sarifSnippetRegion covers a fetched snippet's lines
+func sarifSnippetRegion(snippet github.ContentSnippet) *sarifRegion {
+	text := make([]string, len(snippet.Lines))
+	for i, line := range snippet.Lines {
+		text[i] = line.Text
+	}
+	return &sarifRegion{
+		StartLine: snippet.StartLine,
+		EndLine:   snippet.EndLine,
+		Snippet:   &sarifContent{Text: strings.Join(text, "\n")},
+	}
+}
+
+// matchedTexts returns the distinct texts GitHub matched, cut from each
+// fragment at the match indices, or the reported match text when the
+// indices are missing
+func matchedTexts(item *github.SearchItem) []string {
+	var texts []string
+	seen := make(map[string]bool)
+	add := func(text string) {
+		if text != "" && !seen[text] {
+			seen[text] = true
+			texts = append(texts, text)
+		}
+	}
+
+	for _, match := range item.TextMatches {
+		fragment := getStringValue(match.Fragment)
+		for _, m := range match.Matches {
+			if ranges := matchRanges(fragment, []github.Match{m}); len(ranges) > 0 {
+				add(fragment[ranges[0].start:ranges[0].end])
+				continue
+			}
+			add(getStringValue(m.Text))
+		}
+	}
+	return texts
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// decodeSARIF parses rendered SARIF and returns its single run
+func decodeSARIF(t *testing.T, content string) (sarifLog, sarifRun) {
+	t.Helper()
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(content), &log))
+	require.Len(t, log.Runs, 1)
+	return log, log.Runs[0]
+}
+
+func TestSARIFFormatter(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[1].TextMatches[0].Matches = []github.Match{{Text: github.StringPtr("next"), Indices: []int{13, 17}}}
+	results.Items[1].Content = &github.FileContent{TotalLines: 20, Snippets: []github.ContentSnippet{{
+		StartLine: 1, EndLine: 3,
+		Lines: []github.ContentLine{
+			{Number: 1, Text: "{"},
+			{Number: 2, Text: `  "name": "next",`, Match: true},
+			{Number: 3, Text: `  "next": "13.0.0"`, Match: true},
+		},
+	}}}
+
+	formatter, err := NewFormatter(FormatSARIF, DefaultOptions())
+	require.NoError(t, err)
+	out, err := formatter.Format(results, "useState")
+	require.NoError(t, err)
+
+	log, run := decodeSARIF(t, out)
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, "https://json.schemastore.org/sarif-2.1.0.json", log.Schema)
+	assert.Equal(t, "gh-scout", run.Tool.Driver.Name)
+	assert.Equal(t, "unicodeCodePoints", run.ColumnKind)
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, "code-search", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "Code search: useState", run.Tool.Driver.Rules[0].FullDescription.Text)
+	assert.Equal(t, []sarifInvocation{{ExecutionSuccessful: true}}, run.Invocations)
+	require.Len(t, run.Results, 2)
+
+	// Without fetched content the result points at the file
+	hooks := run.Results[0]
+	assert.Equal(t, "code-search", hooks.RuleID)
+	assert.Equal(t, 0, hooks.RuleIndex)
+	assert.Equal(t, "Matched `useState` in facebook/react/packages/react/src/ReactHooks.ts", hooks.Message.Text)
+	assert.Equal(t, "https://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts", hooks.HostedViewerURI)
+	assert.Equal(t, []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: "packages/react/src/ReactHooks.ts", URIBaseID: "facebook/react"},
+	}}}, hooks.Locations)
+	assert.Equal(t, map[string]interface{}{"repository": "facebook/react", "stars": float64(50000)}, hooks.Properties)
+
+	// Fetched lines give the region, narrowed to the matched text
+	next := run.Results[1]
+	require.Len(t, next.Locations, 1)
+	location := next.Locations[0].PhysicalLocation
+	assert.Equal(t, &sarifRegion{StartLine: 2, StartColumn: 12, EndColumn: 16, Snippet: &sarifContent{Text: `  "name": "next",`}}, location.Region)
+	assert.Equal(t, &sarifRegion{StartLine: 1, EndLine: 3, Snippet: &sarifContent{Text: "{\n  \"name\": \"next\",\n  \"next\": \"13.0.0\""}}, location.ContextRegion)
+	require.Len(t, next.RelatedLocations, 1)
+	assert.Equal(t, 1, next.RelatedLocations[0].ID)
+	assert.Equal(t, "Also matched on line 3", next.RelatedLocations[0].Message.Text)
+	assert.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 4, EndColumn: 8, Snippet: &sarifContent{Text: `  "next": "13.0.0"`}}, next.RelatedLocations[0].PhysicalLocation.Region)
+
+	// Paths resolve against each repository's blob URL
+	assert.Equal(t, map[string]sarifArtifactLocation{
+		"facebook/react": {URI: "https://github.com/facebook/react/blob/main/"},
+		"vercel/next.js": {URI: "https://github.com/vercel/next.js/blob/main/"},
+	}, run.OriginalURIBaseIDs)
+	assert.Equal(t, []sarifVersionControl{
+		{RepositoryURI: "https://github.com/facebook/react", MappedTo: sarifArtifactLocation{URIBaseID: "facebook/react"}},
+		{RepositoryURI: "https://github.com/vercel/next.js", MappedTo: sarifArtifactLocation{URIBaseID: "vercel/next.js"}},
+	}, run.VersionControlProvenance)
+}
+
+func TestSARIFReport_Rules(t *testing.T) {
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	incomplete := &github.SearchResults{
+		IncompleteResults: github.BoolPtr(true),
+		Items: []github.SearchItem{{
+			Path:    github.StringPtr("docs/API endpoints.md"),
+			HTMLURL: github.StringPtr("https://github.example.com/acme/api/blob/" + commit + "/docs/API%20endpoints.md"),
+			Repository: github.Repository{
+				FullName: github.StringPtr("acme/api"),
+			},
+			TextMatches: []github.TextMatch{{
+				Fragment: github.StringPtr("Call https://api.internal.example.com/v1 — or the ümlaut host"),
+				Matches:  []github.Match{{Indices: []int{5, 40}}, {Text: github.StringPtr("ümlaut"), Indices: []int{50, 56}}},
+			}},
+		}},
+	}
+
+	report := SARIFReport{Rules: []SARIFRule{
+		{ID: "endpoints", Name: "Hardcoded endpoints", Query: "api.internal", Tags: []string{"security", "urls", "security"}, Results: incomplete},
+		{ID: "empty", Name: "Nothing", Query: "nothing", Results: &github.SearchResults{}},
+		{ID: "broken", Name: "Broken", Query: "bad", Error: "validation failed"},
+	}}
+	content, err := report.Render()
+	require.NoError(t, err)
+	_, run := decodeSARIF(t, string(content))
+
+	rules := run.Tool.Driver.Rules
+	require.Len(t, rules, 3)
+	assert.Equal(t, "endpoints", rules[0].ID)
+	assert.Equal(t, "Hardcoded endpoints", rules[0].ShortDescription.Text)
+	assert.Equal(t, "warning", rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, &sarifProperties{Tags: []string{"security", "urls"}}, rules[0].Properties, "tags are unique")
+	assert.Nil(t, rules[1].Properties)
+
+	// Failed and incomplete searches are reported as notifications
+	require.Len(t, run.Invocations, 1)
+	assert.False(t, run.Invocations[0].ExecutionSuccessful)
+	assert.Equal(t, []sarifNotification{
+		{Level: "warning", Message: sarifMessage{Text: `GitHub timed out and returned incomplete results for "Hardcoded endpoints"`}, AssociatedRule: &sarifRuleReference{ID: "endpoints", Index: 0}},
+		{Level: "error", Message: sarifMessage{Text: `Search "Broken" failed: validation failed`}, AssociatedRule: &sarifRuleReference{ID: "broken", Index: 2}},
+	}, run.Invocations[0].ToolExecutionNotifications)
+
+	// Matched text is cut from the fragment by character indices
+	require.Len(t, run.Results, 1)
+	result := run.Results[0]
+	assert.Equal(t, "Matched `https://api.internal.example.com/v1`, `ümlaut` in acme/api/docs/API endpoints.md", result.Message.Text)
+	assert.Equal(t, sarifArtifactLocation{URI: "docs/API%20endpoints.md", URIBaseID: "acme/api"}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, map[string]interface{}{"repository": "acme/api"}, result.Properties)
+
+	// The repository URL comes from the result URL, and commits become the revision
+	assert.Equal(t, map[string]sarifArtifactLocation{
+		"acme/api": {URI: "https://github.example.com/acme/api/blob/" + commit + "/"},
+	}, run.OriginalURIBaseIDs)
+	assert.Equal(t, []sarifVersionControl{
+		{RepositoryURI: "https://github.example.com/acme/api", RevisionID: commit, MappedTo: sarifArtifactLocation{URIBaseID: "acme/api"}},
+	}, run.VersionControlProvenance)
+}
+
+func TestSARIFReport_Empty(t *testing.T) {
+	content, err := SARIFReport{}.Render()
+	require.NoError(t, err)
+
+	// A run with no findings still lists an empty results array
+	assert.Contains(t, string(content), `"results": []`)
+	_, run := decodeSARIF(t, string(content))
+	assert.Empty(t, run.Results)
+	assert.Empty(t, run.Tool.Driver.Rules)
+	assert.True(t, run.Invocations[0].ExecutionSuccessful)
+}
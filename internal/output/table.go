@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// Columns accepted by --columns for the csv and tsv formats
+const (
+	ColumnRepo     = "repo"
+	ColumnPath     = "path"
+	ColumnURL      = "url"
+	ColumnStars    = "stars"
+	ColumnForks    = "forks"
+	ColumnLanguage = "language"
+	ColumnPushedAt = "pushed_at"
+	ColumnScore    = "score"
+	ColumnSHA      = "sha"
+	ColumnFragment = "fragment"
+)
+
+// DefaultColumns are the columns written when none are selected
+var DefaultColumns = []string{ColumnRepo, ColumnPath, ColumnURL, ColumnStars, ColumnLanguage}
+
+// columnValues extracts each column's cell from a search result. Missing
+// values, such as star counts skipped by --lite, are left empty rather
+// than written as zero.
+var columnValues = map[string]func(item *github.SearchItem) string{
+	ColumnRepo:     func(item *github.SearchItem) string { return getStringValue(item.Repository.FullName) },
+	ColumnPath:     func(item *github.SearchItem) string { return getStringValue(item.Path) },
+	ColumnURL:      func(item *github.SearchItem) string { return getStringValue(item.HTMLURL) },
+	ColumnStars:    func(item *github.SearchItem) string { return intCell(item.Repository.StargazersCount) },
+	ColumnForks:    func(item *github.SearchItem) string { return intCell(item.Repository.ForksCount) },
+	ColumnLanguage: func(item *github.SearchItem) string { return getStringValue(item.Repository.Language) },
+	ColumnPushedAt: func(item *github.SearchItem) string {
+		if item.Repository.PushedAt == nil {
+			return ""
+		}
+		return item.Repository.PushedAt.UTC().Format(time.RFC3339)
+	},
+	ColumnScore: func(item *github.SearchItem) string {
+		if item.Score == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*item.Score, 'f', -1, 64)
+	},
+	ColumnSHA:      func(item *github.SearchItem) string { return getStringValue(item.SHA) },
+	ColumnFragment: firstFragmentLine,
+}
+
+// SupportedColumns lists the column names accepted by ParseColumns
+func SupportedColumns() []string {
+	return []string{ColumnRepo, ColumnPath, ColumnURL, ColumnStars, ColumnForks, ColumnLanguage, ColumnPushedAt, ColumnScore, ColumnSHA, ColumnFragment}
+}
+
+// ParseColumns validates column names, which may also be comma-separated
+// within an entry. No names selects DefaultColumns.
+func ParseColumns(names []string) ([]string, error) {
+	var columns []string
+	for _, name := range names {
+		for _, column := range strings.Split(name, ",") {
+			column = strings.ToLower(strings.TrimSpace(column))
+			if column == "" {
+				continue
+			}
+			if _, ok := columnValues[column]; !ok {
+				return nil, fmt.Errorf("unknown column: %s (supported: %s)", column, strings.Join(SupportedColumns(), ", "))
+			}
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return slices.Clone(DefaultColumns), nil
+	}
+	return columns, nil
+}
+
+// ColumnValues returns the cells of item for columns
+func ColumnValues(item *github.SearchItem, columns []string) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		if value, ok := columnValues[column]; ok {
+			values[i] = value(item)
+		}
+	}
+	return values
+}
+
+// WriteTable encodes records separated by comma. Fields holding the
+// separator, quotes or line breaks are quoted as RFC 4180 describes.
+func WriteTable(records [][]string, comma rune) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = comma
+	if err := writer.WriteAll(records); err != nil {
+		return "", fmt.Errorf("failed to write table: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// TableFormatter renders one row per result with a header row, as CSV or
+// TSV depending on Comma
+type TableFormatter struct {
+	Options Options
+	Comma   rune
+}
+
+// Format formats search results as a table of the selected columns
+func (f *TableFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	columns := f.Options.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	records := make([][]string, 0, len(results.Items)+1)
+	records = append(records, columns)
+	for i := range results.Items {
+		records = append(records, ColumnValues(&results.Items[i], columns))
+	}
+	return WriteTable(records, f.Comma)
+}
+
+// firstFragmentLine returns the first non-blank line of the first text match
+func firstFragmentLine(item *github.SearchItem) string {
+	for _, match := range item.TextMatches {
+		for _, line := range strings.Split(getStringValue(match.Fragment), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// intCell formats an optional count, leaving unknown counts empty
+func intCell(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
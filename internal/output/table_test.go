@@ -0,0 +1,101 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr string
+	}{
+		{"defaults", nil, DefaultColumns, ""},
+		{"flag values", []string{"repo", "stars"}, []string{"repo", "stars"}, ""},
+		{"comma separated and cased", []string{" Repo,PUSHED_AT ", "", "fragment"}, []string{"repo", "pushed_at", "fragment"}, ""},
+		{"unknown", []string{"repo", "owner"}, nil, "unknown column: owner (supported: repo, path, url, stars, forks, language, pushed_at, score, sha, fragment)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := ParseColumns(tt.names)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, columns)
+		})
+	}
+}
+
+func TestColumnValues(t *testing.T) {
+	pushed := time.Date(2025, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	score := 12.5
+	item := createTestSearchResults().Items[0]
+	item.Repository.PushedAt = &pushed
+	item.Score = &score
+
+	assert.Equal(t, []string{
+		"facebook/react",
+		"packages/react/src/ReactHooks.ts",
+		"https://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts",
+		"50000",
+		"10000",
+		"TypeScript",
+		"2025-03-01T11:30:00Z",
+		"12.5",
+		"abc123",
+		"function useState<S>(initialState: S | (() => S)): [S, Dispatch<SetStateAction<S>>] {",
+	}, ColumnValues(&item, SupportedColumns()))
+
+	// Unknown values stay empty rather than reading as zero
+	lite := github.SearchItem{Path: github.StringPtr("a.go")}
+	assert.Equal(t, []string{"a.go", "", "", "", ""}, ColumnValues(&lite, []string{"path", "stars", "forks", "pushed_at", "fragment"}))
+}
+
+func TestTableFormatter(t *testing.T) {
+	results := createTestSearchResults()
+	results.Items[0].TextMatches[0].Fragment = github.StringPtr("\n  const x = \"a, b\";\nnext")
+
+	t.Run("csv quotes fields", func(t *testing.T) {
+		formatter, err := NewFormatter(FormatCSV, Options{Columns: []string{ColumnRepo, ColumnStars, ColumnFragment}})
+		require.NoError(t, err)
+		out, err := formatter.Format(results, "useState")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "facebook/react,50000,\"const x = \"\"a, b\"\";\"\n")
+		records, err := csv.NewReader(bytes.NewReader([]byte(out))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"repo", "stars", "fragment"}, records[0])
+		assert.Equal(t, []string{"facebook/react", "50000", `const x = "a, b";`}, records[1])
+	})
+
+	t.Run("tsv with default columns", func(t *testing.T) {
+		formatter, err := NewFormatter(FormatTSV, Options{})
+		require.NoError(t, err)
+		out, err := formatter.Format(results, "useState")
+		require.NoError(t, err)
+
+		lines := strings.Split(out, "\n")
+		require.Len(t, lines, 4)
+		assert.Equal(t, "repo\tpath\turl\tstars\tlanguage", lines[0])
+		assert.Equal(t, "facebook/react\tpackages/react/src/ReactHooks.ts\thttps://github.com/facebook/react/blob/main/packages/react/src/ReactHooks.ts\t50000\tTypeScript", lines[1])
+	})
+
+	t.Run("empty results keep the header", func(t *testing.T) {
+		out, err := (&TableFormatter{Comma: ','}).Format(&github.SearchResults{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "repo,path,url,stars,language\n", out)
+	})
+}
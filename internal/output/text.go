@@ -0,0 +1,135 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// DefaultFormatter renders results for reading in a terminal
+type DefaultFormatter struct {
+	Options Options
+}
+
+// Format formats search results with a pagination-aware summary
+func (f *DefaultFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	var buf strings.Builder
+
+	f.writeSummary(&buf, results)
+
+	for i := range results.Items {
+		if f.Options.Limit > 0 && i >= f.Options.Limit {
+			break
+		}
+		f.formatItem(&buf, &results.Items[i])
+	}
+
+	return buf.String(), nil
+}
+
+// writeSummary writes the result count and how to reach the next page
+func (f *DefaultFormatter) writeSummary(buf *strings.Builder, results *github.SearchResults) {
+	if results.Total == nil {
+		return
+	}
+
+	totalResults := *results.Total
+	displayedCount := len(results.Items)
+	startResult, endResult := resultRange(results, f.Options)
+
+	if totalResults > displayedCount {
+		buf.WriteString(fmt.Sprintf("🔍 Found %d total results (showing %d-%d)\n\n",
+			totalResults, startResult, endResult))
+	} else {
+		buf.WriteString(fmt.Sprintf("🔍 Found %d results\n\n", totalResults))
+	}
+
+	// Add pagination guidance if there are more results
+	if totalResults > endResult {
+		remainingResults := totalResults - endResult
+		nextPage := f.Options.Page + 1
+		if f.Options.Page == 0 {
+			nextPage = 2 // Auto pagination starts at page 1, next is page 2
+		}
+
+		buf.WriteString(fmt.Sprintf("💡 **%d more results available** - Use `--page %d` to see results %d-%d\n\n",
+			remainingResults, nextPage, endResult+1, min(totalResults, endResult+f.Options.Limit)))
+	}
+}
+
+// formatItem writes the repository, file and code for a single result
+func (f *DefaultFormatter) formatItem(buf *strings.Builder, item *github.SearchItem) {
+	path := getStringValue(item.Path)
+
+	if f.Options.ShowRepository {
+		buf.WriteString(fmt.Sprintf("📁 [%s](%s)", getStringValue(item.Repository.FullName), getStringValue(item.Repository.HTMLURL)))
+		if f.Options.ShowStars {
+			buf.WriteString(fmt.Sprintf(" ⭐ %d", getIntValue(item.Repository.StargazersCount)))
+		}
+		buf.WriteString("\n")
+	}
+
+	if path != "" {
+		buf.WriteString(fmt.Sprintf("📄 **%s**\n\n", path))
+	}
+
+	if summary := duplicateSummary(item); summary != "" {
+		buf.WriteString(fmt.Sprintf("🧬 %s\n\n", summary))
+	}
+
+	lang := languageForPath(path)
+	if hasFetchedContent(item) {
+		writeContentSnippets(buf, item.Content, lang, f.Options.MaxContentLines)
+	} else {
+		for _, match := range item.TextMatches {
+			if match.Fragment != nil {
+				buf.WriteString(fmt.Sprintf("```%s\n%s```\n", lang,
+					formatFragment(*match.Fragment, f.Options.ShowLineNumbers, f.Options.MaxContentLines)))
+			}
+		}
+	}
+
+	if item.HTMLURL != nil {
+		buf.WriteString(fmt.Sprintf("🔗 [View on GitHub](%s)\n\n", *item.HTMLURL))
+	}
+
+	buf.WriteString("---\n")
+}
+
+// CompactFormatter renders one repository:path line per result
+type CompactFormatter struct {
+	Options Options
+}
+
+// Format formats search results in compact format
+func (f *CompactFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	var buf strings.Builder
+
+	if results.Total != nil && *results.Total > len(results.Items) {
+		startResult, endResult := resultRange(results, f.Options)
+		buf.WriteString(fmt.Sprintf("# Results %d-%d of %d total\n", startResult, endResult, *results.Total))
+	}
+
+	for _, item := range results.Items {
+		buf.WriteString(fmt.Sprintf("%s:%s\n", getStringValue(item.Repository.FullName), getStringValue(item.Path)))
+	}
+
+	return buf.String(), nil
+}
+
+// PipeFormatter renders repository:path:url lines for other tools
+type PipeFormatter struct{}
+
+// Format formats search results for pipe output
+func (f *PipeFormatter) Format(results *github.SearchResults, query string) (string, error) {
+	var buf strings.Builder
+
+	for _, item := range results.Items {
+		if item.Repository.FullName != nil && item.Path != nil && item.HTMLURL != nil {
+			buf.WriteString(fmt.Sprintf("%s:%s:%s\n", *item.Repository.FullName, *item.Path, *item.HTMLURL))
+		}
+	}
+
+	return buf.String(), nil
+}
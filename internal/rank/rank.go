@@ -0,0 +1,218 @@
+// Package rank reorders search results using repository signals. GitHub's
+// code search API only sorts by recently indexed, so every other order is
+// applied client-side to the results that were fetched.
+package rank
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+// Supported sort modes
+const (
+	SortRelevance = "relevance" // GitHub's best match order, left as is
+	SortIndexed   = "indexed"   // recently indexed first, sorted by GitHub
+	SortStars     = "stars"     // most starred repository first
+	SortForks     = "forks"     // most forked repository first
+	SortRecent    = "recent"    // most recently pushed repository first
+	SortCreated   = "created"   // newest repository first
+	SortQuality   = "quality"   // weighted blend of repository signals, see Weights
+)
+
+// aliases maps older sort names to current modes
+var aliases = map[string]string{
+	"updated":    SortRecent,
+	"best-match": SortRelevance,
+}
+
+// recencyHalfLife is the push age at which the recency signal halves
+const recencyHalfLife = 365 * 24 * time.Hour
+
+// Weights blends repository signals into a quality score. Each signal is
+// scaled to 0-1 before weighting, then penalties scale the blended score
+// down for archived repositories and forks.
+type Weights struct {
+	Stars           float64
+	Forks           float64
+	Recency         float64
+	Relevance       float64 // GitHub's search score relative to the best result
+	ArchivedPenalty float64 // fraction of the score removed for archived repositories
+	ForkPenalty     float64 // fraction of the score removed for forks
+}
+
+// Modes lists the supported sort modes
+func Modes() []string {
+	return []string{SortRelevance, SortIndexed, SortStars, SortForks, SortRecent, SortCreated, SortQuality}
+}
+
+// Normalize resolves aliases and rejects unknown sort modes. An empty mode
+// means relevance.
+func Normalize(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return SortRelevance, nil
+	}
+	if alias, ok := aliases[mode]; ok {
+		return alias, nil
+	}
+	if !slices.Contains(Modes(), mode) {
+		return "", fmt.Errorf("invalid sort: %s (supported: %s)", mode, strings.Join(Modes(), ", "))
+	}
+	return mode, nil
+}
+
+// APISort returns the sort parameter to send to GitHub for a mode, which
+// is empty for everything GitHub cannot sort by itself
+func APISort(mode string) string {
+	if normalized, _ := Normalize(mode); normalized == SortIndexed {
+		return SortIndexed
+	}
+	return ""
+}
+
+// ClientSide reports whether a mode reorders results after they are
+// fetched, using repository fields that are only present when results are
+// enriched
+func ClientSide(mode string) bool {
+	normalized, err := Normalize(mode)
+	return err == nil && normalized != SortRelevance && normalized != SortIndexed
+}
+
+// Apply stably sorts items by mode, highest first, or lowest first when
+// order is "asc". Modes GitHub sorts by leave items untouched.
+func Apply(items []github.SearchItem, mode, order string, weights Weights, now time.Time) {
+	if !ClientSide(mode) || len(items) < 2 {
+		return
+	}
+	mode, _ = Normalize(mode)
+
+	maxScore := 0.0
+	for i := range items {
+		maxScore = max(maxScore, floatValue(items[i].Score))
+	}
+
+	type ranked struct {
+		item github.SearchItem
+		key  float64
+	}
+	rankedItems := make([]ranked, len(items))
+	for i, item := range items {
+		rankedItems[i] = ranked{item: item, key: sortKey(&item, mode, weights, maxScore, now)}
+	}
+
+	descending := order != "asc"
+	slices.SortStableFunc(rankedItems, func(a, b ranked) int {
+		switch {
+		case a.key == b.key:
+			return 0
+		case (a.key > b.key) == descending:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	for i := range rankedItems {
+		items[i] = rankedItems[i].item
+	}
+}
+
+// sortKey returns the value an item is ordered by under mode
+func sortKey(item *github.SearchItem, mode string, weights Weights, maxScore float64, now time.Time) float64 {
+	repository := &item.Repository
+	switch mode {
+	case SortStars:
+		return float64(intValue(repository.StargazersCount))
+	case SortForks:
+		return float64(intValue(repository.ForksCount))
+	case SortRecent:
+		return float64(lastActivity(repository).Unix())
+	case SortCreated:
+		if repository.CreatedAt == nil {
+			return 0
+		}
+		return float64(repository.CreatedAt.Unix())
+	default:
+		return Quality(item, weights, maxScore, now)
+	}
+}
+
+// Quality scores an item between 0 and 1. maxScore is the highest GitHub
+// search score among the results being ranked.
+func Quality(item *github.SearchItem, weights Weights, maxScore float64, now time.Time) float64 {
+	total := weights.Stars + weights.Forks + weights.Recency + weights.Relevance
+	if total <= 0 {
+		return 0
+	}
+
+	repository := &item.Repository
+	relevance := 0.0
+	if maxScore > 0 {
+		relevance = floatValue(item.Score) / maxScore
+	}
+
+	score := (weights.Stars*logScale(intValue(repository.StargazersCount), 5) +
+		weights.Forks*logScale(intValue(repository.ForksCount), 4) +
+		weights.Recency*recency(repository, now) +
+		weights.Relevance*relevance) / total
+
+	if repository.Archived != nil && *repository.Archived {
+		score *= 1 - weights.ArchivedPenalty
+	}
+	if repository.Fork != nil && *repository.Fork {
+		score *= 1 - weights.ForkPenalty
+	}
+	return score
+}
+
+// logScale maps a count to 0-1 on a log scale, reaching 1 at 10^decades
+func logScale(count, decades int) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return min(1, math.Log10(float64(count)+1)/float64(decades))
+}
+
+// recency is 1 for a repository pushed now, halving every recencyHalfLife
+func recency(repository *github.Repository, now time.Time) float64 {
+	pushed := lastActivity(repository)
+	if pushed.IsZero() {
+		return 0
+	}
+	age := max(now.Sub(pushed), 0)
+	return math.Pow(0.5, float64(age)/float64(recencyHalfLife))
+}
+
+// lastActivity returns when a repository was last pushed, falling back to
+// its last update
+func lastActivity(repository *github.Repository) time.Time {
+	switch {
+	case repository.PushedAt != nil:
+		return *repository.PushedAt
+	case repository.UpdatedAt != nil:
+		return *repository.UpdatedAt
+	default:
+		return time.Time{}
+	}
+}
+
+// intValue dereferences an optional count
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// floatValue dereferences an optional score
+func floatValue(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
@@ -0,0 +1,130 @@
+package rank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/silouanwright/gh-scout/internal/github"
+)
+
+var now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// testWeights matches the default ranking configuration
+var testWeights = Weights{Stars: 0.4, Forks: 0.1, Recency: 0.3, Relevance: 0.2, ArchivedPenalty: 0.5, ForkPenalty: 0.3}
+
+// rankedItem builds a result from repository signals
+func rankedItem(name string, stars, forks int, pushedDaysAgo int, score float64) github.SearchItem {
+	item := github.CreateTestSearchItem(name, "config.json", "{}")
+	item.Repository = github.CreateTestRepository(name, stars)
+	item.Repository.ForksCount = github.IntPtr(forks)
+	pushed := now.AddDate(0, 0, -pushedDaysAgo)
+	item.Repository.PushedAt = &pushed
+	created := now.AddDate(-pushedDaysAgo/10, 0, 0)
+	item.Repository.CreatedAt = &created
+	item.Score = github.Float64Ptr(score)
+	return item
+}
+
+// names lists the repositories of items in order
+func names(items []github.SearchItem) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = *item.Repository.FullName
+	}
+	return result
+}
+
+func testItems() []github.SearchItem {
+	return []github.SearchItem{
+		rankedItem("small/active", 40, 2, 1, 9.0),
+		rankedItem("big/stale", 90000, 9000, 2000, 1.0),
+		rankedItem("mid/recent", 5000, 800, 30, 5.0),
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"":          SortRelevance,
+		"relevance": SortRelevance,
+		"Stars":     SortStars,
+		"updated":   SortRecent,
+		"quality":   SortQuality,
+		"indexed":   SortIndexed,
+	}
+	for input, want := range tests {
+		got, err := Normalize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := Normalize("popularity")
+	assert.EqualError(t, err, "invalid sort: popularity (supported: relevance, indexed, stars, forks, recent, created, quality)")
+}
+
+func TestAPISort(t *testing.T) {
+	assert.Equal(t, "indexed", APISort("indexed"))
+	for _, mode := range []string{"relevance", "stars", "forks", "recent", "quality", "bogus"} {
+		assert.Empty(t, APISort(mode), mode)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		mode  string
+		order string
+		want  []string
+	}{
+		{SortRelevance, "desc", []string{"small/active", "big/stale", "mid/recent"}},
+		{SortIndexed, "desc", []string{"small/active", "big/stale", "mid/recent"}},
+		{SortStars, "desc", []string{"big/stale", "mid/recent", "small/active"}},
+		{SortStars, "asc", []string{"small/active", "mid/recent", "big/stale"}},
+		{SortForks, "desc", []string{"big/stale", "mid/recent", "small/active"}},
+		{SortRecent, "desc", []string{"small/active", "mid/recent", "big/stale"}},
+		{"updated", "desc", []string{"small/active", "mid/recent", "big/stale"}},
+		{SortCreated, "desc", []string{"small/active", "mid/recent", "big/stale"}},
+		{SortQuality, "desc", []string{"mid/recent", "small/active", "big/stale"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+" "+tt.order, func(t *testing.T) {
+			items := testItems()
+			Apply(items, tt.mode, tt.order, testWeights, now)
+			assert.Equal(t, tt.want, names(items))
+		})
+	}
+}
+
+func TestApply_StableForTies(t *testing.T) {
+	items := []github.SearchItem{
+		rankedItem("a/one", 10, 0, 1, 1),
+		rankedItem("b/two", 10, 0, 1, 1),
+		rankedItem("c/three", 10, 0, 1, 1),
+	}
+	Apply(items, SortStars, "desc", testWeights, now)
+	assert.Equal(t, []string{"a/one", "b/two", "c/three"}, names(items))
+
+	// Results without repository details keep their order too
+	bare := []github.SearchItem{{Path: github.StringPtr("a")}, {Path: github.StringPtr("b")}}
+	Apply(bare, SortQuality, "desc", testWeights, now)
+	assert.Equal(t, "a", *bare[0].Path)
+}
+
+func TestQuality(t *testing.T) {
+	item := rankedItem("acme/app", 99999, 9999, 0, 10)
+	assert.InDelta(t, 1.0, Quality(&item, testWeights, 10, now), 0.001)
+
+	item.Repository.Archived = github.BoolPtr(true)
+	assert.InDelta(t, 0.5, Quality(&item, testWeights, 10, now), 0.001)
+
+	item.Repository.Fork = github.BoolPtr(true)
+	assert.InDelta(t, 0.35, Quality(&item, testWeights, 10, now), 0.001)
+
+	// A year without pushes halves the recency signal
+	stale := rankedItem("acme/stale", 0, 0, 365, 0)
+	assert.InDelta(t, 0.3*0.5, Quality(&stale, testWeights, 10, now), 0.01)
+
+	assert.Zero(t, Quality(&stale, Weights{}, 10, now))
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// MaxQueryLength is the longest query GitHub code search accepts
+const MaxQueryLength = 256
+
 // QueryBuilder constructs GitHub search queries with filters
 type QueryBuilder struct {
 	terms       []string
@@ -210,6 +213,31 @@ func (qb *QueryBuilder) Build() string {
 	return strings.Join(parts, " ")
 }
 
+// ChunkRepositories splits repos into groups whose repo: qualifiers fit
+// alongside baseQuery within maxLength characters. A repository that cannot
+// fit even on its own still gets a group of its own.
+func ChunkRepositories(baseQuery string, repos []string, maxLength int) [][]string {
+	var chunks [][]string
+	var current []string
+	length := len(baseQuery)
+
+	for _, repo := range repos {
+		qualifier := len(" repo:") + len(repo)
+		if len(current) > 0 && length+qualifier > maxLength {
+			chunks = append(chunks, current)
+			current = nil
+			length = len(baseQuery)
+		}
+		current = append(current, repo)
+		length += qualifier
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
 // GetFilters returns the current filters as a SearchFilters struct
 func (qb *QueryBuilder) GetFilters() SearchFilters {
 	filters := SearchFilters{
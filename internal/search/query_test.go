@@ -505,3 +505,56 @@ func TestComplexQuery(t *testing.T) {
 	err := qb.Validate()
 	assert.NoError(t, err)
 }
+
+func TestChunkRepositories(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseQuery string
+		repos     []string
+		maxLength int
+		expected  [][]string
+	}{
+		{
+			name:      "all repositories fit in one query",
+			baseQuery: "config",
+			repos:     []string{"org/a", "org/b"},
+			maxLength: MaxQueryLength,
+			expected:  [][]string{{"org/a", "org/b"}},
+		},
+		{
+			name:      "repositories split across queries",
+			baseQuery: "config",                               // 6 chars
+			repos:     []string{"org/aa", "org/bb", "org/cc"}, // 12 chars each as " repo:org/aa"
+			maxLength: 30,
+			expected:  [][]string{{"org/aa", "org/bb"}, {"org/cc"}},
+		},
+		{
+			name:      "oversized repository gets its own chunk",
+			baseQuery: "config",
+			repos:     []string{"org/a", "org/a-very-long-repository-name", "org/b"},
+			maxLength: 25,
+			expected:  [][]string{{"org/a"}, {"org/a-very-long-repository-name"}, {"org/b"}},
+		},
+		{
+			name:      "no repositories",
+			baseQuery: "config",
+			repos:     nil,
+			maxLength: MaxQueryLength,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := ChunkRepositories(tt.baseQuery, tt.repos, tt.maxLength)
+			assert.Equal(t, tt.expected, chunks)
+
+			for _, chunk := range chunks {
+				if len(chunk) > 1 {
+					query := NewQueryBuilder([]string{tt.baseQuery}).WithRepositories(chunk).Build()
+					assert.LessOrEqual(t, len(query), tt.maxLength)
+				}
+			}
+		})
+	}
+}